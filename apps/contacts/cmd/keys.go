@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	appconfig "github.com/flockstore/mannaiah-backend/apps/contacts/config"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/repository"
+	commonconfig "github.com/flockstore/mannaiah-backend/common/config"
+	"github.com/flockstore/mannaiah-backend/common/database"
+	"github.com/flockstore/mannaiah-backend/common/encryption"
+)
+
+// keysUsage describes the keys subcommands.
+const keysUsage = `usage: keys <command> [flags]
+
+commands:
+  rotate     add a new encryption key to the key file and make it current
+  reencrypt  re-encrypt stored contacts with the current key
+`
+
+// runKeysCommand handles the "keys rotate" and "keys reencrypt" subcommands.
+// It reports false when args do not start with "keys".
+//
+// To rotate keys, run "keys rotate", restart every instance so new writes use
+// the new key, then run "keys reencrypt". Old keys must stay in the key file
+// until reencrypt completes.
+func runKeysCommand(args []string, configPath string, stdout, stderr io.Writer) (int, bool) {
+	if len(args) == 0 || args[0] != "keys" {
+		return commonconfig.ExitOK, false
+	}
+	if len(args) < 2 {
+		fmt.Fprint(stderr, keysUsage)
+		return commonconfig.ExitUsage, true
+	}
+
+	fs := flag.NewFlagSet("keys "+args[1], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&configPath, "config", configPath, "path of the YAML configuration file")
+	batch := fs.Int("batch", 500, "rows re-encrypted per batch")
+
+	switch args[1] {
+	case "rotate", "reencrypt":
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n%s", args[1], keysUsage)
+		return commonconfig.ExitUsage, true
+	}
+	if err := fs.Parse(args[2:]); err != nil {
+		return commonconfig.ExitUsage, true
+	}
+
+	cfg, _, err := commonconfig.Load[appconfig.Config](configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to load configuration: %v\n", err)
+		return commonconfig.ExitInvalid, true
+	}
+	if cfg.EncryptionKeyFile == "" {
+		fmt.Fprintln(stderr, "encryption_key_file is not set")
+		return commonconfig.ExitInvalid, true
+	}
+
+	if args[1] == "rotate" {
+		version, err := encryption.RotateKeyFile(cfg.EncryptionKeyFile)
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to rotate keys: %v\n", err)
+			return commonconfig.ExitInvalid, true
+		}
+		fmt.Fprintf(stdout, "key version %d is now current in %s; restart the service, then run \"keys reencrypt\"\n",
+			version, cfg.EncryptionKeyFile)
+		return commonconfig.ExitOK, true
+	}
+
+	keys, err := encryption.LoadKeyFile(cfg.EncryptionKeyFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to load encryption keys: %v\n", err)
+		return commonconfig.ExitInvalid, true
+	}
+
+	ctx := context.Background()
+	db, err := database.Connect(ctx, cfg.DatabaseConfig, nil)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to connect to database: %v\n", err)
+		return commonconfig.ExitInvalid, true
+	}
+	defer db.Close()
+
+	count, err := repository.Reencrypt(ctx, db, encryption.NewCipher(keys), *batch)
+	if err != nil {
+		fmt.Fprintf(stderr, "re-encryption stopped after %d contacts: %v\n", count, err)
+		return commonconfig.ExitInvalid, true
+	}
+	fmt.Fprintf(stdout, "re-encrypted %d contacts\n", count)
+	return commonconfig.ExitOK, true
+}
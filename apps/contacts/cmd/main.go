@@ -2,47 +2,201 @@ package main
 
 import (
 	"context"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
 	"github.com/flockstore/mannaiah-backend/apps/contacts/http"
 	"github.com/flockstore/mannaiah-backend/apps/contacts/repository"
 	"github.com/flockstore/mannaiah-backend/apps/contacts/service"
 	"github.com/gofiber/fiber/v2"
-	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 	"log"
+	"os"
+	"time"
 
 	appconfig "github.com/flockstore/mannaiah-backend/apps/contacts/config"
+	contactsv1 "github.com/flockstore/mannaiah-backend/apps/contacts/gen/contacts/v1"
+	contactgrpc "github.com/flockstore/mannaiah-backend/apps/contacts/grpc"
+	"github.com/flockstore/mannaiah-backend/common/auth"
 	commonconfig "github.com/flockstore/mannaiah-backend/common/config"
+	"github.com/flockstore/mannaiah-backend/common/database"
+	"github.com/flockstore/mannaiah-backend/common/encryption"
 	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/pii"
+	"github.com/flockstore/mannaiah-backend/common/ratelimit"
+	grpctransport "github.com/flockstore/mannaiah-backend/common/transport/grpc"
 	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
+	ggrpc "google.golang.org/grpc"
 )
 
 func main() {
+	if code, ok := commonconfig.RunCommand[appconfig.Config](os.Args[1:], "config.yaml", os.Stdout, os.Stderr); ok {
+		os.Exit(code)
+	}
+	if code, ok := runKeysCommand(os.Args[1:], "config.yaml", os.Stdout, os.Stderr); ok {
+		os.Exit(code)
+	}
 
-	cfg, _, err := commonconfig.Load[appconfig.Config]("config.yaml")
+	watcher, _, err := commonconfig.Watch[appconfig.Config]("config.yaml")
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
+	cfg := watcher.Get()
 
-	logg := logger.New(cfg.LogLevel, nil)
-
-	db, err := pgx.Connect(context.Background(), cfg.DatabaseURL)
+	initial, err := logger.ParseLevel(cfg.LogLevel)
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
+	level := zap.NewAtomicLevelAt(initial)
+	logg, err := logger.Build(logger.NewOptions(cfg.GlobalConfig, level))
+	if err != nil {
+		log.Fatalf("failed to initialize logger: %v", err)
+	}
+	zap.ReplaceGlobals(logg.Desugar())
+	pii.SetPolicy(pii.Policy(cfg.PIIMasking))
+	logg.Infow("configuration loaded", "config", cfg.String())
+
+	// The log level is reloaded first so later subscribers already log at the new level.
+	watcher.Subscribe(func(old, new *appconfig.Config) {
+		if old.LogLevel == new.LogLevel {
+			return
+		}
+		lvl, err := logger.ParseLevel(new.LogLevel)
+		if err != nil {
+			logg.Errorw("log level reload rejected", "error", err)
+			return
+		}
+		level.SetLevel(lvl)
+		logg.Infow("log level changed", "from", old.LogLevel, "to", new.LogLevel)
+	})
+	watcher.Subscribe(func(old, new *appconfig.Config) {
+		if old.PIIMasking != new.PIIMasking {
+			pii.SetPolicy(pii.Policy(new.PIIMasking))
+			logg.Infow("pii masking changed", "from", old.PIIMasking, "to", new.PIIMasking)
+		}
+	})
+
+	apiKeys, err := auth.ParseAPIKeys(cfg.APIKeys)
+	if err != nil {
+		log.Fatalf("failed to load API keys: %v", err)
+	}
+	if !apiKeys.Enabled() {
+		logg.Warn("no API keys configured, contacts API is not authenticated")
+	}
+
+	proxies, err := httptransport.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("failed to load trusted proxies: %v", err)
+	}
 
-	repo := repository.NewPostgresContactRepository(db)
-	svc := service.NewContactService(repo)
-	handler := http.New(svc, logg)
+	var (
+		db    *database.PgxClient
+		repo  domain.ContactRepository
+		tx    database.Transactor = database.NopTransactor{}
+		ready func() bool
+	)
+
+	switch cfg.Storage {
+	case appconfig.StorageMemory:
+		logg.Warn("using in-memory contact storage, data will be lost on restart")
+		repo = repository.NewMemoryContactRepository()
+	default:
+		keys, err := encryption.LoadKeyFile(cfg.EncryptionKeyFile)
+		if err != nil {
+			log.Fatalf("failed to load encryption keys: %v", err)
+		}
+
+		db, err = database.Open(context.Background(), cfg.DatabaseConfig, prometheus.DefaultRegisterer)
+		if err != nil {
+			log.Fatalf("failed to open database: %v", err)
+		}
+		defer db.Close()
+
+		// Servers start right away and report unready until the database answers.
+		go func() {
+			if err := db.Wait(context.Background()); err != nil {
+				logg.Fatalw("failed to connect to database", "error", err)
+			}
+			logg.Info("database connection established")
+		}()
+
+		repo, tx, ready = repository.NewPostgresContactRepository(db, encryption.NewCipher(keys)), db, db.Ready
+	}
+
+	svc := service.NewContactService(repo, tx)
+	handler := http.New(svc)
+
+	var contactsMiddlewares []fiber.Handler
+	if cfg.RateLimit.Enabled {
+		var store ratelimit.Store = ratelimit.NewMemoryStore()
+		if cfg.RateLimit.Store == "postgres" {
+			if db == nil {
+				log.Fatalf("postgres rate limit store requires %q storage", appconfig.StoragePostgres)
+			}
+			pg := ratelimit.NewPostgresStore(db)
+			go pg.PruneEvery(context.Background(), time.Minute, func(err error) {
+				logg.Warnw("rate limit counters prune failed", "error", err)
+			})
+			store = pg
+		}
+
+		opts, err := httptransport.NewRateLimitOptions("contacts", cfg.RateLimit, store)
+		if err != nil {
+			log.Fatalf("failed to configure rate limiting: %v", err)
+		}
+		limiter := httptransport.NewRateLimiter(opts)
+		contactsMiddlewares = append(contactsMiddlewares, limiter.Middleware())
+
+		watcher.Subscribe(func(_, new *appconfig.Config) {
+			opts, err := httptransport.NewRateLimitOptions("contacts", new.RateLimit, store)
+			if err != nil {
+				logg.Errorw("rate limit reload rejected", "error", err)
+				return
+			}
+			limiter.Update(opts)
+		})
+	}
 
 	srv := httptransport.New(httptransport.Options{
-		Port:   cfg.Port,
-		Logger: logg,
+		Port:              cfg.Port,
+		Logger:            logg,
+		AccessLogSampling: cfg.AccessLogSampling,
+		OpenAPI:           http.OpenAPI(),
+		Metrics:           prometheus.DefaultGatherer,
+		Ready:             ready,
+		LogLevel:          level,
+		AdminToken:        cfg.AdminToken,
+		APIKeys:           apiKeys,
+		TrustedProxies:    proxies,
+		ProxyHeader:       cfg.ProxyHeader,
 		Routes: func(router fiber.Router) {
-			handler.RegisterRoutes(router)
+			handler.RegisterRoutes(router.Group(http.BasePath, contactsMiddlewares...))
 		},
 	})
 
-	if err := srv.Start(context.Background()); err != nil {
-		logg.Fatal("server stopped with error", err)
-	}
+	grpcSrv := grpctransport.New(grpctransport.Options{
+		Port:    cfg.GRPCPort,
+		Logger:  logg,
+		Ready:   ready,
+		APIKeys: apiKeys,
+		Register: func(server *ggrpc.Server) {
+			contactsv1.RegisterContactServiceServer(server, contactgrpc.New(svc))
+		},
+	})
+
+	// Both servers stop on the same signal; the shared context also stops gRPC
+	// when HTTP exits first, and main waits for its graceful stop to finish.
+	ctx, cancel := context.WithCancel(context.Background())
+	grpcDone := make(chan struct{})
+	go func() {
+		defer close(grpcDone)
+		if err := grpcSrv.Start(ctx); err != nil {
+			logg.Errorw("gRPC server stopped with error", "error", err)
+		}
+	}()
 
+	if err := srv.Start(ctx); err != nil {
+		logg.Errorw("server stopped with error", "error", err)
+	}
+	cancel()
+	<-grpcDone
 }
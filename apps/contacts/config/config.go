@@ -1,9 +1,38 @@
 package config
 
+//go:generate sh -c "go run ../cmd config schema > config.schema.json"
+//go:generate sh -c "go run ../cmd config env > ENVIRONMENT.md"
+
 import "github.com/flockstore/mannaiah-backend/common/config"
 
+const (
+	// StoragePostgres persists contacts in PostgreSQL.
+	StoragePostgres = "postgres"
+
+	// StorageMemory keeps contacts in process memory; data is lost on restart.
+	StorageMemory = "memory"
+)
+
 // Config extends the shared GlobalConfig with service-specific settings.
 type Config struct {
 	config.GlobalConfig   `mapstructure:"server"`
 	config.DatabaseConfig `mapstructure:"database"`
+
+	// Storage selects the contact repository backend. The memory backend needs
+	// no database and is meant for demos and local experiments, so production
+	// refuses it.
+	Storage string `mapstructure:"storage" default:"postgres" validate:"oneof=postgres memory" validate_production:"ne=memory" reload:"restart"`
+
+	// EncryptionKeyFile is the local key file holding the keys that encrypt
+	// document numbers, emails and phones at rest. The postgres backend requires
+	// it; create or rotate it with `contacts keys rotate`.
+	EncryptionKeyFile string `mapstructure:"encryption_key_file" validate:"required_if=Storage postgres" reload:"restart"`
+
+	// RateLimit throttles the public contacts routes.
+	RateLimit config.RateLimitConfig `mapstructure:"contacts"`
+}
+
+// String renders the configuration with secrets redacted, safe for logging.
+func (c Config) String() string {
+	return config.String(c)
 }
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/common/config"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGeneratedReferenceInSync verifies the committed schema and env var
+// reference match the Config struct. Run `go generate ./config` after changing it.
+func TestGeneratedReferenceInSync(t *testing.T) {
+	schema, err := config.JSONSchema[Config]()
+	require.NoError(t, err)
+
+	want, err := os.ReadFile("config.schema.json")
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(schema), "config.schema.json is stale, run go generate ./config")
+
+	want, err = os.ReadFile("ENVIRONMENT.md")
+	require.NoError(t, err)
+	require.Equal(t, string(want), config.EnvReference[Config](), "ENVIRONMENT.md is stale, run go generate ./config")
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// ConsentPurpose is what a contact authorizes their data to be used for.
+type ConsentPurpose string
+
+const (
+	// PurposeMarketing covers commercial communications through a channel.
+	PurposeMarketing ConsentPurpose = "marketing"
+
+	// PurposeDataProcessing covers storing and processing personal data (Habeas Data authorization).
+	PurposeDataProcessing ConsentPurpose = "data_processing"
+
+	// PurposeProfiling covers building segments and profiles from the contact's data.
+	PurposeProfiling ConsentPurpose = "profiling"
+)
+
+// ConsentChannel is the medium a marketing consent applies to.
+type ConsentChannel string
+
+const (
+	// ChannelEmail reaches the contact by email.
+	ChannelEmail ConsentChannel = "email"
+
+	// ChannelSMS reaches the contact by text message.
+	ChannelSMS ConsentChannel = "sms"
+
+	// ChannelWhatsApp reaches the contact through WhatsApp.
+	ChannelWhatsApp ConsentChannel = "whatsapp"
+
+	// ChannelPhone reaches the contact by phone call.
+	ChannelPhone ConsentChannel = "phone"
+)
+
+// ConsentAction is the decision a consent record proves.
+type ConsentAction string
+
+const (
+	// ConsentGrant records that the contact gave consent.
+	ConsentGrant ConsentAction = "grant"
+
+	// ConsentRevoke records that the contact withdrew consent.
+	ConsentRevoke ConsentAction = "revoke"
+)
+
+// ConsentRecord is the auditable proof of a consent decision. Records are
+// append-only: the current state of a purpose and channel is given by its
+// latest record, and the full history is kept even after the contact is deleted.
+type ConsentRecord struct {
+	// ID is the unique identifier in the system.
+	ID string
+
+	// ContactID is the contact who made the decision.
+	ContactID string
+
+	// Purpose is what the decision applies to.
+	Purpose ConsentPurpose
+
+	// Channel is the medium of marketing decisions; empty for other purposes.
+	Channel ConsentChannel
+
+	// Action is whether consent was granted or revoked.
+	Action ConsentAction
+
+	// Source is where the decision was collected (e.g. "web_form", "pos").
+	Source string
+
+	// PolicyVersion is the version of the privacy policy the contact was shown.
+	PolicyVersion string
+
+	// IP is the address the decision was made from.
+	IP string `pii:"true"`
+
+	// RecordedAt is when the decision was recorded.
+	RecordedAt time.Time
+}
+
+// ConsentState is the current decision of a contact for a purpose and channel.
+type ConsentState struct {
+	// Purpose is what the decision applies to.
+	Purpose ConsentPurpose
+
+	// Channel is the medium of marketing decisions; empty for other purposes.
+	Channel ConsentChannel
+
+	// Granted reports whether consent currently holds.
+	Granted bool
+
+	// PolicyVersion is the policy version of the latest decision.
+	PolicyVersion string
+
+	// UpdatedAt is when the latest decision was recorded.
+	UpdatedAt time.Time
+}
+
+// Valid reports whether p is a known purpose.
+func (p ConsentPurpose) Valid() bool {
+	return p == PurposeMarketing || p == PurposeDataProcessing || p == PurposeProfiling
+}
+
+// Valid reports whether c is a known channel.
+func (c ConsentChannel) Valid() bool {
+	return c == ChannelEmail || c == ChannelSMS || c == ChannelWhatsApp || c == ChannelPhone
+}
+
+// Validate checks the record purpose, channel and proof fields.
+// Marketing decisions need a channel; other purposes take none.
+func (r *ConsentRecord) Validate() error {
+	invalid := func(reason string) error {
+		return ErrInvalidConsent.WithDetails(map[string]string{"purpose": string(r.Purpose), "reason": reason})
+	}
+
+	if !r.Purpose.Valid() {
+		return invalid("unknown purpose")
+	}
+	if r.Purpose == PurposeMarketing && !r.Channel.Valid() {
+		return invalid("marketing consent needs a channel")
+	}
+	if r.Purpose != PurposeMarketing && r.Channel != "" {
+		return invalid("only marketing consent applies to a channel")
+	}
+	if r.Action != ConsentGrant && r.Action != ConsentRevoke {
+		return invalid("unknown action")
+	}
+	if strings.TrimSpace(r.Source) == "" || strings.TrimSpace(r.PolicyVersion) == "" {
+		return invalid("source and policy version are required")
+	}
+	return nil
+}
+
+// CurrentConsents folds records, in any order, into the latest decision of
+// each purpose and channel, ordered by purpose then channel.
+func CurrentConsents(records []*ConsentRecord) []ConsentState {
+	type key struct {
+		purpose ConsentPurpose
+		channel ConsentChannel
+	}
+
+	latest := make(map[key]*ConsentRecord)
+	for _, r := range records {
+		k := key{r.Purpose, r.Channel}
+		if prev, ok := latest[k]; !ok || laterConsent(r, prev) {
+			latest[k] = r
+		}
+	}
+
+	states := make([]ConsentState, 0, len(latest))
+	for _, r := range latest {
+		states = append(states, ConsentState{
+			Purpose:       r.Purpose,
+			Channel:       r.Channel,
+			Granted:       r.Action == ConsentGrant,
+			PolicyVersion: r.PolicyVersion,
+			UpdatedAt:     r.RecordedAt,
+		})
+	}
+	slices.SortFunc(states, func(a, b ConsentState) int {
+		if c := strings.Compare(string(a.Purpose), string(b.Purpose)); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.Channel), string(b.Channel))
+	})
+	return states
+}
+
+// laterConsent reports whether a was recorded after b, breaking ties by ID.
+func laterConsent(a, b *ConsentRecord) bool {
+	if c := a.RecordedAt.Compare(b.RecordedAt); c != 0 {
+		return c > 0
+	}
+	return a.ID > b.ID
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConsentRecord_Validate verifies marketing needs a channel and proof fields are required.
+func TestConsentRecord_Validate(t *testing.T) {
+	valid := []ConsentRecord{
+		{Purpose: PurposeMarketing, Channel: ChannelEmail, Action: ConsentGrant, Source: "web_form", PolicyVersion: "v1"},
+		{Purpose: PurposeDataProcessing, Action: ConsentRevoke, Source: "pos", PolicyVersion: "v1"},
+	}
+	for _, r := range valid {
+		assert.NoError(t, r.Validate(), r.Purpose)
+	}
+
+	invalid := []ConsentRecord{
+		{Purpose: "newsletter", Action: ConsentGrant, Source: "pos", PolicyVersion: "v1"},
+		{Purpose: PurposeMarketing, Action: ConsentGrant, Source: "pos", PolicyVersion: "v1"},
+		{Purpose: PurposeProfiling, Channel: ChannelSMS, Action: ConsentGrant, Source: "pos", PolicyVersion: "v1"},
+		{Purpose: PurposeProfiling, Action: "maybe", Source: "pos", PolicyVersion: "v1"},
+		{Purpose: PurposeProfiling, Action: ConsentGrant, Source: " ", PolicyVersion: "v1"},
+	}
+	for _, r := range invalid {
+		assert.ErrorIs(t, r.Validate(), ErrInvalidConsent, r.Purpose)
+	}
+}
+
+// TestCurrentConsents verifies the latest record of each purpose and channel wins.
+func TestCurrentConsents(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := []*ConsentRecord{
+		{ID: "3", Purpose: PurposeMarketing, Channel: ChannelSMS, Action: ConsentRevoke, PolicyVersion: "v2", RecordedAt: t0.Add(2 * time.Hour)},
+		{ID: "1", Purpose: PurposeMarketing, Channel: ChannelSMS, Action: ConsentGrant, PolicyVersion: "v1", RecordedAt: t0},
+		{ID: "2", Purpose: PurposeMarketing, Channel: ChannelEmail, Action: ConsentGrant, PolicyVersion: "v1", RecordedAt: t0.Add(time.Hour)},
+		{ID: "4", Purpose: PurposeDataProcessing, Action: ConsentGrant, PolicyVersion: "v2", RecordedAt: t0},
+	}
+
+	assert.Equal(t, []ConsentState{
+		{Purpose: PurposeDataProcessing, Granted: true, PolicyVersion: "v2", UpdatedAt: t0},
+		{Purpose: PurposeMarketing, Channel: ChannelEmail, Granted: true, PolicyVersion: "v1", UpdatedAt: t0.Add(time.Hour)},
+		{Purpose: PurposeMarketing, Channel: ChannelSMS, Granted: false, PolicyVersion: "v2", UpdatedAt: t0.Add(2 * time.Hour)},
+	}, CurrentConsents(records))
+	assert.Empty(t, CurrentConsents(nil))
+}
@@ -17,41 +17,58 @@ const (
 )
 
 // Contact is the aggregate root representing a legal or natural entity.
+// Fields tagged pii hold personal data and are masked when logged.
 type Contact struct {
 	domain.Auditable
 
 	// ID is the unique identifier in the system.
 	ID string
 
+	// Tenant owns the contact. Contacts are only visible to their tenant, and
+	// documents are unique per tenant.
+	Tenant string
+
 	// DocumentType defines the kind of document used.
 	DocumentType DocumentType
 
 	// DocumentNumber is the unique ID number (digits only).
-	DocumentNumber string
+	DocumentNumber string `pii:"document"`
 
 	// LegalName is used for entities with NIT (e.g. business).
 	LegalName string
 
 	// FirstName is used if the contact is a natural person.
-	FirstName string
+	FirstName string `pii:"name"`
 
 	// LastName is used if the contact is a natural person.
-	LastName string
+	LastName string `pii:"name"`
 
 	// Email is the main contact email.
-	Email string
+	Email string `pii:"email"`
 
 	// Phone is the main contact phone number.
-	Phone string
+	Phone string `pii:"phone"`
 
 	// Address is the main physical address.
-	Address string
+	Address string `pii:"address"`
 
 	// AddressExtra is a complement (e.g. apartment, floor).
-	AddressExtra string
+	AddressExtra string `pii:"address"`
 
 	// CityCode represents the city or town code (e.g. DANE).
 	CityCode string
+
+	// CustomFields holds tenant-defined values keyed by FieldDefinition.Key.
+	// Values are validated against the definitions of the requesting tenant.
+	CustomFields map[string]any
+
+	// Tags are the catalog tags linked to the contact, ordered by name.
+	// They are read-only on the contact; use the tag operations to change them.
+	Tags []Tag
+
+	// Consents is the current consent state, derived from the consent history.
+	// It is read-only on the contact; use the consent operations to change it.
+	Consents []ConsentState
 }
 
 // ValidateNames  check if name combination is correct
@@ -0,0 +1,339 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/flockstore/mannaiah-backend/common/domain"
+)
+
+// FieldType is the value type of a custom field.
+type FieldType string
+
+const (
+	// FieldString holds free text.
+	FieldString FieldType = "string"
+
+	// FieldNumber holds a JSON number.
+	FieldNumber FieldType = "number"
+
+	// FieldDate holds a calendar date formatted as DateLayout.
+	FieldDate FieldType = "date"
+
+	// FieldEnum holds one of the options listed in the field rules.
+	FieldEnum FieldType = "enum"
+
+	// FieldBool holds true or false.
+	FieldBool FieldType = "bool"
+)
+
+// DateLayout is the format of FieldDate values (e.g. "1990-05-17").
+const DateLayout = time.DateOnly
+
+// fieldKeyPattern restricts custom field keys to lowercase snake_case.
+var fieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// FieldRules constrains custom field values. Rules that do not apply to the
+// field type are rejected when the definition is created.
+type FieldRules struct {
+	// MinLength is the minimum number of characters of string values.
+	MinLength *int `json:"minLength,omitempty"`
+
+	// MaxLength is the maximum number of characters of string values.
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// Pattern is a regular expression string values must match.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Min is the lowest accepted number.
+	Min *float64 `json:"min,omitempty"`
+
+	// Max is the highest accepted number.
+	Max *float64 `json:"max,omitempty"`
+
+	// Options lists the accepted values of enum fields.
+	Options []string `json:"options,omitempty"`
+}
+
+// FieldDefinition describes a custom field a tenant stores on its contacts
+// (e.g. birthday, shoe size, preferred store).
+type FieldDefinition struct {
+	domain.Auditable
+
+	// ID is the unique identifier in the system.
+	ID string
+
+	// Tenant owns the definition; definitions of other tenants are invisible.
+	Tenant string
+
+	// Key names the field inside Contact.CustomFields.
+	Key string
+
+	// Type is the value type.
+	Type FieldType
+
+	// Required makes contacts without a value invalid.
+	Required bool
+
+	// Rules constrains accepted values.
+	Rules FieldRules
+}
+
+// CustomFieldError describes a custom field value that failed validation.
+// It mirrors the field errors of request validation so clients handle both alike.
+type CustomFieldError struct {
+	// Field is the path of the value (e.g. "customFields.shoe_size").
+	Field string `json:"field"`
+
+	// Rule is the rule that failed (e.g. "max").
+	Rule string `json:"rule"`
+
+	// Param is the rule parameter, if any (e.g. "50").
+	Param string `json:"param,omitempty"`
+}
+
+// Validate checks the definition is well formed.
+func (d *FieldDefinition) Validate() error {
+	invalid := func(reason string) error {
+		return ErrInvalidFieldDefinition.WithDetails(map[string]string{"key": d.Key, "reason": reason})
+	}
+
+	if !fieldKeyPattern.MatchString(d.Key) {
+		return invalid("key must be lowercase snake_case of at most 63 characters")
+	}
+
+	r := d.Rules
+	lengths := r.MinLength != nil || r.MaxLength != nil || r.Pattern != ""
+	bounds := r.Min != nil || r.Max != nil
+	switch d.Type {
+	case FieldString:
+		if bounds || len(r.Options) > 0 {
+			return invalid("string fields accept minLength, maxLength and pattern only")
+		}
+		if r.Pattern != "" {
+			if _, err := regexp.Compile(r.Pattern); err != nil {
+				return invalid("pattern is not a valid regular expression")
+			}
+		}
+		if r.MinLength != nil && r.MaxLength != nil && *r.MinLength > *r.MaxLength {
+			return invalid("minLength is greater than maxLength")
+		}
+	case FieldNumber:
+		if lengths || len(r.Options) > 0 {
+			return invalid("number fields accept min and max only")
+		}
+		if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+			return invalid("min is greater than max")
+		}
+	case FieldEnum:
+		if lengths || bounds {
+			return invalid("enum fields accept options only")
+		}
+		if len(r.Options) == 0 {
+			return invalid("enum fields need options")
+		}
+	case FieldDate, FieldBool:
+		if lengths || bounds || len(r.Options) > 0 {
+			return invalid(string(d.Type) + " fields accept no rules")
+		}
+	default:
+		return invalid("unknown type")
+	}
+	return nil
+}
+
+// Check validates value against the definition and returns it normalized:
+// numbers as float64, dates as DateLayout strings. It returns the failed rule
+// and its parameter, or an empty rule when value is valid.
+func (d *FieldDefinition) Check(value any) (any, string, string) {
+	r := d.Rules
+	switch d.Type {
+	case FieldString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, "type", string(d.Type)
+		}
+		n := utf8.RuneCountInString(s)
+		if r.MinLength != nil && n < *r.MinLength {
+			return nil, "min", strconv.Itoa(*r.MinLength)
+		}
+		if r.MaxLength != nil && n > *r.MaxLength {
+			return nil, "max", strconv.Itoa(*r.MaxLength)
+		}
+		if r.Pattern != "" && !regexp.MustCompile(r.Pattern).MatchString(s) {
+			return nil, "pattern", r.Pattern
+		}
+		return s, "", ""
+	case FieldNumber:
+		f, ok := toFloat(value)
+		if !ok {
+			return nil, "type", string(d.Type)
+		}
+		if r.Min != nil && f < *r.Min {
+			return nil, "gte", formatFloat(*r.Min)
+		}
+		if r.Max != nil && f > *r.Max {
+			return nil, "lte", formatFloat(*r.Max)
+		}
+		return f, "", ""
+	case FieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, "type", string(d.Type)
+		}
+		if _, err := time.Parse(DateLayout, s); err != nil {
+			return nil, "date", DateLayout
+		}
+		return s, "", ""
+	case FieldEnum:
+		s, ok := value.(string)
+		if !ok || !slices.Contains(r.Options, s) {
+			return nil, "oneof", fmt.Sprint(r.Options)
+		}
+		return s, "", ""
+	case FieldBool:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, "type", string(d.Type)
+		}
+		return b, "", ""
+	}
+	return nil, "type", string(d.Type)
+}
+
+// Parse converts a raw query string value into the field type, for filters.
+func (d *FieldDefinition) Parse(raw string) (any, error) {
+	var value any = raw
+	switch d.Type {
+	case FieldNumber:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, invalidCustomField(d.Key, "type", string(d.Type))
+		}
+		value = f
+	case FieldBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, invalidCustomField(d.Key, "type", string(d.Type))
+		}
+		value = b
+	}
+
+	normalized, rule, param := d.Check(value)
+	if rule != "" {
+		return nil, invalidCustomField(d.Key, rule, param)
+	}
+	return normalized, nil
+}
+
+// ParseCustomFieldFilter converts filter values to their field types so they
+// compare equal to stored values. Strings, as received from query parameters,
+// are parsed; other values are checked as is. Unknown keys are rejected.
+func ParseCustomFieldFilter(defs []*FieldDefinition, values map[string]any) (map[string]any, error) {
+	byKey := make(map[string]*FieldDefinition, len(defs))
+	for _, d := range defs {
+		byKey[d.Key] = d
+	}
+
+	out := make(map[string]any, len(values))
+	for key, value := range values {
+		d, ok := byKey[key]
+		if !ok {
+			return nil, invalidCustomField(key, "unknown", "")
+		}
+
+		if raw, isString := value.(string); isString {
+			parsed, err := d.Parse(raw)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = parsed
+			continue
+		}
+
+		normalized, rule, param := d.Check(value)
+		if rule != "" {
+			return nil, invalidCustomField(key, rule, param)
+		}
+		out[key] = normalized
+	}
+	return out, nil
+}
+
+// ValidateCustomFields checks values against the tenant definitions and
+// returns them normalized. Unknown keys, missing required fields and invalid
+// values are all reported at once through ErrInvalidCustomFields.
+func ValidateCustomFields(defs []*FieldDefinition, values map[string]any) (map[string]any, error) {
+	byKey := make(map[string]*FieldDefinition, len(defs))
+	for _, d := range defs {
+		byKey[d.Key] = d
+	}
+
+	var errs []CustomFieldError
+	out := make(map[string]any, len(values))
+	for key, value := range values {
+		d, ok := byKey[key]
+		if !ok {
+			errs = append(errs, CustomFieldError{Field: fieldPath(key), Rule: "unknown"})
+			continue
+		}
+		normalized, rule, param := d.Check(value)
+		if rule != "" {
+			errs = append(errs, CustomFieldError{Field: fieldPath(key), Rule: rule, Param: param})
+			continue
+		}
+		out[key] = normalized
+	}
+
+	for _, d := range defs {
+		if _, ok := values[d.Key]; d.Required && !ok {
+			errs = append(errs, CustomFieldError{Field: fieldPath(d.Key), Rule: "required"})
+		}
+	}
+
+	if len(errs) > 0 {
+		slices.SortFunc(errs, func(a, b CustomFieldError) int {
+			return strings.Compare(a.Field, b.Field)
+		})
+		return nil, ErrInvalidCustomFields.WithDetails(errs)
+	}
+	return out, nil
+}
+
+// invalidCustomField reports a single invalid custom field through ErrInvalidCustomFields.
+func invalidCustomField(key, rule, param string) error {
+	return ErrInvalidCustomFields.WithDetails([]CustomFieldError{{Field: fieldPath(key), Rule: rule, Param: param}})
+}
+
+// fieldPath returns the request path of a custom field key.
+func fieldPath(key string) string {
+	return "customFields." + key
+}
+
+// toFloat converts JSON and Go numbers to float64.
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// formatFloat renders a rule bound without trailing zeros.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// testDefinitions returns a schema with one field of each kind used by the tests.
+func testDefinitions() []*FieldDefinition {
+	return []*FieldDefinition{
+		{Key: "birthday", Type: FieldDate},
+		{Key: "shoe_size", Type: FieldNumber, Required: true, Rules: FieldRules{Min: ptr(20.0), Max: ptr(50.0)}},
+		{Key: "store", Type: FieldEnum, Rules: FieldRules{Options: []string{"north", "south"}}},
+		{Key: "nickname", Type: FieldString, Rules: FieldRules{MaxLength: ptr(5)}},
+		{Key: "newsletter", Type: FieldBool},
+	}
+}
+
+// TestFieldDefinition_Validate verifies keys and rules are checked against the field type.
+func TestFieldDefinition_Validate(t *testing.T) {
+	for _, d := range testDefinitions() {
+		assert.NoError(t, d.Validate(), d.Key)
+	}
+
+	invalid := []FieldDefinition{
+		{Key: "Shoe Size", Type: FieldNumber},
+		{Key: "size", Type: "color"},
+		{Key: "size", Type: FieldNumber, Rules: FieldRules{MaxLength: ptr(3)}},
+		{Key: "size", Type: FieldNumber, Rules: FieldRules{Min: ptr(5.0), Max: ptr(1.0)}},
+		{Key: "store", Type: FieldEnum},
+		{Key: "code", Type: FieldString, Rules: FieldRules{Pattern: "("}},
+		{Key: "active", Type: FieldBool, Rules: FieldRules{Options: []string{"yes"}}},
+	}
+	for _, d := range invalid {
+		assert.ErrorIs(t, d.Validate(), ErrInvalidFieldDefinition, d.Key)
+	}
+}
+
+// TestValidateCustomFields_Normalizes verifies valid values are returned normalized.
+func TestValidateCustomFields_Normalizes(t *testing.T) {
+	got, err := ValidateCustomFields(testDefinitions(), map[string]any{
+		"birthday":   "1990-05-17",
+		"shoe_size":  42,
+		"store":      "north",
+		"newsletter": true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"birthday":   "1990-05-17",
+		"shoe_size":  42.0,
+		"store":      "north",
+		"newsletter": true,
+	}, got)
+}
+
+// TestValidateCustomFields_ReportsEveryError verifies all failures are listed, sorted by field.
+func TestValidateCustomFields_ReportsEveryError(t *testing.T) {
+	_, err := ValidateCustomFields(testDefinitions(), map[string]any{
+		"birthday": "17/05/1990",
+		"store":    "east",
+		"nickname": "Bartholomew",
+		"color":    "red",
+	})
+	require.ErrorIs(t, err, ErrInvalidCustomFields)
+	assert.Equal(t, []CustomFieldError{
+		{Field: "customFields.birthday", Rule: "date", Param: DateLayout},
+		{Field: "customFields.color", Rule: "unknown"},
+		{Field: "customFields.nickname", Rule: "max", Param: "5"},
+		{Field: "customFields.shoe_size", Rule: "required"},
+		{Field: "customFields.store", Rule: "oneof", Param: "[north south]"},
+	}, apperrors.From(err).Details)
+}
+
+// TestParseCustomFieldFilter verifies query strings are converted to the field types.
+func TestParseCustomFieldFilter(t *testing.T) {
+	got, err := ParseCustomFieldFilter(testDefinitions(), map[string]any{"shoe_size": "42", "newsletter": "true", "store": "south"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"shoe_size": 42.0, "newsletter": true, "store": "south"}, got)
+
+	_, err = ParseCustomFieldFilter(testDefinitions(), map[string]any{"shoe_size": "large"})
+	assert.ErrorIs(t, err, ErrInvalidCustomFields)
+
+	_, err = ParseCustomFieldFilter(testDefinitions(), map[string]any{"color": "red"})
+	assert.ErrorIs(t, err, ErrInvalidCustomFields)
+}
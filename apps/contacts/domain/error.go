@@ -1,15 +1,52 @@
 package domain
 
-import "errors"
+import apperrors "github.com/flockstore/mannaiah-backend/common/errors"
 
 // ErrContactNotFound is returned when a contact is not found in the repository.
-var ErrContactNotFound = errors.New("contact not found")
+var ErrContactNotFound = apperrors.NotFound("contact.not_found", "contact not found")
 
 // ErrDuplicateDocument is returned when a contact with the same document number already exists.
-var ErrDuplicateDocument = errors.New("duplicate document number")
+var ErrDuplicateDocument = apperrors.Conflict("contact.duplicate_document", "duplicate document number")
 
 // ErrInvalidNameCombination is returned when both legal_name and (first_name + last_name) are set.
-var ErrInvalidNameCombination = errors.New("invalid name combination: choose either legal_name or first+last name")
+var ErrInvalidNameCombination = apperrors.InvalidArgument("contact.invalid_name_combination", "invalid name combination: choose either legal_name or first+last name")
 
 // ErrMissingName is returned when neither legal_name nor first_name+last_name are provided.
-var ErrMissingName = errors.New("missing required name: provide legal_name or first+last name")
+var ErrMissingName = apperrors.InvalidArgument("contact.missing_name", "missing required name: provide legal_name or first+last name")
+
+// ErrTagNotFound is returned when a tag does not exist in the catalog.
+var ErrTagNotFound = apperrors.NotFound("tag.not_found", "tag not found")
+
+// ErrDuplicateTag is returned when a tag with the same name already exists.
+var ErrDuplicateTag = apperrors.Conflict("tag.duplicate_name", "duplicate tag name")
+
+// ErrInvalidTagName is returned when a tag name is blank.
+var ErrInvalidTagName = apperrors.InvalidArgument("tag.invalid_name", "tag name must not be empty")
+
+// ErrEmptyFilter is returned when a bulk operation is requested without any filter criteria.
+var ErrEmptyFilter = apperrors.InvalidArgument("contact.empty_filter", "bulk operations require a filter")
+
+// ErrInvalidCustomFields is returned when custom field values do not match their definitions.
+// Its details list every offending field.
+var ErrInvalidCustomFields = apperrors.InvalidArgument("contact.invalid_custom_fields", "invalid custom fields")
+
+// ErrInvalidFieldDefinition is returned when a custom field definition is malformed.
+var ErrInvalidFieldDefinition = apperrors.InvalidArgument("field.invalid_definition", "invalid custom field definition")
+
+// ErrDuplicateFieldDefinition is returned when the tenant already defines a field with the same key.
+var ErrDuplicateFieldDefinition = apperrors.Conflict("field.duplicate_key", "duplicate custom field key")
+
+// ErrFieldDefinitionNotFound is returned when a custom field definition does not exist for the tenant.
+var ErrFieldDefinitionNotFound = apperrors.NotFound("field.not_found", "custom field not found")
+
+// ErrInvalidRelationship is returned when a relationship does not fit the contacts it links.
+var ErrInvalidRelationship = apperrors.InvalidArgument("relationship.invalid", "invalid relationship")
+
+// ErrDuplicateRelationship is returned when both contacts are already linked with the same type.
+var ErrDuplicateRelationship = apperrors.Conflict("relationship.duplicate", "duplicate relationship")
+
+// ErrRelationshipNotFound is returned when a relationship does not exist for the contact.
+var ErrRelationshipNotFound = apperrors.NotFound("relationship.not_found", "relationship not found")
+
+// ErrInvalidConsent is returned when a consent record is malformed.
+var ErrInvalidConsent = apperrors.InvalidArgument("consent.invalid", "invalid consent")
@@ -8,25 +8,28 @@ type ContactPatch struct {
 	LegalName *string
 
 	// FirstName is the new first name (optional).
-	FirstName *string
+	FirstName *string `pii:"name"`
 
 	// LastName is the new last name (optional).
-	LastName *string
+	LastName *string `pii:"name"`
 
 	// Address is the main address (optional).
-	Address *string
+	Address *string `pii:"address"`
 
 	// AddressExtra is a complementary address field (optional).
-	AddressExtra *string
+	AddressExtra *string `pii:"address"`
 
 	// CityCode is the city or town code (optional).
 	CityCode *string
 
 	// Phone is the updated phone number (optional).
-	Phone *string
+	Phone *string `pii:"phone"`
 
 	// Email is the updated email address (optional).
-	Email *string
+	Email *string `pii:"email"`
+
+	// CustomFields are merged into the existing values; keys set to nil are removed (optional).
+	CustomFields map[string]any
 }
 
 // ApplyPatch applies only the non-nil fields from a ContactPatch into the given Contact.
@@ -60,4 +63,18 @@ func ApplyPatch(c *Contact, patch *ContactPatch) {
 	if patch.Email != nil {
 		c.Email = *patch.Email
 	}
+	if patch.CustomFields != nil {
+		merged := make(map[string]any, len(c.CustomFields)+len(patch.CustomFields))
+		for k, v := range c.CustomFields {
+			merged[k] = v
+		}
+		for k, v := range patch.CustomFields {
+			if v == nil {
+				delete(merged, k)
+				continue
+			}
+			merged[k] = v
+		}
+		c.CustomFields = merged
+	}
 }
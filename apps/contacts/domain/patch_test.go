@@ -58,3 +58,14 @@ func TestApplyPatchContact(t *testing.T) {
 	equal, err := testutil.AssertPatchedFieldsEqual(original, expected)
 	require.True(t, equal, "Patch application failed: %v", err)
 }
+
+// TestApplyPatchContact_CustomFields ensures custom fields are merged and null values remove keys.
+func TestApplyPatchContact_CustomFields(t *testing.T) {
+	original := Contact{CustomFields: map[string]any{"shoe_size": 42.0, "store": "north"}}
+
+	ApplyPatch(&original, &ContactPatch{CustomFields: map[string]any{"store": nil, "birthday": "1990-05-17"}})
+	require.Equal(t, map[string]any{"shoe_size": 42.0, "birthday": "1990-05-17"}, original.CustomFields)
+
+	ApplyPatch(&original, &ContactPatch{})
+	require.Len(t, original.CustomFields, 2)
+}
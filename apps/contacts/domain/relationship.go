@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/domain"
+)
+
+// RelationType is the kind of link between two contacts. Links are directed:
+// the subject (From) relates to the object (To).
+type RelationType string
+
+const (
+	// RelationEmployeeOf links a person to the company employing them.
+	RelationEmployeeOf RelationType = "employee_of"
+
+	// RelationLegalRepresentativeOf links a person to the company they legally represent.
+	RelationLegalRepresentativeOf RelationType = "legal_representative_of"
+
+	// RelationBillingContactFor links a person to the company they receive invoices for.
+	RelationBillingContactFor RelationType = "billing_contact_for"
+
+	// RelationParentCompanyOf links a company to one of its subsidiaries.
+	RelationParentCompanyOf RelationType = "parent_company_of"
+)
+
+// Valid reports whether t is a known relation type.
+func (t RelationType) Valid() bool {
+	switch t {
+	case RelationEmployeeOf, RelationLegalRepresentativeOf, RelationBillingContactFor, RelationParentCompanyOf:
+		return true
+	}
+	return false
+}
+
+// PersonToCompany reports whether t links a natural person to a company.
+// Every other type links two companies.
+func (t RelationType) PersonToCompany() bool {
+	return t == RelationEmployeeOf || t == RelationLegalRepresentativeOf || t == RelationBillingContactFor
+}
+
+// Relationship is a typed, directed link between two contacts, such as a
+// person being the purchasing manager of a company. Relationships are removed
+// when either contact is deleted.
+type Relationship struct {
+	domain.Auditable
+
+	// ID is the unique identifier in the system.
+	ID string
+
+	// Type is the kind of link.
+	Type RelationType
+
+	// FromID is the subject contact: the person, or the parent company.
+	FromID string
+
+	// ToID is the object contact: the company, or the subsidiary.
+	ToID string
+
+	// Role describes the subject's position (e.g. "purchasing manager").
+	Role string
+
+	// ValidFrom is the first day the relationship holds; nil means since always.
+	ValidFrom *time.Time
+
+	// ValidUntil is the last day the relationship holds; nil means open-ended.
+	ValidUntil *time.Time
+}
+
+// RelatedContact pairs a relationship with the contact on its other side.
+type RelatedContact struct {
+	// Relationship is the link between both contacts.
+	Relationship *Relationship
+
+	// Contact is the related contact.
+	Contact *Contact
+}
+
+// IsLegalEntity reports whether the contact is a company rather than a natural person.
+func (c *Contact) IsLegalEntity() bool {
+	return c.LegalName != ""
+}
+
+// Other returns the ID of the contact on the other side of contactID.
+func (r *Relationship) Other(contactID string) string {
+	if r.FromID == contactID {
+		return r.ToID
+	}
+	return r.FromID
+}
+
+// ActiveOn reports whether the relationship holds on the day of at.
+func (r *Relationship) ActiveOn(at time.Time) bool {
+	day := at.Format(time.DateOnly)
+	if r.ValidFrom != nil && day < r.ValidFrom.Format(time.DateOnly) {
+		return false
+	}
+	if r.ValidUntil != nil && day > r.ValidUntil.Format(time.DateOnly) {
+		return false
+	}
+	return true
+}
+
+// Validate checks the relationship type, its dates and that from and to are
+// the kind of contacts the type links.
+func (r *Relationship) Validate(from, to *Contact) error {
+	invalid := func(reason string) error {
+		return ErrInvalidRelationship.WithDetails(map[string]string{"type": string(r.Type), "reason": reason})
+	}
+
+	if !r.Type.Valid() {
+		return invalid("unknown type")
+	}
+	if from.ID == to.ID {
+		return invalid("a contact cannot be related to itself")
+	}
+	if r.ValidFrom != nil && r.ValidUntil != nil && r.ValidUntil.Before(*r.ValidFrom) {
+		return invalid("validUntil is before validFrom")
+	}
+	if !to.IsLegalEntity() {
+		return invalid("the related contact must be a company")
+	}
+	if r.Type.PersonToCompany() && from.IsLegalEntity() {
+		return invalid("the contact must be a person")
+	}
+	if !r.Type.PersonToCompany() && !from.IsLegalEntity() {
+		return invalid("the contact must be a company")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// date returns a pointer to the given YYYY-MM-DD day.
+func date(s string) *time.Time {
+	t, _ := time.Parse(time.DateOnly, s)
+	return &t
+}
+
+// TestRelationship_Validate verifies types link the right kind of contacts.
+func TestRelationship_Validate(t *testing.T) {
+	person := &Contact{ID: "p1", FirstName: "Ana", LastName: "Gomez"}
+	company := &Contact{ID: "c1", LegalName: "Flock S.A.S."}
+	subsidiary := &Contact{ID: "c2", LegalName: "Flock Retail S.A.S."}
+
+	valid := []struct {
+		rel      Relationship
+		from, to *Contact
+	}{
+		{Relationship{Type: RelationEmployeeOf, Role: "purchasing manager"}, person, company},
+		{Relationship{Type: RelationLegalRepresentativeOf, ValidFrom: date("2024-01-01"), ValidUntil: date("2024-01-01")}, person, company},
+		{Relationship{Type: RelationBillingContactFor}, person, company},
+		{Relationship{Type: RelationParentCompanyOf}, company, subsidiary},
+	}
+	for _, tc := range valid {
+		assert.NoError(t, tc.rel.Validate(tc.from, tc.to), tc.rel.Type)
+	}
+
+	invalid := []struct {
+		rel      Relationship
+		from, to *Contact
+	}{
+		{Relationship{Type: "friend_of"}, person, company},
+		{Relationship{Type: RelationEmployeeOf}, person, person},
+		{Relationship{Type: RelationEmployeeOf}, company, subsidiary},
+		{Relationship{Type: RelationParentCompanyOf}, person, company},
+		{Relationship{Type: RelationParentCompanyOf}, company, company},
+		{Relationship{Type: RelationEmployeeOf, ValidFrom: date("2024-02-01"), ValidUntil: date("2024-01-01")}, person, company},
+	}
+	for _, tc := range invalid {
+		assert.ErrorIs(t, tc.rel.Validate(tc.from, tc.to), ErrInvalidRelationship, tc.rel.Type)
+	}
+}
+
+// TestRelationship_ActiveOn verifies validity dates are inclusive and optional.
+func TestRelationship_ActiveOn(t *testing.T) {
+	r := Relationship{ValidFrom: date("2024-01-01"), ValidUntil: date("2024-12-31")}
+	assert.False(t, r.ActiveOn(*date("2023-12-31")))
+	assert.True(t, r.ActiveOn(*date("2024-01-01")))
+	assert.True(t, r.ActiveOn(date("2024-12-31").Add(23*time.Hour)))
+	assert.False(t, r.ActiveOn(*date("2025-01-01")))
+	assert.True(t, (&Relationship{}).ActiveOn(time.Now()))
+}
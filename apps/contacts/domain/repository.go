@@ -1,19 +1,71 @@
 package domain
 
+import "context"
+
 // ContactRepository defines the behavior required to persist and retrieve contacts.
 type ContactRepository interface {
-	// Save inserts a new contact or updates it if it already exists.
-	Save(contact *Contact) error
+	// Save inserts a new contact or updates it if it already exists. An ID held
+	// by another tenant fails with ErrContactNotFound.
+	Save(ctx context.Context, contact *Contact) error
+
+	// GetByID fetches a contact of a tenant by its unique ID.
+	GetByID(ctx context.Context, tenant, id string) (*Contact, error)
+
+	// GetByDocument finds a contact of a tenant using document type and number.
+	GetByDocument(ctx context.Context, tenant string, docType DocumentType, docNumber string) (*Contact, error)
+
+	// Delete removes a contact of a tenant by its ID, together with its relationships.
+	Delete(ctx context.Context, tenant, id string) error
+
+	// List returns the contacts matching filter, always within filter.Tenant
+	// (could be paginated later).
+	List(ctx context.Context, filter ContactFilter) ([]*Contact, error)
+
+	// SaveTag inserts or updates a catalog tag. An ID held by another tenant
+	// fails with ErrTagNotFound.
+	SaveTag(ctx context.Context, tag *Tag) error
+
+	// ListTags returns the tag catalog of a tenant ordered by name.
+	ListTags(ctx context.Context, tenant string) ([]*Tag, error)
+
+	// GetTagsByName returns the catalog tags of a tenant with the given names,
+	// ignoring case. Unknown names are skipped.
+	GetTagsByName(ctx context.Context, tenant string, names []string) ([]*Tag, error)
+
+	// DeleteTag removes a tag of a tenant from the catalog and from every contact.
+	DeleteTag(ctx context.Context, tenant, id string) error
+
+	// AddTags links the tags to every active contact matching filter and
+	// returns the number of links created. Tags of other tenants are skipped.
+	AddTags(ctx context.Context, filter ContactFilter, tagIDs []string) (int, error)
+
+	// RemoveTags unlinks the tags from every active contact matching filter and
+	// returns the number of links removed.
+	RemoveTags(ctx context.Context, filter ContactFilter, tagIDs []string) (int, error)
+
+	// SaveFieldDefinition inserts or updates a custom field definition. An ID
+	// held by another tenant fails with ErrFieldDefinitionNotFound.
+	SaveFieldDefinition(ctx context.Context, def *FieldDefinition) error
+
+	// ListFieldDefinitions returns the custom field definitions of a tenant ordered by key.
+	ListFieldDefinitions(ctx context.Context, tenant string) ([]*FieldDefinition, error)
+
+	// DeleteFieldDefinition removes a custom field definition of a tenant and
+	// strips its key from the custom fields of the tenant's contacts.
+	DeleteFieldDefinition(ctx context.Context, tenant, id string) error
+
+	// SaveRelationship inserts or updates a relationship between two contacts.
+	SaveRelationship(ctx context.Context, r *Relationship) error
 
-	// GetByID fetches a contact by its unique ID.
-	GetByID(id string) (*Contact, error)
+	// ListRelationships returns the relationships on either side of a contact, oldest first.
+	ListRelationships(ctx context.Context, contactID string) ([]*Relationship, error)
 
-	// GetByDocument finds a contact using document type and number.
-	GetByDocument(docType DocumentType, docNumber string) (*Contact, error)
+	// DeleteRelationship removes a relationship on either side of a contact.
+	DeleteRelationship(ctx context.Context, contactID, id string) error
 
-	// Delete removes a contact by its ID.
-	Delete(id string) error
+	// AddConsent appends a consent record to the history of its contact.
+	AddConsent(ctx context.Context, r *ConsentRecord) error
 
-	// List returns all contacts (could be paginated later).
-	List() ([]*Contact, error)
+	// ListConsents returns the consent history of a contact, oldest first.
+	ListConsents(ctx context.Context, contactID string) ([]*ConsentRecord, error)
 }
@@ -1,19 +1,81 @@
 package domain
 
+import (
+	"context"
+	"time"
+)
+
 // ContactService defines application-level use cases for managing contacts.
 type ContactService interface {
 	// Create creates a new contact.
-	Create(contact *Contact) error
+	Create(ctx context.Context, contact *Contact) error
 
 	// Get retrieves a contact by its ID.
-	Get(id string) (*Contact, error)
+	Get(ctx context.Context, id string) (*Contact, error)
 
 	// Update applies partial updates to an existing contact.
-	Update(id string, patch *ContactPatch) (*Contact, error)
+	Update(ctx context.Context, id string, patch *ContactPatch) (*Contact, error)
+
+	// Delete removes a contact by its ID, together with its relationships.
+	Delete(ctx context.Context, id string) error
+
+	// List retrieves the contacts matching filter.
+	List(ctx context.Context, filter ContactFilter) ([]*Contact, error)
+
+	// CreateTag adds a tag to the catalog.
+	CreateTag(ctx context.Context, tag *Tag) error
+
+	// ListTags returns the tag catalog of the request tenant.
+	ListTags(ctx context.Context) ([]*Tag, error)
+
+	// DeleteTag removes a tag from the catalog and from every contact.
+	DeleteTag(ctx context.Context, id string) error
+
+	// TagContact links the named tags to a contact and returns it.
+	TagContact(ctx context.Context, id string, tags []string) (*Contact, error)
+
+	// UntagContact unlinks the named tags from a contact and returns it.
+	UntagContact(ctx context.Context, id string, tags []string) (*Contact, error)
+
+	// BulkTag links the named tags to every contact matching filter and
+	// returns the number of links created.
+	BulkTag(ctx context.Context, filter ContactFilter, tags []string) (int, error)
+
+	// BulkUntag unlinks the named tags from every contact matching filter and
+	// returns the number of links removed.
+	BulkUntag(ctx context.Context, filter ContactFilter, tags []string) (int, error)
+
+	// CreateFieldDefinition adds a custom field definition for the tenant in ctx.
+	CreateFieldDefinition(ctx context.Context, def *FieldDefinition) error
+
+	// ListFieldDefinitions returns the custom field definitions of the tenant in ctx.
+	ListFieldDefinitions(ctx context.Context) ([]*FieldDefinition, error)
+
+	// DeleteFieldDefinition removes a custom field definition of the tenant in ctx
+	// together with its values on the tenant's contacts.
+	DeleteFieldDefinition(ctx context.Context, id string) error
+
+	// CreateRelationship links two existing contacts.
+	CreateRelationship(ctx context.Context, r *Relationship) error
+
+	// DeleteRelationship removes a relationship of a contact.
+	DeleteRelationship(ctx context.Context, contactID, id string) error
+
+	// ListPeople returns the people related to a company. A non-zero activeOn
+	// keeps only the relationships holding on that day.
+	ListPeople(ctx context.Context, companyID string, activeOn time.Time) ([]*RelatedContact, error)
+
+	// ListCompanies returns the companies related to a contact: the employers
+	// of a person, or the parent and subsidiaries of a company. A non-zero
+	// activeOn keeps only the relationships holding on that day.
+	ListCompanies(ctx context.Context, contactID string, activeOn time.Time) ([]*RelatedContact, error)
+
+	// GrantConsent records that a contact gave consent for r.Purpose and r.Channel.
+	GrantConsent(ctx context.Context, r *ConsentRecord) error
 
-	// Delete removes a contact by its ID.
-	Delete(id string) error
+	// RevokeConsent records that a contact withdrew consent for r.Purpose and r.Channel.
+	RevokeConsent(ctx context.Context, r *ConsentRecord) error
 
-	// List retrieves all contacts.
-	List() ([]*Contact, error)
+	// ConsentHistory returns every consent record of a contact, oldest first.
+	ConsentHistory(ctx context.Context, contactID string) ([]*ConsentRecord, error)
 }
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/flockstore/mannaiah-backend/common/domain"
+)
+
+// Tag is a label from the catalog used to segment contacts (e.g. "VIP").
+// Names are unique within a tenant regardless of case.
+type Tag struct {
+	domain.Auditable
+
+	// ID is the unique identifier in the system.
+	ID string
+
+	// Tenant owns the tag; tags of other tenants are invisible.
+	Tenant string
+
+	// Name is the label shown to users and used in filters.
+	Name string
+
+	// Color is an optional hex color (e.g. "#ff8800") used to render the tag.
+	Color string
+
+	// Description explains what the tag is used for.
+	Description string
+}
+
+// TagMatch selects how ContactFilter.Tags are combined.
+type TagMatch string
+
+const (
+	// MatchAny selects contacts carrying at least one of the tags.
+	MatchAny TagMatch = "any"
+
+	// MatchAll selects contacts carrying every tag.
+	MatchAll TagMatch = "all"
+)
+
+// ContactFilter narrows the contacts returned by List and affected by bulk
+// tag operations. Zero-valued criteria are ignored.
+type ContactFilter struct {
+	// Tenant restricts the result to the contacts of this tenant. It is always
+	// applied, and set by the service from the request.
+	Tenant string
+
+	// IDs restricts the result to these contact IDs.
+	IDs []string
+
+	// Tags restricts the result to contacts carrying these tag names.
+	Tags []string
+
+	// Match combines Tags; the zero value means MatchAny.
+	Match TagMatch
+
+	// CustomFields restricts the result to contacts holding these exact custom field values.
+	CustomFields map[string]any
+
+	// MarketingChannel restricts the result to contacts whose current marketing
+	// consent for this channel is granted, i.e. who may legally receive marketing through it.
+	MarketingChannel ConsentChannel
+
+	// Email restricts the result to contacts with this email address, ignoring case.
+	Email string
+
+	// Phone restricts the result to contacts with this phone number.
+	Phone string
+}
+
+// Empty reports whether the filter selects every contact.
+func (f ContactFilter) Empty() bool {
+	return len(f.IDs) == 0 && len(NormalizeTagNames(f.Tags)) == 0 && len(f.CustomFields) == 0 &&
+		f.MarketingChannel == "" && strings.TrimSpace(f.Email) == "" && strings.TrimSpace(f.Phone) == ""
+}
+
+// NormalizeTagName trims a tag name and lowercases it for case-insensitive comparison.
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeTagNames normalizes names, dropping blanks and duplicates while
+// keeping the original order.
+func NormalizeTagNames(names []string) []string {
+	var out []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		n := NormalizeTagName(name)
+		if n != "" && !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormalizeTagNames verifies names are trimmed, lowercased and deduplicated in order.
+func TestNormalizeTagNames(t *testing.T) {
+	got := NormalizeTagNames([]string{" VIP", "wholesale", "vip ", "", "  ", "Black Friday 2025"})
+	assert.Equal(t, []string{"vip", "wholesale", "black friday 2025"}, got)
+	assert.Nil(t, NormalizeTagNames(nil))
+}
+
+// TestContactFilter_Empty verifies blank tag names do not count as criteria.
+func TestContactFilter_Empty(t *testing.T) {
+	assert.True(t, ContactFilter{}.Empty())
+	assert.True(t, ContactFilter{Tags: []string{" "}, Match: MatchAll}.Empty())
+	assert.False(t, ContactFilter{IDs: []string{"c1"}}.Empty())
+	assert.False(t, ContactFilter{Tags: []string{"vip"}}.Empty())
+}
@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	contactsv1 "github.com/flockstore/mannaiah-backend/apps/contacts/gen/contacts/v1"
+	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler exposes domain.ContactService over gRPC.
+// Domain errors are translated into gRPC statuses by the transport interceptors.
+type Handler struct {
+	contactsv1.UnimplementedContactServiceServer
+
+	service  domain.ContactService
+	validate *validator.Validate
+}
+
+// New creates a new Handler with the given ContactService.
+func New(service domain.ContactService) *Handler {
+	return &Handler{
+		service:  service,
+		validate: httptransport.NewValidator(),
+	}
+}
+
+// CreateContact registers a new contact.
+func (h *Handler) CreateContact(ctx context.Context, req *contactsv1.CreateContactRequest) (*contactsv1.CreateContactResponse, error) {
+	input := ToContactInput(req)
+	if err := h.validate.Struct(&input); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	contact := ToDomainContact(input)
+	if err := h.service.Create(ctx, contact); err != nil {
+		return nil, err
+	}
+	return &contactsv1.CreateContactResponse{Contact: ToProto(contact)}, nil
+}
+
+// GetContact retrieves a contact by its ID.
+func (h *Handler) GetContact(ctx context.Context, req *contactsv1.GetContactRequest) (*contactsv1.GetContactResponse, error) {
+	contact, err := h.service.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &contactsv1.GetContactResponse{Contact: ToProto(contact)}, nil
+}
+
+// UpdateContact applies a partial update to a contact.
+func (h *Handler) UpdateContact(ctx context.Context, req *contactsv1.UpdateContactRequest) (*contactsv1.UpdateContactResponse, error) {
+	input := ToContactPatchInput(req)
+	if err := h.validate.Struct(&input); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	updated, err := h.service.Update(ctx, req.GetId(), ToDomainPatch(input))
+	if err != nil {
+		return nil, err
+	}
+	return &contactsv1.UpdateContactResponse{Contact: ToProto(updated)}, nil
+}
+
+// DeleteContact removes a contact by its ID.
+func (h *Handler) DeleteContact(ctx context.Context, req *contactsv1.DeleteContactRequest) (*contactsv1.DeleteContactResponse, error) {
+	if err := h.service.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return &contactsv1.DeleteContactResponse{}, nil
+}
+
+// ListContacts returns all contacts.
+func (h *Handler) ListContacts(ctx context.Context, _ *contactsv1.ListContactsRequest) (*contactsv1.ListContactsResponse, error) {
+	contacts, err := h.service.List(ctx, domain.ContactFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &contactsv1.ListContactsResponse{Contacts: make([]*contactsv1.Contact, len(contacts))}
+	for i, c := range contacts {
+		resp.Contacts[i] = ToProto(c)
+	}
+	return resp, nil
+}
+
+// invalidArgument converts validator errors into an InvalidArgument status with field violations.
+func invalidArgument(err error) error {
+	ve := httptransport.NewValidationError(err)
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(ve.Fields))
+	for i, f := range ve.Fields {
+		violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message}
+	}
+
+	st := status.New(codes.InvalidArgument, httptransport.ErrValidationFailed.Message)
+	if withDetails, derr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); derr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
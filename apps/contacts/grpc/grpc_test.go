@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	contactsv1 "github.com/flockstore/mannaiah-backend/apps/contacts/gen/contacts/v1"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	grpctransport "github.com/flockstore/mannaiah-backend/common/transport/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newTestClient serves the handler over an in-memory connection with the standard interceptors.
+func newTestClient(t *testing.T, svc domain.ContactService) contactsv1.ContactServiceClient {
+	t.Helper()
+
+	srv := grpctransport.New(grpctransport.Options{
+		Register: func(s *ggrpc.Server) {
+			contactsv1.RegisterContactServiceServer(s, New(svc))
+		},
+	})
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(func() { srv.Stop(time.Second) })
+
+	conn, err := ggrpc.NewClient("passthrough:///bufnet",
+		ggrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		ggrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return contactsv1.NewContactServiceClient(conn)
+}
+
+// TestGetContact_Success verifies a contact is returned through gRPC.
+func TestGetContact_Success(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("Get", mock.Anything, "abc").Return(&domain.Contact{ID: "abc", FirstName: "Ana"}, nil)
+
+	resp, err := newTestClient(t, svc).GetContact(context.Background(), &contactsv1.GetContactRequest{Id: "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, "abc", resp.GetContact().GetId())
+	assert.Equal(t, "Ana", resp.GetContact().GetFirstName())
+}
+
+// TestGetContact_NotFound verifies domain errors become gRPC statuses with their stable code.
+func TestGetContact_NotFound(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("Get", mock.Anything, "missing").Return(nil, domain.ErrContactNotFound)
+
+	_, err := newTestClient(t, svc).GetContact(context.Background(), &contactsv1.GetContactRequest{Id: "missing"})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "contact.not_found", st.Details()[0].(*errdetails.ErrorInfo).Reason)
+}
+
+// TestCreateContact_Duplicate verifies conflicts map to AlreadyExists.
+func TestCreateContact_Duplicate(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("Create", mock.Anything, mock.AnythingOfType("*domain.Contact")).Return(domain.ErrDuplicateDocument)
+
+	_, err := newTestClient(t, svc).CreateContact(context.Background(), &contactsv1.CreateContactRequest{
+		DocumentType: "CC", DocumentNumber: "1", FirstName: "Ana", LastName: "Gomez",
+		Address: "Calle 1", CityCode: "05001", Phone: "3001234567", Email: "ana@flock.com",
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+// TestCreateContact_InvalidInput verifies validation failures list field violations.
+func TestCreateContact_InvalidInput(t *testing.T) {
+	svc := mocks.NewContactService(t)
+
+	_, err := newTestClient(t, svc).CreateContact(context.Background(), &contactsv1.CreateContactRequest{
+		DocumentType: "CC", DocumentNumber: "1", Address: "Calle 1", CityCode: "123", Phone: "3001234567", Email: "ana@flock.com",
+	})
+	st, _ := status.FromError(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	violations := st.Details()[0].(*errdetails.BadRequest).GetFieldViolations()
+	require.Len(t, violations, 1)
+	assert.Equal(t, "cityCode", violations[0].GetField())
+}
+
+// TestCreateContact_CustomFieldsAndTenant verifies custom fields travel both
+// ways and the x-tenant-id metadata reaches the service.
+func TestCreateContact_CustomFieldsAndTenant(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("Create",
+		mock.MatchedBy(func(ctx context.Context) bool { return reqctx.Tenant(ctx) == "acme" }),
+		mock.MatchedBy(func(c *domain.Contact) bool { return c.CustomFields["shoe_size"] == 42.0 }),
+	).Return(nil)
+
+	fields, err := structpb.NewStruct(map[string]any{"shoe_size": 42})
+	require.NoError(t, err)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), grpctransport.MetadataTenantID, "acme")
+	resp, err := newTestClient(t, svc).CreateContact(ctx, &contactsv1.CreateContactRequest{
+		DocumentType: "CC", DocumentNumber: "1", FirstName: "Ana", LastName: "Gomez",
+		Address: "Calle 1", CityCode: "05001", Phone: "3001234567", Email: "ana@flock.com",
+		CustomFields: fields,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"shoe_size": 42.0}, resp.GetContact().GetCustomFields().AsMap())
+}
@@ -0,0 +1,37 @@
+package grpc
+
+// ContactInput holds the validated fields of a CreateContactRequest.
+//
+// Field names follow the protobuf JSON names so violations point at request fields.
+// Validation rules mirror http.ContactInput; TestInputs_MatchHTTP keeps both in sync.
+type ContactInput struct {
+	DocumentType   string `json:"documentType" validate:"required"`
+	DocumentNumber string `json:"documentNumber" validate:"required" pii:"document"`
+	LegalName      string `json:"legalName"`
+	FirstName      string `json:"firstName" pii:"name"`
+	LastName       string `json:"lastName" pii:"name"`
+	Address        string `json:"address" validate:"required" pii:"address"`
+	AddressExtra   string `json:"addressExtra" pii:"address"`
+	CityCode       string `json:"cityCode" validate:"required,len=5,numeric"`
+	Phone          string `json:"phone" validate:"required,min=8,numeric" pii:"phone"`
+	Email          string `json:"email" validate:"required,email" pii:"email"`
+
+	// CustomFields are checked by the service against the tenant's field definitions.
+	CustomFields map[string]any `json:"customFields"`
+}
+
+// ContactPatchInput holds the validated fields of an UpdateContactRequest.
+// Validation rules mirror http.ContactPatchInput.
+type ContactPatchInput struct {
+	LegalName    *string `json:"legalName"`
+	FirstName    *string `json:"firstName" pii:"name"`
+	LastName     *string `json:"lastName" pii:"name"`
+	Address      *string `json:"address" validate:"omitempty,required" pii:"address"`
+	AddressExtra *string `json:"addressExtra" pii:"address"`
+	CityCode     *string `json:"cityCode" validate:"omitempty,len=5,numeric"`
+	Phone        *string `json:"phone" validate:"omitempty,min=8,numeric" pii:"phone"`
+	Email        *string `json:"email" validate:"omitempty,email" pii:"email"`
+
+	// CustomFields are merged into the existing values; null values remove keys.
+	CustomFields map[string]any `json:"customFields"`
+}
@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	contacthttp "github.com/flockstore/mannaiah-backend/apps/contacts/http"
+	"github.com/stretchr/testify/assert"
+)
+
+// fieldRules maps the JSON name of every field of v to its validate and pii tags.
+func fieldRules(v any) map[string]string {
+	t := reflect.TypeOf(v)
+	rules := make(map[string]string, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		rules[name] = "validate:" + f.Tag.Get("validate") + " pii:" + f.Tag.Get("pii")
+	}
+	return rules
+}
+
+// TestInputs_MatchHTTP ensures gRPC requests are validated and masked exactly
+// like their HTTP counterparts, so both transports accept the same contacts.
+func TestInputs_MatchHTTP(t *testing.T) {
+	assert.Equal(t, fieldRules(contacthttp.ContactInput{}), fieldRules(ContactInput{}))
+	assert.Equal(t, fieldRules(contacthttp.ContactPatchInput{}), fieldRules(ContactPatchInput{}))
+}
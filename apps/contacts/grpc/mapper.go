@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	contactsv1 "github.com/flockstore/mannaiah-backend/apps/contacts/gen/contacts/v1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToContactInput converts a CreateContactRequest into a ContactInput for validation.
+func ToContactInput(req *contactsv1.CreateContactRequest) ContactInput {
+	return ContactInput{
+		DocumentType:   req.GetDocumentType(),
+		DocumentNumber: req.GetDocumentNumber(),
+		LegalName:      req.GetLegalName(),
+		FirstName:      req.GetFirstName(),
+		LastName:       req.GetLastName(),
+		Address:        req.GetAddress(),
+		AddressExtra:   req.GetAddressExtra(),
+		CityCode:       req.GetCityCode(),
+		Phone:          req.GetPhone(),
+		Email:          req.GetEmail(),
+		CustomFields:   fromStruct(req.GetCustomFields()),
+	}
+}
+
+// ToContactPatchInput converts an UpdateContactRequest into a ContactPatchInput for validation.
+//
+// Proto3 optional fields map onto nil pointers when unset.
+func ToContactPatchInput(req *contactsv1.UpdateContactRequest) ContactPatchInput {
+	return ContactPatchInput{
+		LegalName:    req.LegalName,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		Address:      req.Address,
+		AddressExtra: req.AddressExtra,
+		CityCode:     req.CityCode,
+		Phone:        req.Phone,
+		Email:        req.Email,
+		CustomFields: fromStruct(req.GetCustomFields()),
+	}
+}
+
+// ToDomainContact converts a validated ContactInput into a domain.Contact.
+func ToDomainContact(input ContactInput) *domain.Contact {
+	return &domain.Contact{
+		DocumentType:   domain.DocumentType(input.DocumentType),
+		DocumentNumber: input.DocumentNumber,
+		LegalName:      input.LegalName,
+		FirstName:      input.FirstName,
+		LastName:       input.LastName,
+		Address:        input.Address,
+		AddressExtra:   input.AddressExtra,
+		CityCode:       input.CityCode,
+		Phone:          input.Phone,
+		Email:          input.Email,
+		CustomFields:   input.CustomFields,
+	}
+}
+
+// ToDomainPatch converts a validated ContactPatchInput into a domain.ContactPatch.
+func ToDomainPatch(input ContactPatchInput) *domain.ContactPatch {
+	return &domain.ContactPatch{
+		LegalName:    input.LegalName,
+		FirstName:    input.FirstName,
+		LastName:     input.LastName,
+		Address:      input.Address,
+		AddressExtra: input.AddressExtra,
+		CityCode:     input.CityCode,
+		Phone:        input.Phone,
+		Email:        input.Email,
+		CustomFields: input.CustomFields,
+	}
+}
+
+// ToProto converts a domain.Contact into its protobuf representation.
+func ToProto(c *domain.Contact) *contactsv1.Contact {
+	return &contactsv1.Contact{
+		Id:             c.ID,
+		DocumentType:   string(c.DocumentType),
+		DocumentNumber: c.DocumentNumber,
+		LegalName:      c.LegalName,
+		FirstName:      c.FirstName,
+		LastName:       c.LastName,
+		Address:        c.Address,
+		AddressExtra:   c.AddressExtra,
+		CityCode:       c.CityCode,
+		Phone:          c.Phone,
+		Email:          c.Email,
+		CreatedAt:      timestamppb.New(c.CreatedAt),
+		UpdatedAt:      timestamppb.New(c.UpdatedAt),
+		CustomFields:   toStruct(c.CustomFields),
+	}
+}
+
+// fromStruct returns the values of s, or nil when it is unset.
+func fromStruct(s *structpb.Struct) map[string]any {
+	if s == nil {
+		return nil
+	}
+	return s.AsMap()
+}
+
+// toStruct converts custom field values into a Struct, never nil so contacts
+// without custom fields carry an empty one.
+func toStruct(values map[string]any) *structpb.Struct {
+	s, err := structpb.NewStruct(values)
+	if err != nil {
+		// Unreachable: custom field values are strings, numbers and booleans.
+		return &structpb.Struct{}
+	}
+	return s
+}
@@ -2,22 +2,30 @@ package helper
 
 import (
 	"errors"
+	"fmt"
+
 	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/common/encryption"
 	"github.com/jackc/pgx/v5"
 )
 
-// ScanContact reads database columns into a Contact entity.
+// ScanContact reads database columns into a Contact entity, decrypting the
+// encrypted fields with cipher.
 //
-// It expects the columns to follow the exact order defined in the SELECT statement.
+// It expects the columns to follow the exact order defined in the SELECT statement,
+// ending with custom_fields and key_version. Rows without a key version predate encryption and are read as is.
 // Returns a pointer to Contact and any scan error.
-func ScanContact(scanner pgx.Row) (*domain.Contact, error) {
-	var c domain.Contact
+func ScanContact(scanner pgx.Row, cipher *encryption.Cipher) (*domain.Contact, error) {
+	var (
+		c          domain.Contact
+		keyVersion *int32
+	)
 
 	err := scanner.Scan(
 		&c.ID, &c.DocumentType, &c.DocumentNumber, &c.LegalName,
 		&c.FirstName, &c.LastName, &c.Address, &c.AddressExtra,
 		&c.CityCode, &c.Phone, &c.Email,
-		&c.CreatedAt, &c.UpdatedAt, &c.DeletedAt,
+		&c.CreatedAt, &c.UpdatedAt, &c.DeletedAt, &c.CustomFields, &keyVersion, &c.Tenant,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -28,5 +36,24 @@ func ScanContact(scanner pgx.Row) (*domain.Contact, error) {
 		return nil, err
 	}
 
+	if keyVersion == nil {
+		return &c, nil
+	}
+
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{
+		{FieldDocumentNumber, &c.DocumentNumber},
+		{FieldEmail, &c.Email},
+		{FieldPhone, &c.Phone},
+	} {
+		plain, err := cipher.Decrypt(f.name, *f.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt contact %s: %w", c.ID, err)
+		}
+		*f.value = plain
+	}
+
 	return &c, nil
 }
@@ -0,0 +1,87 @@
+package helper
+
+import (
+	"strings"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/common/encryption"
+)
+
+// Names of the encrypted contact columns. They are bound to each ciphertext
+// and scope its blind index, so they must not change once data is written.
+const (
+	// FieldDocumentNumber is the doc_number column.
+	FieldDocumentNumber = "doc_number"
+
+	// FieldEmail is the email column.
+	FieldEmail = "email"
+
+	// FieldPhone is the phone column.
+	FieldPhone = "phone"
+)
+
+// SealedContact holds the encrypted columns of a Contact and their blind indexes.
+type SealedContact struct {
+	// DocumentNumber is the encrypted document number.
+	DocumentNumber string
+
+	// DocumentIndex is the blind index of the document number.
+	DocumentIndex string
+
+	// Email is the encrypted email.
+	Email string
+
+	// EmailIndex is the blind index of the normalized email.
+	EmailIndex string
+
+	// Phone is the encrypted phone.
+	Phone string
+
+	// PhoneIndex is the blind index of the phone.
+	PhoneIndex string
+
+	// KeyVersion is the version of the key the values were sealed with.
+	KeyVersion uint32
+}
+
+// SealContact encrypts the personal fields of c with the current key and
+// computes their blind indexes.
+func SealContact(c *domain.Contact, cipher *encryption.Cipher) (SealedContact, error) {
+	var (
+		s   SealedContact
+		err error
+	)
+
+	if s.KeyVersion, err = cipher.CurrentVersion(); err != nil {
+		return s, err
+	}
+
+	for _, f := range []struct {
+		name         string
+		value        string
+		sealed, bidx *string
+	}{
+		{FieldDocumentNumber, c.DocumentNumber, &s.DocumentNumber, &s.DocumentIndex},
+		{FieldEmail, c.Email, &s.Email, &s.EmailIndex},
+		{FieldPhone, c.Phone, &s.Phone, &s.PhoneIndex},
+	} {
+		if *f.sealed, err = cipher.Encrypt(f.name, f.value); err != nil {
+			return s, err
+		}
+		if *f.bidx, err = BlindIndex(cipher, f.name, f.value); err != nil {
+			return s, err
+		}
+	}
+
+	return s, nil
+}
+
+// BlindIndex returns the blind index of value for an encrypted contact field.
+// Values are normalized first so lookups match regardless of case or padding.
+func BlindIndex(cipher *encryption.Cipher, field, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if field == FieldEmail {
+		value = strings.ToLower(value)
+	}
+	return cipher.BlindIndex(field, value)
+}
@@ -0,0 +1,110 @@
+package helper
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/common/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCipher returns a cipher with a single key, version 1.
+func newTestCipher(t *testing.T) *encryption.Cipher {
+	t.Helper()
+	ring, err := encryption.NewKeyring(1,
+		map[uint32][]byte{1: bytes.Repeat([]byte{1}, encryption.KeySize)},
+		bytes.Repeat([]byte{2}, encryption.KeySize))
+	require.NoError(t, err)
+	return encryption.NewCipher(ring)
+}
+
+// fakeRow is a pgx.Row returning fixed column values.
+type fakeRow struct {
+	values []any
+}
+
+// Scan copies the fixed values into dest, which must match their types.
+func (r fakeRow) Scan(dest ...any) error {
+	for i, d := range dest {
+		switch d := d.(type) {
+		case *string:
+			*d = r.values[i].(string)
+		case *domain.DocumentType:
+			*d = r.values[i].(domain.DocumentType)
+		case *time.Time:
+			*d = r.values[i].(time.Time)
+		case **time.Time:
+			*d = nil
+		case *map[string]any:
+			*d, _ = r.values[i].(map[string]any)
+		case **int32:
+			if v, ok := r.values[i].(int32); ok {
+				*d = &v
+			}
+		}
+	}
+	return nil
+}
+
+// row returns the columns of contactColumns for c, with the given personal fields and key version.
+func row(c domain.Contact, doc, phone, email string, version any) fakeRow {
+	return fakeRow{values: []any{
+		c.ID, c.DocumentType, doc, c.LegalName,
+		c.FirstName, c.LastName, c.Address, c.AddressExtra,
+		c.CityCode, phone, email,
+		c.CreatedAt, c.UpdatedAt, nil, c.CustomFields, version, c.Tenant,
+	}}
+}
+
+// TestSealAndScanContact verifies sealed fields are encrypted and scanned back in clear.
+func TestSealAndScanContact(t *testing.T) {
+	cipher := newTestCipher(t)
+	c := domain.Contact{ID: "c1", DocumentType: domain.DocumentCC, DocumentNumber: "100",
+		FirstName: "Ana", LastName: "Gomez", Email: "ana@flock.com", Phone: "3001234567"}
+
+	sealed, err := SealContact(&c, cipher)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), sealed.KeyVersion)
+	assert.NotEqual(t, c.DocumentNumber, sealed.DocumentNumber)
+	assert.NotEqual(t, c.Email, sealed.Email)
+	assert.NotEqual(t, c.Phone, sealed.Phone)
+
+	index, err := BlindIndex(cipher, FieldDocumentNumber, "100")
+	require.NoError(t, err)
+	assert.Equal(t, index, sealed.DocumentIndex)
+
+	got, err := ScanContact(row(c, sealed.DocumentNumber, sealed.Phone, sealed.Email, int32(1)), cipher)
+	require.NoError(t, err)
+	assert.Equal(t, c, *got)
+}
+
+// TestScanContact_Plaintext verifies rows without a key version are read as stored.
+func TestScanContact_Plaintext(t *testing.T) {
+	c := domain.Contact{ID: "c1", DocumentType: domain.DocumentCC, DocumentNumber: "100", Email: "ana@flock.com"}
+
+	got, err := ScanContact(row(c, "100", "", "ana@flock.com", nil), newTestCipher(t))
+	require.NoError(t, err)
+	assert.Equal(t, c, *got)
+}
+
+// TestScanContact_DecryptError verifies undecryptable values fail the scan.
+func TestScanContact_DecryptError(t *testing.T) {
+	c := domain.Contact{ID: "c1"}
+
+	_, err := ScanContact(row(c, "100", "", "", int32(1)), newTestCipher(t))
+	assert.ErrorIs(t, err, encryption.ErrMalformed)
+}
+
+// TestBlindIndex_Normalizes verifies emails match regardless of case and padding.
+func TestBlindIndex_Normalizes(t *testing.T) {
+	cipher := newTestCipher(t)
+
+	a, err := BlindIndex(cipher, FieldEmail, " Ana@Flock.com ")
+	require.NoError(t, err)
+	b, err := BlindIndex(cipher, FieldEmail, "ana@flock.com")
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"context"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// consentRecorder is a service operation appending a consent decision.
+type consentRecorder func(ctx context.Context, r *domain.ConsentRecord) error
+
+// GrantConsent handles POST /contacts/:id/consents/grant to record that the
+// contact gave consent.
+func (h *Handler) GrantConsent(c *fiber.Ctx) error {
+	return h.recordConsent(c, h.service.GrantConsent)
+}
+
+// RevokeConsent handles POST /contacts/:id/consents/revoke to record that the
+// contact withdrew consent.
+func (h *Handler) RevokeConsent(c *fiber.Ctx) error {
+	return h.recordConsent(c, h.service.RevokeConsent)
+}
+
+// ConsentHistory handles GET /contacts/:id/consents to retrieve every consent
+// record of the contact, oldest first.
+func (h *Handler) ConsentHistory(c *fiber.Ctx) error {
+	records, err := h.service.ConsentHistory(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return err
+	}
+	response := make([]ConsentRecordResponse, len(records))
+	for i, r := range records {
+		response[i] = ToConsentRecordResponse(r)
+	}
+	return httptransport.WriteSuccess(c, response)
+}
+
+// recordConsent parses a consent decision and records it with record. The
+// decision defaults to the caller's IP.
+func (h *Handler) recordConsent(c *fiber.Ctx, record consentRecorder) error {
+	var input ConsentInput
+	if err := h.parse(c, &input); err != nil {
+		return err
+	}
+
+	// Params alias the request buffer; copy the ID since the record outlives the request.
+	consent := ToDomainConsent(utils.CopyString(c.Params("id")), httptransport.ClientIP(c), input)
+	if err := record(c.UserContext(), consent); err != nil {
+		return err
+	}
+	return httptransport.WriteCreated(c, ToConsentRecordResponse(consent))
+}
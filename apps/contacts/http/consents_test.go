@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGrantConsent_ReturnsCreated verifies the caller's IP is recorded when the input names none.
+func TestGrantConsent_ReturnsCreated(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("GrantConsent", mock.Anything, &domain.ConsentRecord{
+		ContactID: "c1", Purpose: domain.PurposeMarketing, Channel: domain.ChannelWhatsApp,
+		Source: "web_form", PolicyVersion: "2025-01", IP: "0.0.0.0",
+	}).
+		Run(func(args mock.Arguments) {
+			r := args.Get(1).(*domain.ConsentRecord)
+			r.ID, r.Action = "k1", domain.ConsentGrant
+		}).
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/contacts/c1/consents/grant",
+		strings.NewReader(`{"purpose":"marketing","channel":"whatsapp","source":"web_form","policyVersion":"2025-01"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newTestApp(svc).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 201, resp.StatusCode)
+
+	var body envelope[ConsentRecordResponse]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "k1", body.Data.ID)
+	require.Equal(t, "grant", body.Data.Action)
+}
+
+// TestRevokeConsent_MarketingNeedsChannel verifies marketing decisions name a channel.
+func TestRevokeConsent_MarketingNeedsChannel(t *testing.T) {
+	req := httptest.NewRequest("POST", "/contacts/c1/consents/revoke",
+		strings.NewReader(`{"purpose":"marketing","source":"call_center","policyVersion":"2025-01"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newTestApp(mocks.NewContactService(t)).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 400, resp.StatusCode)
+}
+
+// TestListContacts_FiltersByMarketingConsent verifies ?marketing= selects contacts by channel.
+func TestListContacts_FiltersByMarketingConsent(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("List", mock.Anything, domain.ContactFilter{MarketingChannel: domain.ChannelSMS}).
+		Return([]*domain.Contact{{ID: "c1", Consents: []domain.ConsentState{
+			{Purpose: domain.PurposeMarketing, Channel: domain.ChannelSMS, Granted: true, PolicyVersion: "2025-01"},
+		}}}, nil)
+
+	resp, err := newTestApp(svc).Test(httptest.NewRequest("GET", "/contacts?marketing=sms", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	var body envelope[[]ContactResponse]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Data, 1)
+	require.Len(t, body.Data[0].Consents, 1)
+	require.True(t, body.Data[0].Consents[0].Granted)
+}
+
+// TestListContacts_UnknownMarketingChannel verifies unknown channels are rejected.
+func TestListContacts_UnknownMarketingChannel(t *testing.T) {
+	resp, err := newTestApp(mocks.NewContactService(t)).Test(httptest.NewRequest("GET", "/contacts?marketing=fax", nil))
+	require.NoError(t, err)
+	require.Equal(t, 400, resp.StatusCode)
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"strings"
+
+	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
+	"github.com/gofiber/fiber/v2"
+)
+
+// customFieldParam prefixes the query parameters filtering by custom field, as in cf[shoe_size]=42.
+const customFieldParam = "cf["
+
+// CreateFieldDefinition handles POST /contacts/fields to define a custom field
+// for the tenant of the request.
+func (h *Handler) CreateFieldDefinition(c *fiber.Ctx) error {
+	var input FieldDefinitionInput
+	if err := h.parse(c, &input); err != nil {
+		return err
+	}
+
+	def := ToDomainFieldDefinition(input)
+	if err := h.service.CreateFieldDefinition(c.UserContext(), def); err != nil {
+		return err
+	}
+	return httptransport.WriteCreated(c, ToFieldDefinitionResponse(*def))
+}
+
+// ListFieldDefinitions handles GET /contacts/fields to retrieve the custom
+// fields of the tenant of the request.
+func (h *Handler) ListFieldDefinitions(c *fiber.Ctx) error {
+	defs, err := h.service.ListFieldDefinitions(c.UserContext())
+	if err != nil {
+		return err
+	}
+	response := make([]FieldDefinitionResponse, len(defs))
+	for i, d := range defs {
+		response[i] = ToFieldDefinitionResponse(*d)
+	}
+	return httptransport.WriteSuccess(c, response)
+}
+
+// DeleteFieldDefinition handles DELETE /contacts/fields/:fieldId to remove a
+// custom field together with its values on the tenant's contacts.
+func (h *Handler) DeleteFieldDefinition(c *fiber.Ctx) error {
+	if err := h.service.DeleteFieldDefinition(c.UserContext(), c.Params("fieldId")); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// customFieldQuery collects the cf[key]=value query parameters, which the
+// query parser does not map.
+func customFieldQuery(c *fiber.Ctx) map[string]string {
+	var fields map[string]string
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		k := string(key)
+		if !strings.HasPrefix(k, customFieldParam) || !strings.HasSuffix(k, "]") {
+			return
+		}
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+		fields[k[len(customFieldParam):len(k)-1]] = string(value)
+	})
+	return fields
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListContacts_FiltersByCustomFields verifies cf[key]=value parameters become a raw filter.
+func TestListContacts_FiltersByCustomFields(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	filter := domain.ContactFilter{CustomFields: map[string]any{"shoe_size": "42", "store": "north"}}
+	svc.On("List", mock.Anything, filter).
+		Return([]*domain.Contact{{ID: "c1", CustomFields: map[string]any{"shoe_size": 42.0, "store": "north"}}}, nil)
+
+	resp, err := newTestApp(svc).Test(httptest.NewRequest("GET", "/contacts?cf[shoe_size]=42&cf[store]=north", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	var body envelope[[]ContactResponse]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Data, 1)
+	require.Equal(t, map[string]any{"shoe_size": 42.0, "store": "north"}, body.Data[0].CustomFields)
+}
+
+// TestCreateFieldDefinition_ReturnsCreated verifies field definitions are routed ahead of contact IDs.
+func TestCreateFieldDefinition_ReturnsCreated(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("CreateFieldDefinition", mock.Anything, &domain.FieldDefinition{
+		Key: "store", Type: domain.FieldEnum, Rules: domain.FieldRules{Options: []string{"north", "south"}},
+	}).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.FieldDefinition).ID = "f1" }).
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/contacts/fields", strings.NewReader(`{"key":"store","type":"enum","rules":{"options":["north","south"]}}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newTestApp(svc).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 201, resp.StatusCode)
+
+	var body envelope[FieldDefinitionResponse]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "f1", body.Data.ID)
+	require.Equal(t, []string{"north", "south"}, body.Data.Rules.Options)
+}
+
+// TestCreateFieldDefinition_InvalidType verifies unknown field types are rejected.
+func TestCreateFieldDefinition_InvalidType(t *testing.T) {
+	req := httptest.NewRequest("POST", "/contacts/fields", strings.NewReader(`{"key":"color","type":"color"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newTestApp(mocks.NewContactService(t)).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 400, resp.StatusCode)
+}
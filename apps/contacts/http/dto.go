@@ -2,43 +2,189 @@ package http
 
 // ContactInput represents the data required to create a new contact.
 type ContactInput struct {
-	DocumentType   string `json:"documentType" validate:"required"`           // Document type (e.g. "CC", "TI")
-	DocumentNumber string `json:"documentNumber" validate:"required"`         // Unique document identifier
-	LegalName      string `json:"legalName"`                                  // Legal name (for legal entities)
-	FirstName      string `json:"firstName"`                                  // First name (for individuals)
-	LastName       string `json:"lastName"`                                   // Last name (for individuals)
-	Address        string `json:"address" validate:"required"`                // Main address (mandatory)
-	AddressExtra   string `json:"addressExtra"`                               // Additional address details (optional)
-	CityCode       string `json:"cityCode" validate:"required,len=5,numeric"` // 5-digit city code from catalog
-	Phone          string `json:"phone" validate:"required,min=8,numeric"`    // Minimum 8-digit phone number
-	Email          string `json:"email" validate:"required,email"`            // Valid email address
+	DocumentType   string         `json:"documentType" validate:"required"`                    // Document type (e.g. "CC", "TI")
+	DocumentNumber string         `json:"documentNumber" validate:"required" pii:"document"`   // Unique document identifier
+	LegalName      string         `json:"legalName"`                                           // Legal name (for legal entities)
+	FirstName      string         `json:"firstName" pii:"name"`                                // First name (for individuals)
+	LastName       string         `json:"lastName" pii:"name"`                                 // Last name (for individuals)
+	Address        string         `json:"address" validate:"required" pii:"address"`           // Main address (mandatory)
+	AddressExtra   string         `json:"addressExtra" pii:"address"`                          // Additional address details (optional)
+	CityCode       string         `json:"cityCode" validate:"required,len=5,numeric"`          // 5-digit city code from catalog
+	Phone          string         `json:"phone" validate:"required,min=8,numeric" pii:"phone"` // Minimum 8-digit phone number
+	Email          string         `json:"email" validate:"required,email" pii:"email"`         // Valid email address
+	CustomFields   map[string]any `json:"customFields"`                                        // Values of the tenant custom fields, by key
 }
 
 // ContactPatchInput represents a partial update payload for a contact.
 type ContactPatchInput struct {
-	LegalName    *string `json:"legalName,omitempty"`                                   // Updated legal name
-	FirstName    *string `json:"firstName,omitempty"`                                   // Updated first name
-	LastName     *string `json:"lastName,omitempty"`                                    // Updated last name
-	Address      *string `json:"address,omitempty" validate:"omitempty,required"`       // If present, must not be empty
-	AddressExtra *string `json:"addressExtra,omitempty"`                                // Updated extra address (optional)
-	CityCode     *string `json:"cityCode,omitempty" validate:"omitempty,len=5,numeric"` // Must be 5 digits if present
-	Phone        *string `json:"phone,omitempty" validate:"omitempty,min=8,numeric"`    // Must be at least 8 digits if present
-	Email        *string `json:"email,omitempty" validate:"omitempty,email"`            // Must be valid if present
+	LegalName    *string        `json:"legalName,omitempty"`                                            // Updated legal name
+	FirstName    *string        `json:"firstName,omitempty" pii:"name"`                                 // Updated first name
+	LastName     *string        `json:"lastName,omitempty" pii:"name"`                                  // Updated last name
+	Address      *string        `json:"address,omitempty" validate:"omitempty,required" pii:"address"`  // If present, must not be empty
+	AddressExtra *string        `json:"addressExtra,omitempty" pii:"address"`                           // Updated extra address (optional)
+	CityCode     *string        `json:"cityCode,omitempty" validate:"omitempty,len=5,numeric"`          // Must be 5 digits if present
+	Phone        *string        `json:"phone,omitempty" validate:"omitempty,min=8,numeric" pii:"phone"` // Must be at least 8 digits if present
+	Email        *string        `json:"email,omitempty" validate:"omitempty,email" pii:"email"`         // Must be valid if present
+	CustomFields map[string]any `json:"customFields,omitempty"`                                         // Merged into existing values; null removes a key
 }
 
 // ContactResponse represents the contact data returned to the client.
 type ContactResponse struct {
-	ID             string `json:"id"`             // Unique contact identifier (UUID)
-	DocumentType   string `json:"documentType"`   // Document type (e.g. "CC")
-	DocumentNumber string `json:"documentNumber"` // Document number (e.g. 123456789)
-	LegalName      string `json:"legalName"`      // Legal name (for legal entities)
-	FirstName      string `json:"firstName"`      // First name (for individuals)
-	LastName       string `json:"lastName"`       // Last name (for individuals)
-	Address        string `json:"address"`        // Main address
-	AddressExtra   string `json:"addressExtra"`   // Extra address details
-	CityCode       string `json:"cityCode"`       // 5-digit city code
-	Phone          string `json:"phone"`          // Phone number
-	Email          string `json:"email"`          // Email address
-	CreatedAt      string `json:"createdAt"`      // ISO 8601 creation timestamp
-	UpdatedAt      string `json:"updatedAt"`      // ISO 8601 last update timestamp
+	ID             string                 `json:"id"`                            // Unique contact identifier (UUID)
+	DocumentType   string                 `json:"documentType"`                  // Document type (e.g. "CC")
+	DocumentNumber string                 `json:"documentNumber" pii:"document"` // Document number (e.g. 123456789)
+	LegalName      string                 `json:"legalName"`                     // Legal name (for legal entities)
+	FirstName      string                 `json:"firstName" pii:"name"`          // First name (for individuals)
+	LastName       string                 `json:"lastName" pii:"name"`           // Last name (for individuals)
+	Address        string                 `json:"address" pii:"address"`         // Main address
+	AddressExtra   string                 `json:"addressExtra" pii:"address"`    // Extra address details
+	CityCode       string                 `json:"cityCode"`                      // 5-digit city code
+	Phone          string                 `json:"phone" pii:"phone"`             // Phone number
+	Email          string                 `json:"email" pii:"email"`             // Email address
+	CreatedAt      string                 `json:"createdAt"`                     // ISO 8601 creation timestamp
+	UpdatedAt      string                 `json:"updatedAt"`                     // ISO 8601 last update timestamp
+	Tags           []TagResponse          `json:"tags"`                          // Tags linked to the contact, ordered by name
+	CustomFields   map[string]any         `json:"customFields"`                  // Values of the tenant custom fields, by key
+	Consents       []ConsentStateResponse `json:"consents"`                      // Current consent per purpose and channel
+}
+
+// ListContactsQuery represents the query parameters accepted when listing contacts.
+type ListContactsQuery struct {
+	Tags         string            `query:"tags" json:"tags"`                                                               // Comma-separated tag names to filter by
+	Match        string            `query:"match" json:"match" validate:"omitempty,oneof=any all"`                          // Whether contacts need any (default) or all tags
+	CustomFields map[string]string `query:"cf" json:"cf"`                                                                   // Exact custom field values, as cf[key]=value
+	Marketing    string            `query:"marketing" json:"marketing" validate:"omitempty,oneof=email sms whatsapp phone"` // Only contacts who may receive marketing through this channel
+	Email        string            `query:"email" json:"email" validate:"omitempty,email" pii:"email"`                      // Only contacts with this email address, ignoring case
+	Phone        string            `query:"phone" json:"phone" validate:"omitempty,numeric" pii:"phone"`                    // Only contacts with this phone number
+}
+
+// TagInput represents the data required to create a catalog tag.
+type TagInput struct {
+	Name        string `json:"name" validate:"required,max=64"`          // Unique tag name (e.g. "VIP")
+	Color       string `json:"color" validate:"omitempty,hexcolor"`      // Hex color (e.g. "#ff8800")
+	Description string `json:"description" validate:"omitempty,max=255"` // What the tag is used for
+}
+
+// TagResponse represents a catalog tag returned to the client.
+type TagResponse struct {
+	ID          string `json:"id"`          // Unique tag identifier (UUID)
+	Name        string `json:"name"`        // Tag name
+	Color       string `json:"color"`       // Hex color
+	Description string `json:"description"` // What the tag is used for
+}
+
+// ContactTagsInput lists the tag names to link to or unlink from a contact.
+type ContactTagsInput struct {
+	Tags []string `json:"tags" validate:"required,min=1,dive,required,max=64"` // Tag names
+}
+
+// ContactFilterInput selects the contacts affected by a bulk operation.
+type ContactFilterInput struct {
+	IDs          []string       `json:"ids"`                                      // Contact IDs
+	Tags         []string       `json:"tags"`                                     // Tag names the contacts carry
+	Match        string         `json:"match" validate:"omitempty,oneof=any all"` // Whether contacts need any (default) or all tags
+	CustomFields map[string]any `json:"customFields"`                             // Exact custom field values the contacts hold
+}
+
+// BulkTagInput links or unlinks tags on every contact matching a filter.
+type BulkTagInput struct {
+	Action string             `json:"action" validate:"required,oneof=add remove"`         // "add" or "remove"
+	Tags   []string           `json:"tags" validate:"required,min=1,dive,required,max=64"` // Tag names
+	Filter ContactFilterInput `json:"filter"`                                              // Contacts to change; must not be empty
+}
+
+// BulkTagResponse reports the outcome of a bulk tag operation.
+type BulkTagResponse struct {
+	Affected int `json:"affected"` // Number of links created or removed
+}
+
+// FieldRulesInput constrains the values of a custom field. Only the rules of
+// the field type are accepted.
+type FieldRulesInput struct {
+	MinLength *int     `json:"minLength,omitempty" validate:"omitempty,min=0"`       // Minimum characters (string)
+	MaxLength *int     `json:"maxLength,omitempty" validate:"omitempty,min=1"`       // Maximum characters (string)
+	Pattern   string   `json:"pattern,omitempty"`                                    // Regular expression values must match (string)
+	Min       *float64 `json:"min,omitempty"`                                        // Lowest accepted value (number)
+	Max       *float64 `json:"max,omitempty"`                                        // Highest accepted value (number)
+	Options   []string `json:"options,omitempty" validate:"omitempty,dive,required"` // Accepted values (enum)
+}
+
+// FieldDefinitionInput represents the data required to define a custom field.
+type FieldDefinitionInput struct {
+	Key      string          `json:"key" validate:"required,max=63"`                              // Snake case key (e.g. "shoe_size")
+	Type     string          `json:"type" validate:"required,oneof=string number date enum bool"` // Value type
+	Required bool            `json:"required"`                                                    // Whether every contact needs a value
+	Rules    FieldRulesInput `json:"rules"`                                                       // Validation rules
+}
+
+// FieldDefinitionResponse represents a custom field definition returned to the client.
+type FieldDefinitionResponse struct {
+	ID        string          `json:"id"`        // Unique field identifier (UUID)
+	Key       string          `json:"key"`       // Snake case key
+	Type      string          `json:"type"`      // Value type
+	Required  bool            `json:"required"`  // Whether every contact needs a value
+	Rules     FieldRulesInput `json:"rules"`     // Validation rules
+	CreatedAt string          `json:"createdAt"` // ISO 8601 creation timestamp
+}
+
+// RelationshipInput represents the data required to relate the contact in the path to another contact.
+type RelationshipInput struct {
+	Type       string `json:"type" validate:"required,oneof=employee_of legal_representative_of billing_contact_for parent_company_of"` // Kind of link
+	ContactID  string `json:"contactId" validate:"required"`                                                                            // Related company (or subsidiary)
+	Role       string `json:"role" validate:"omitempty,max=128"`                                                                        // Position (e.g. "purchasing manager")
+	ValidFrom  string `json:"validFrom" validate:"omitempty,datetime=2006-01-02"`                                                       // First day, YYYY-MM-DD
+	ValidUntil string `json:"validUntil" validate:"omitempty,datetime=2006-01-02"`                                                      // Last day, YYYY-MM-DD
+}
+
+// RelationshipResponse represents a relationship returned to the client.
+type RelationshipResponse struct {
+	ID         string `json:"id"`                   // Unique relationship identifier (UUID)
+	Type       string `json:"type"`                 // Kind of link
+	FromID     string `json:"fromId"`               // Subject contact: the person, or the parent company
+	ToID       string `json:"toId"`                 // Object contact: the company, or the subsidiary
+	Role       string `json:"role"`                 // Position of the subject
+	ValidFrom  string `json:"validFrom,omitempty"`  // First day, YYYY-MM-DD
+	ValidUntil string `json:"validUntil,omitempty"` // Last day, YYYY-MM-DD
+	CreatedAt  string `json:"createdAt"`            // ISO 8601 creation timestamp
+}
+
+// RelatedContactResponse pairs a relationship with the contact on its other side.
+type RelatedContactResponse struct {
+	Relationship RelationshipResponse `json:"relationship"` // Link between both contacts
+	Contact      ContactResponse      `json:"contact"`      // Related contact
+}
+
+// RelatedContactsQuery represents the query parameters accepted when listing related contacts.
+type RelatedContactsQuery struct {
+	Active bool `query:"active" json:"active"` // Only relationships valid today
+}
+
+// ConsentInput represents a consent decision collected from a contact.
+type ConsentInput struct {
+	Purpose       string `json:"purpose" validate:"required,oneof=marketing data_processing profiling"`                     // What the decision applies to
+	Channel       string `json:"channel" validate:"required_if=Purpose marketing,omitempty,oneof=email sms whatsapp phone"` // Medium, for marketing only
+	Source        string `json:"source" validate:"required,max=64"`                                                         // Where it was collected (e.g. "web_form")
+	PolicyVersion string `json:"policyVersion" validate:"required,max=32"`                                                  // Privacy policy version shown
+	IP            string `json:"ip" validate:"omitempty,ip" pii:"true"`                                                     // Address it was made from; defaults to the caller
+}
+
+// ConsentRecordResponse represents a consent record of the history returned to the client.
+type ConsentRecordResponse struct {
+	ID            string `json:"id"`            // Unique record identifier (UUID)
+	Purpose       string `json:"purpose"`       // What the decision applies to
+	Channel       string `json:"channel"`       // Medium, for marketing only
+	Action        string `json:"action"`        // "grant" or "revoke"
+	Source        string `json:"source"`        // Where it was collected
+	PolicyVersion string `json:"policyVersion"` // Privacy policy version shown
+	IP            string `json:"ip" pii:"true"` // Address it was made from
+	RecordedAt    string `json:"recordedAt"`    // ISO 8601 timestamp
+}
+
+// ConsentStateResponse represents the current consent of a contact for a purpose and channel.
+type ConsentStateResponse struct {
+	Purpose       string `json:"purpose"`       // What the decision applies to
+	Channel       string `json:"channel"`       // Medium, for marketing only
+	Granted       bool   `json:"granted"`       // Whether consent currently holds
+	PolicyVersion string `json:"policyVersion"` // Policy version of the latest decision
+	UpdatedAt     string `json:"updatedAt"`     // ISO 8601 timestamp of the latest decision
 }
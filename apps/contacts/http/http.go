@@ -1,38 +1,36 @@
 package http
 
 import (
-	"errors"
-	"fmt"
 	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/pii"
+	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
-	"strings"
 )
 
 // Handler manages HTTP routes for contact operations.
 type Handler struct {
-	logger   *zap.SugaredLogger
 	service  domain.ContactService
 	validate *validator.Validate
 }
 
 // New creates a new Handler with the given ContactService.
-func New(service domain.ContactService, l *zap.SugaredLogger) *Handler {
+// Handlers log through the request-scoped logger stored in the user context.
+func New(service domain.ContactService) *Handler {
 	return &Handler{
-		logger:   l,
 		service:  service,
-		validate: validator.New(),
+		validate: httptransport.NewValidator(),
 	}
 }
 
 // RegisterRoutes mounts contact routes on the given router group.
+// Routes are taken from the same table that generates the OpenAPI document.
 func (h *Handler) RegisterRoutes(router fiber.Router) {
-	router.Post("/", h.CreateContact)
-	router.Get("/", h.ListContacts)
-	router.Get("/:id", h.GetContact)
-	router.Patch("/:id", h.PatchContact)
-	router.Delete("/:id", h.DeleteContact)
+	for _, r := range h.routes() {
+		router.Add(r.Method, r.Path, r.handler)
+	}
 }
 
 // CreateContact handles POST /contacts to create a new contact.
@@ -40,54 +38,68 @@ func (h *Handler) CreateContact(c *fiber.Ctx) error {
 	var input ContactInput
 
 	if err := c.BodyParser(&input); err != nil {
-		h.logger.Debug("Failed to parse body", zap.Error(err))
-		return fiber.NewError(fiber.StatusBadRequest, "invalid JSON")
+		logger.FromContext(c.UserContext()).Debugw("Failed to parse body", pii.Error(err))
+		return httptransport.ErrInvalidBody
 	}
 
 	if err := h.validate.Struct(&input); err != nil {
-		me := mapValidationErrors(err)
-		h.logger.Debug("Failed to parse body", zap.Error(me))
-		return me
+		ve := httptransport.NewValidationError(err)
+		logger.FromContext(c.UserContext()).Debugw("Invalid contact input", zap.Error(ve))
+		return ve
 	}
 
 	domainContact := ToDomainContact(input)
-	if err := h.service.Create(domainContact); err != nil {
-		return MapDomainErrorToFiber(err)
+	if err := h.service.Create(c.UserContext(), domainContact); err != nil {
+		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(ToResponseDTO(domainContact))
+	return httptransport.WriteCreated(c, ToResponseDTO(domainContact))
 }
 
 // GetContact handles GET /contacts/:id to retrieve a contact by ID.
 func (h *Handler) GetContact(c *fiber.Ctx) error {
 	id := c.Params("id")
-	contact, err := h.service.Get(id)
+	contact, err := h.service.Get(c.UserContext(), id)
 	if err != nil {
-		return MapDomainErrorToFiber(err)
+		return err
 	}
-	return c.JSON(ToResponseDTO(contact))
+	return httptransport.WriteSuccess(c, ToResponseDTO(contact))
 }
 
 // DeleteContact handles DELETE /contacts/:id to remove a contact by ID.
 func (h *Handler) DeleteContact(c *fiber.Ctx) error {
 	id := c.Params("id")
-	if err := h.service.Delete(id); err != nil {
-		return MapDomainErrorToFiber(err)
+	if err := h.service.Delete(c.UserContext(), id); err != nil {
+		return err
 	}
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
-// ListContacts handles GET /contacts to retrieve all contacts.
+// ListContacts handles GET /contacts to retrieve contacts, optionally
+// filtered by tags (e.g. ?tags=vip,wholesale&match=all) or looked up by
+// email or phone (e.g. ?email=ana@flock.com).
 func (h *Handler) ListContacts(c *fiber.Ctx) error {
-	contacts, err := h.service.List()
+	var query ListContactsQuery
+	if err := c.QueryParser(&query); err != nil {
+		logger.FromContext(c.UserContext()).Debugw("Failed to parse query", zap.Error(err))
+		return httptransport.ErrInvalidQuery
+	}
+	query.CustomFields = customFieldQuery(c)
+	if err := h.validate.Struct(&query); err != nil {
+		ve := httptransport.NewValidationError(err)
+		logger.FromContext(c.UserContext()).Debugw("Invalid list query", zap.Error(ve))
+		return ve
+	}
+
+	contacts, err := h.service.List(c.UserContext(), ToListFilter(query))
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return err
 	}
 	response := make([]ContactResponse, len(contacts))
 	for i, contact := range contacts {
 		response[i] = ToResponseDTO(contact)
 	}
-	return c.JSON(response)
+	return httptransport.WriteSuccess(c, response)
 }
 
 // PatchContact handles PATCH /contacts/:id to partially update a contact.
@@ -95,32 +107,34 @@ func (h *Handler) PatchContact(c *fiber.Ctx) error {
 	id := c.Params("id")
 	var patch ContactPatchInput
 	if err := c.BodyParser(&patch); err != nil {
-		h.logger.Debug("Failed to parse body", zap.Error(err))
-		return fiber.NewError(fiber.StatusBadRequest, "invalid JSON")
+		logger.FromContext(c.UserContext()).Debugw("Failed to parse body", pii.Error(err))
+		return httptransport.ErrInvalidBody
 	}
 	if err := h.validate.Struct(&patch); err != nil {
-		me := mapValidationErrors(err)
-		h.logger.Debug("Failed to parse body", zap.Error(me))
-		return me
+		ve := httptransport.NewValidationError(err)
+		logger.FromContext(c.UserContext()).Debugw("Invalid contact patch", zap.Error(ve))
+		return ve
 	}
 
 	domainPatch := ToDomainPatch(patch)
-	updated, err := h.service.Update(id, domainPatch)
+	updated, err := h.service.Update(c.UserContext(), id, domainPatch)
 	if err != nil {
-		return MapDomainErrorToFiber(err)
+		return err
 	}
-	return c.JSON(ToResponseDTO(updated))
+	return httptransport.WriteSuccess(c, ToResponseDTO(updated))
 }
 
-// mapValidationErrors converts validator errors into readable messages.
-func mapValidationErrors(err error) error {
-	var errs validator.ValidationErrors
-	if errors.As(err, &errs) {
-		var msg []string
-		for _, e := range errs {
-			msg = append(msg, fmt.Sprintf("field '%s' failed on '%s'", e.Field(), e.Tag()))
-		}
-		return fiber.NewError(fiber.StatusBadRequest, strings.Join(msg, ", "))
-	}
-	return fiber.NewError(fiber.StatusBadRequest, "validation failed")
+// parse decodes the JSON body into input and validates it. It is shared by the
+// tag, custom field, relationship and consent endpoints.
+func (h *Handler) parse(c *fiber.Ctx, input any) error {
+	if err := c.BodyParser(input); err != nil {
+		logger.FromContext(c.UserContext()).Debugw("Failed to parse body", pii.Error(err))
+		return httptransport.ErrInvalidBody
+	}
+	if err := h.validate.Struct(input); err != nil {
+		ve := httptransport.NewValidationError(err)
+		logger.FromContext(c.UserContext()).Debugw("Invalid request body", zap.Error(ve))
+		return ve
+	}
+	return nil
 }
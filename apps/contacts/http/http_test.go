@@ -0,0 +1,191 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/testutil"
+	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestApp mounts the contact routes on a server with the standard middlewares.
+func newTestApp(svc domain.ContactService) *fiber.App {
+	handler := New(svc)
+	return httptransport.New(httptransport.Options{
+		Routes: func(router fiber.Router) {
+			handler.RegisterRoutes(router.Group(BasePath))
+		},
+	}).App()
+}
+
+// envelope decodes the standard success payload.
+type envelope[T any] struct {
+	Data      T      `json:"data"`
+	RequestID string `json:"requestId"`
+}
+
+// TestGetContact_ReturnsEnvelope verifies that successful responses use the standard envelope.
+func TestGetContact_ReturnsEnvelope(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("Get", mock.Anything, "abc").Return(&domain.Contact{ID: "abc", FirstName: "Ana"}, nil)
+
+	resp, err := newTestApp(svc).Test(httptest.NewRequest("GET", "/contacts/abc", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	var body envelope[ContactResponse]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "abc", body.Data.ID)
+	require.Equal(t, "Ana", body.Data.FirstName)
+	require.NotEmpty(t, body.RequestID)
+}
+
+// TestCreateContact_ValidationDetails verifies that validation failures list fields by JSON name.
+func TestCreateContact_ValidationDetails(t *testing.T) {
+	svc := mocks.NewContactService(t)
+
+	payload := `{"documentType":"CC","documentNumber":"1","address":"Calle 1","cityCode":"123","phone":"3001234567","email":"bad"}`
+	req := httptest.NewRequest("POST", "/contacts", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newTestApp(svc).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 400, resp.StatusCode)
+
+	var body struct {
+		Error struct {
+			Details []httptransport.FieldError `json:"details"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Error.Details, 2)
+	require.Equal(t, "cityCode", body.Error.Details[0].Field)
+	require.Equal(t, "len", body.Error.Details[0].Rule)
+	require.Equal(t, "5", body.Error.Details[0].Param)
+	require.Equal(t, "email", body.Error.Details[1].Field)
+}
+
+// TestCreateContact_ReturnsCreatedEnvelope verifies that creation responds 201 with the envelope.
+func TestCreateContact_ReturnsCreatedEnvelope(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("Create", mock.Anything, mock.AnythingOfType("*domain.Contact")).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.Contact).ID = "new-id" }).
+		Return(nil)
+
+	payload := `{"documentType":"CC","documentNumber":"1","firstName":"Ana","lastName":"Gomez","address":"Calle 1","cityCode":"05001","phone":"3001234567","email":"ana@flock.com"}`
+	req := httptest.NewRequest("POST", "/contacts", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newTestApp(svc).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 201, resp.StatusCode)
+
+	var body envelope[ContactResponse]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "new-id", body.Data.ID)
+}
+
+// TestDomainErrors_RenderedWithStableCodes verifies domain errors map to statuses and codes
+// without a per-app translation layer, and that unexpected errors are not leaked.
+func TestDomainErrors_RenderedWithStableCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantReason string
+		wantMsg    string
+	}{
+		{"not found", domain.ErrContactNotFound, 404, "contact.not_found", "contact not found"},
+		{"duplicate", domain.ErrDuplicateDocument, 409, "contact.duplicate_document", "duplicate document number"},
+		{"invalid names", domain.ErrInvalidNameCombination, 400, "contact.invalid_name_combination", domain.ErrInvalidNameCombination.Message},
+		{"missing name", domain.ErrMissingName, 400, "contact.missing_name", domain.ErrMissingName.Message},
+		{"internal", errors.New(`pq: duplicate key value violates unique constraint "contacts_pkey"`), 500, "internal", "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mocks.NewContactService(t)
+			svc.On("Get", mock.Anything, "abc").Return(nil, tt.err)
+
+			resp, err := newTestApp(svc).Test(httptest.NewRequest("GET", "/contacts/abc", nil))
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			var body httptransport.ErrorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			require.Equal(t, tt.wantReason, body.Error.Reason)
+			require.Equal(t, tt.wantMsg, body.Error.Message)
+		})
+	}
+}
+
+// TestCreateContact_LogsNoPII verifies failed requests never log the personal data they carried.
+func TestCreateContact_LogsNoPII(t *testing.T) {
+	input := ContactInput{
+		DocumentType:   "CC",
+		DocumentNumber: "1023456789",
+		FirstName:      "Ana",
+		LastName:       "Gomez",
+		Address:        "Calle 1 # 2-3",
+		CityCode:       "05001",
+		Phone:          "3001234567",
+		Email:          "ana@flock.com",
+	}
+
+	svc := mocks.NewContactService(t)
+	svc.On("Create", mock.Anything, mock.AnythingOfType("*domain.Contact")).
+		Return(errors.New(`duplicate key (doc_type, doc_number)=(CC, 1023456789) for ana@flock.com`))
+
+	var logs bytes.Buffer
+	handler := New(svc)
+	app := httptransport.New(httptransport.Options{
+		Logger: logger.New("debug", &logs),
+		Routes: func(router fiber.Router) {
+			handler.RegisterRoutes(router.Group(BasePath))
+		},
+	}).App()
+
+	for _, payload := range []string{
+		`{"documentType":"CC","documentNumber":"1023456789","firstName":"Ana","lastName":"Gomez","address":"Calle 1 # 2-3","cityCode":"05001","phone":"3001234567","email":"ana@flock.com"}`,
+		`{"documentNumber":"1023456789","email":"ana@flock.com","phone":3001234567}`,
+	} {
+		req := httptest.NewRequest("POST", "/contacts", strings.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, resp.StatusCode, 400)
+	}
+
+	require.Contains(t, logs.String(), "request failed")
+	require.Contains(t, logs.String(), "Failed to parse body")
+	testutil.AssertNoPII(t, logs.String(), input)
+}
+
+// TestListContacts_LooksUpByEmailAndPhone verifies ?email= and ?phone= reach the filter
+// and malformed values are rejected.
+func TestListContacts_LooksUpByEmailAndPhone(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("List", mock.Anything, domain.ContactFilter{Email: "ana@flock.com", Phone: "3001234567"}).
+		Return([]*domain.Contact{{ID: "c1"}}, nil)
+
+	resp, err := newTestApp(svc).Test(httptest.NewRequest("GET", "/contacts?email=ana@flock.com&phone=3001234567", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	for _, query := range []string{"email=not-an-email", "phone=300-123"} {
+		resp, err := newTestApp(mocks.NewContactService(t)).Test(httptest.NewRequest("GET", "/contacts?"+query, nil))
+		require.NoError(t, err)
+		require.Equal(t, 400, resp.StatusCode, query)
+	}
+}
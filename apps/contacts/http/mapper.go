@@ -2,6 +2,7 @@ package http
 
 import (
 	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"strings"
 	"time"
 )
 
@@ -20,6 +21,7 @@ func ToDomainContact(input ContactInput) *domain.Contact {
 		CityCode:       input.CityCode,
 		Phone:          input.Phone,
 		Email:          input.Email,
+		CustomFields:   input.CustomFields,
 	}
 }
 
@@ -36,6 +38,7 @@ func ToDomainPatch(input ContactPatchInput) *domain.ContactPatch {
 		CityCode:     input.CityCode,
 		Phone:        input.Phone,
 		Email:        input.Email,
+		CustomFields: input.CustomFields,
 	}
 }
 
@@ -57,5 +60,222 @@ func ToResponseDTO(c *domain.Contact) ContactResponse {
 		Email:          c.Email,
 		CreatedAt:      c.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:      c.UpdatedAt.Format(time.RFC3339),
+		Tags:           ToTagResponses(c.Tags),
+		CustomFields:   toCustomFieldsResponse(c.CustomFields),
+		Consents:       ToConsentStateResponses(c.Consents),
 	}
 }
+
+// toCustomFieldsResponse returns values, never nil so contacts without custom
+// fields render an empty object.
+func toCustomFieldsResponse(values map[string]any) map[string]any {
+	if values == nil {
+		return map[string]any{}
+	}
+	return values
+}
+
+// ToDomainTag converts a TagInput DTO into a domain.Tag entity.
+func ToDomainTag(input TagInput) *domain.Tag {
+	return &domain.Tag{
+		Name:        input.Name,
+		Color:       input.Color,
+		Description: input.Description,
+	}
+}
+
+// ToTagResponse converts a domain.Tag into a TagResponse DTO.
+func ToTagResponse(t domain.Tag) TagResponse {
+	return TagResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		Color:       t.Color,
+		Description: t.Description,
+	}
+}
+
+// ToTagResponses converts tags into TagResponse DTOs, never returning nil so
+// contacts without tags render an empty list.
+func ToTagResponses(tags []domain.Tag) []TagResponse {
+	out := make([]TagResponse, len(tags))
+	for i, t := range tags {
+		out[i] = ToTagResponse(t)
+	}
+	return out
+}
+
+// ToDomainFilter converts a ContactFilterInput DTO into a domain.ContactFilter.
+func ToDomainFilter(input ContactFilterInput) domain.ContactFilter {
+	return domain.ContactFilter{
+		IDs:          input.IDs,
+		Tags:         input.Tags,
+		Match:        domain.TagMatch(input.Match),
+		CustomFields: input.CustomFields,
+	}
+}
+
+// ToListFilter converts the list query parameters into a domain.ContactFilter.
+// Tags are given as a comma-separated list; custom field values are kept raw
+// and parsed by the service against the field definitions.
+func ToListFilter(query ListContactsQuery) domain.ContactFilter {
+	var tags []string
+	if query.Tags != "" {
+		tags = strings.Split(query.Tags, ",")
+	}
+
+	var fields map[string]any
+	if len(query.CustomFields) > 0 {
+		fields = make(map[string]any, len(query.CustomFields))
+		for k, v := range query.CustomFields {
+			fields[k] = v
+		}
+	}
+	return domain.ContactFilter{
+		Tags:             tags,
+		Match:            domain.TagMatch(query.Match),
+		CustomFields:     fields,
+		MarketingChannel: domain.ConsentChannel(query.Marketing),
+		Email:            query.Email,
+		Phone:            query.Phone,
+	}
+}
+
+// ToDomainFieldDefinition converts a FieldDefinitionInput DTO into a domain.FieldDefinition entity.
+func ToDomainFieldDefinition(input FieldDefinitionInput) *domain.FieldDefinition {
+	r := input.Rules
+	return &domain.FieldDefinition{
+		Key:      input.Key,
+		Type:     domain.FieldType(input.Type),
+		Required: input.Required,
+		Rules: domain.FieldRules{
+			MinLength: r.MinLength,
+			MaxLength: r.MaxLength,
+			Pattern:   r.Pattern,
+			Min:       r.Min,
+			Max:       r.Max,
+			Options:   r.Options,
+		},
+	}
+}
+
+// ToFieldDefinitionResponse converts a domain.FieldDefinition into a FieldDefinitionResponse DTO.
+func ToFieldDefinitionResponse(d domain.FieldDefinition) FieldDefinitionResponse {
+	r := d.Rules
+	return FieldDefinitionResponse{
+		ID:       d.ID,
+		Key:      d.Key,
+		Type:     string(d.Type),
+		Required: d.Required,
+		Rules: FieldRulesInput{
+			MinLength: r.MinLength,
+			MaxLength: r.MaxLength,
+			Pattern:   r.Pattern,
+			Min:       r.Min,
+			Max:       r.Max,
+			Options:   r.Options,
+		},
+		CreatedAt: d.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToDomainRelationship converts a RelationshipInput DTO into a domain.Relationship
+// from the contact fromID. Dates are expected to be validated already.
+func ToDomainRelationship(fromID string, input RelationshipInput) *domain.Relationship {
+	return &domain.Relationship{
+		Type:       domain.RelationType(input.Type),
+		FromID:     fromID,
+		ToID:       input.ContactID,
+		Role:       input.Role,
+		ValidFrom:  parseDate(input.ValidFrom),
+		ValidUntil: parseDate(input.ValidUntil),
+	}
+}
+
+// ToRelationshipResponse converts a domain.Relationship into a RelationshipResponse DTO.
+func ToRelationshipResponse(r *domain.Relationship) RelationshipResponse {
+	return RelationshipResponse{
+		ID:         r.ID,
+		Type:       string(r.Type),
+		FromID:     r.FromID,
+		ToID:       r.ToID,
+		Role:       r.Role,
+		ValidFrom:  formatDate(r.ValidFrom),
+		ValidUntil: formatDate(r.ValidUntil),
+		CreatedAt:  r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToRelatedContactResponses converts related contacts into DTOs, never returning nil.
+func ToRelatedContactResponses(related []*domain.RelatedContact) []RelatedContactResponse {
+	out := make([]RelatedContactResponse, len(related))
+	for i, r := range related {
+		out[i] = RelatedContactResponse{
+			Relationship: ToRelationshipResponse(r.Relationship),
+			Contact:      ToResponseDTO(r.Contact),
+		}
+	}
+	return out
+}
+
+// parseDate parses a YYYY-MM-DD date, returning nil for empty or invalid values.
+func parseDate(value string) *time.Time {
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// formatDate renders a date as YYYY-MM-DD, or an empty string when nil.
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
+
+// ToDomainConsent converts a ConsentInput DTO into a domain.ConsentRecord of
+// the contact contactID, collected from ip unless the input names another address.
+func ToDomainConsent(contactID, ip string, input ConsentInput) *domain.ConsentRecord {
+	if input.IP != "" {
+		ip = input.IP
+	}
+	return &domain.ConsentRecord{
+		ContactID:     contactID,
+		Purpose:       domain.ConsentPurpose(input.Purpose),
+		Channel:       domain.ConsentChannel(input.Channel),
+		Source:        input.Source,
+		PolicyVersion: input.PolicyVersion,
+		IP:            ip,
+	}
+}
+
+// ToConsentRecordResponse converts a domain.ConsentRecord into a ConsentRecordResponse DTO.
+func ToConsentRecordResponse(r *domain.ConsentRecord) ConsentRecordResponse {
+	return ConsentRecordResponse{
+		ID:            r.ID,
+		Purpose:       string(r.Purpose),
+		Channel:       string(r.Channel),
+		Action:        string(r.Action),
+		Source:        r.Source,
+		PolicyVersion: r.PolicyVersion,
+		IP:            r.IP,
+		RecordedAt:    r.RecordedAt.Format(time.RFC3339),
+	}
+}
+
+// ToConsentStateResponses converts consent states into DTOs, never returning nil
+// so contacts without consents render an empty list.
+func ToConsentStateResponses(states []domain.ConsentState) []ConsentStateResponse {
+	out := make([]ConsentStateResponse, len(states))
+	for i, s := range states {
+		out[i] = ConsentStateResponse{
+			Purpose:       string(s.Purpose),
+			Channel:       string(s.Channel),
+			Granted:       s.Granted,
+			PolicyVersion: s.PolicyVersion,
+			UpdatedAt:     s.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+	return out
+}
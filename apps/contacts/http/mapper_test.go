@@ -82,6 +82,9 @@ func TestToResponseDTO(t *testing.T) {
 		Email:          "jane@example.com",
 		CreatedAt:      now.Format(time.RFC3339),
 		UpdatedAt:      now.Format(time.RFC3339),
+		Tags:           []TagResponse{},
+		CustomFields:   map[string]any{},
+		Consents:       []ConsentStateResponse{},
 	}
 
 	actual := ToResponseDTO(contact)
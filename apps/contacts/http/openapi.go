@@ -0,0 +1,302 @@
+package http
+
+import (
+	"github.com/flockstore/mannaiah-backend/common/openapi"
+	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BasePath is the prefix under which contact routes are mounted.
+const BasePath = "/contacts"
+
+// route pairs an OpenAPI operation with the handler serving it.
+type route struct {
+	openapi.Operation
+	handler fiber.Handler
+}
+
+// routes is the single source of truth for contact endpoints.
+func (h *Handler) routes() []route {
+	return []route{
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodPost,
+				Path:        "/",
+				OperationID: "createContact",
+				Summary:     "Create a contact",
+				Request:     ContactInput{},
+				Response:    ContactResponse{},
+				Status:      fiber.StatusCreated,
+				Errors:      []int{fiber.StatusBadRequest, fiber.StatusConflict},
+			},
+			handler: h.CreateContact,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodGet,
+				Path:        "/",
+				OperationID: "listContacts",
+				Summary:     "List contacts, optionally filtered by tags, custom fields and marketing consent",
+				Query:       ListContactsQuery{},
+				Response:    []ContactResponse{},
+				Status:      fiber.StatusOK,
+				Errors:      []int{fiber.StatusBadRequest},
+			},
+			handler: h.ListContacts,
+		},
+		// Tag catalog routes come before "/:id" so "tags" is not read as a contact ID.
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodPost,
+				Path:        "/tags",
+				OperationID: "createTag",
+				Summary:     "Create a tag",
+				Request:     TagInput{},
+				Response:    TagResponse{},
+				Status:      fiber.StatusCreated,
+				Errors:      []int{fiber.StatusBadRequest, fiber.StatusConflict},
+			},
+			handler: h.CreateTag,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodGet,
+				Path:        "/tags",
+				OperationID: "listTags",
+				Summary:     "List tags",
+				Response:    []TagResponse{},
+				Status:      fiber.StatusOK,
+			},
+			handler: h.ListTags,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodPost,
+				Path:        "/tags/bulk",
+				OperationID: "bulkTagContacts",
+				Summary:     "Add or remove tags on every contact matching a filter",
+				Request:     BulkTagInput{},
+				Response:    BulkTagResponse{},
+				Status:      fiber.StatusOK,
+				Errors:      []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+			},
+			handler: h.BulkTag,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodDelete,
+				Path:        "/tags/:tagId",
+				OperationID: "deleteTag",
+				Summary:     "Delete a tag",
+				Status:      fiber.StatusNoContent,
+				Errors:      []int{fiber.StatusNotFound},
+			},
+			handler: h.DeleteTag,
+		},
+		// Custom field routes come before "/:id" for the same reason.
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodPost,
+				Path:        "/fields",
+				OperationID: "createFieldDefinition",
+				Summary:     "Define a custom field",
+				Request:     FieldDefinitionInput{},
+				Response:    FieldDefinitionResponse{},
+				Status:      fiber.StatusCreated,
+				Errors:      []int{fiber.StatusBadRequest, fiber.StatusConflict},
+			},
+			handler: h.CreateFieldDefinition,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodGet,
+				Path:        "/fields",
+				OperationID: "listFieldDefinitions",
+				Summary:     "List custom fields",
+				Response:    []FieldDefinitionResponse{},
+				Status:      fiber.StatusOK,
+			},
+			handler: h.ListFieldDefinitions,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodDelete,
+				Path:        "/fields/:fieldId",
+				OperationID: "deleteFieldDefinition",
+				Summary:     "Delete a custom field",
+				Status:      fiber.StatusNoContent,
+				Errors:      []int{fiber.StatusNotFound},
+			},
+			handler: h.DeleteFieldDefinition,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodGet,
+				Path:        "/:id",
+				OperationID: "getContact",
+				Summary:     "Get a contact by ID",
+				Response:    ContactResponse{},
+				Status:      fiber.StatusOK,
+				Errors:      []int{fiber.StatusNotFound},
+			},
+			handler: h.GetContact,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodPatch,
+				Path:        "/:id",
+				OperationID: "patchContact",
+				Summary:     "Partially update a contact",
+				Request:     ContactPatchInput{},
+				Response:    ContactResponse{},
+				Status:      fiber.StatusOK,
+				Errors:      []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+			},
+			handler: h.PatchContact,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodDelete,
+				Path:        "/:id",
+				OperationID: "deleteContact",
+				Summary:     "Delete a contact",
+				Status:      fiber.StatusNoContent,
+				Errors:      []int{fiber.StatusNotFound},
+			},
+			handler: h.DeleteContact,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodPost,
+				Path:        "/:id/tags",
+				OperationID: "tagContact",
+				Summary:     "Add tags to a contact",
+				Request:     ContactTagsInput{},
+				Response:    ContactResponse{},
+				Status:      fiber.StatusOK,
+				Errors:      []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+			},
+			handler: h.TagContact,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodDelete,
+				Path:        "/:id/tags",
+				OperationID: "untagContact",
+				Summary:     "Remove tags from a contact",
+				Request:     ContactTagsInput{},
+				Response:    ContactResponse{},
+				Status:      fiber.StatusOK,
+				Errors:      []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+			},
+			handler: h.UntagContact,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodPost,
+				Path:        "/:id/relationships",
+				OperationID: "createRelationship",
+				Summary:     "Relate a contact to a company",
+				Request:     RelationshipInput{},
+				Response:    RelationshipResponse{},
+				Status:      fiber.StatusCreated,
+				Errors:      []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusConflict},
+			},
+			handler: h.CreateRelationship,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodDelete,
+				Path:        "/:id/relationships/:relationshipId",
+				OperationID: "deleteRelationship",
+				Summary:     "Delete a relationship",
+				Status:      fiber.StatusNoContent,
+				Errors:      []int{fiber.StatusNotFound},
+			},
+			handler: h.DeleteRelationship,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodGet,
+				Path:        "/:id/people",
+				OperationID: "listCompanyPeople",
+				Summary:     "List the people related to a company",
+				Query:       RelatedContactsQuery{},
+				Response:    []RelatedContactResponse{},
+				Status:      fiber.StatusOK,
+				Errors:      []int{fiber.StatusNotFound},
+			},
+			handler: h.ListPeople,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodGet,
+				Path:        "/:id/companies",
+				OperationID: "listContactCompanies",
+				Summary:     "List the companies related to a contact",
+				Query:       RelatedContactsQuery{},
+				Response:    []RelatedContactResponse{},
+				Status:      fiber.StatusOK,
+				Errors:      []int{fiber.StatusNotFound},
+			},
+			handler: h.ListCompanies,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodGet,
+				Path:        "/:id/consents",
+				OperationID: "listContactConsents",
+				Summary:     "List the consent history of a contact",
+				Response:    []ConsentRecordResponse{},
+				Status:      fiber.StatusOK,
+				Errors:      []int{fiber.StatusNotFound},
+			},
+			handler: h.ConsentHistory,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodPost,
+				Path:        "/:id/consents/grant",
+				OperationID: "grantConsent",
+				Summary:     "Record that a contact gave consent",
+				Request:     ConsentInput{},
+				Response:    ConsentRecordResponse{},
+				Status:      fiber.StatusCreated,
+				Errors:      []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+			},
+			handler: h.GrantConsent,
+		},
+		{
+			Operation: openapi.Operation{
+				Method:      fiber.MethodPost,
+				Path:        "/:id/consents/revoke",
+				OperationID: "revokeConsent",
+				Summary:     "Record that a contact withdrew consent",
+				Request:     ConsentInput{},
+				Response:    ConsentRecordResponse{},
+				Status:      fiber.StatusCreated,
+				Errors:      []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+			},
+			handler: h.RevokeConsent,
+		},
+	}
+}
+
+// Operations returns the OpenAPI operations served by the handler.
+func (h *Handler) Operations() []openapi.Operation {
+	routes := h.routes()
+	ops := make([]openapi.Operation, len(routes))
+	for i, r := range routes {
+		ops[i] = r.Operation
+	}
+	return ops
+}
+
+// OpenAPI builds the OpenAPI document describing the contacts API.
+func OpenAPI() *openapi.Document {
+	return openapi.Build(
+		openapi.Info{Title: "Mannaiah Contacts API", Version: "1.0.0"},
+		openapi.Options{ErrorModel: httptransport.ErrorResponse{}, Envelope: httptransport.OpenAPIEnvelope},
+		openapi.Group{Prefix: BasePath, Tag: "contacts", Operations: New(nil).Operations()},
+	)
+}
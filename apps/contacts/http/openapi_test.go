@@ -0,0 +1,56 @@
+package http
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// update regenerates the committed OpenAPI document: go test ./http -update
+var update = flag.Bool("update", false, "update the committed OpenAPI document")
+
+// specPath is the committed OpenAPI document consumed by frontend and integration teams.
+const specPath = "../api/openapi.json"
+
+// TestOpenAPI_MatchesCommittedSpec fails when the generated spec drifts from the committed file.
+func TestOpenAPI_MatchesCommittedSpec(t *testing.T) {
+	generated, err := json.MarshalIndent(OpenAPI(), "", "  ")
+	require.NoError(t, err)
+	generated = append(generated, '\n')
+
+	if *update {
+		require.NoError(t, os.WriteFile(specPath, generated, 0o644))
+	}
+
+	committed, err := os.ReadFile(specPath)
+	require.NoError(t, err)
+	require.JSONEq(t, string(committed), string(generated),
+		"OpenAPI document is out of date, run: go test ./http -update")
+}
+
+// TestOpenAPI_CoversRegisteredRoutes ensures every registered route is documented.
+func TestOpenAPI_CoversRegisteredRoutes(t *testing.T) {
+	app := fiber.New()
+	New(nil).RegisterRoutes(app.Group(BasePath))
+
+	doc := OpenAPI()
+	for _, r := range app.GetRoutes(true) {
+		if r.Method == fiber.MethodHead {
+			continue
+		}
+		path := r.Path
+		if len(path) > 1 && path[len(path)-1] == '/' {
+			path = path[:len(path)-1]
+		}
+		path = paramSegment.ReplaceAllString(path, "{$1}")
+		require.Containsf(t, doc.Paths, path, "route %s %s is not documented", r.Method, r.Path)
+	}
+}
+
+// paramSegment matches Fiber path parameters such as ":id".
+var paramSegment = regexp.MustCompile(`:([^/]+)`)
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"go.uber.org/zap"
+)
+
+// relatedLister is a service operation listing the contacts related to a contact.
+type relatedLister func(ctx context.Context, contactID string, activeOn time.Time) ([]*domain.RelatedContact, error)
+
+// CreateRelationship handles POST /contacts/:id/relationships to relate the
+// contact to another contact.
+func (h *Handler) CreateRelationship(c *fiber.Ctx) error {
+	var input RelationshipInput
+	if err := h.parse(c, &input); err != nil {
+		return err
+	}
+
+	// Params alias the request buffer; copy the ID since the relationship outlives the request.
+	rel := ToDomainRelationship(utils.CopyString(c.Params("id")), input)
+	if err := h.service.CreateRelationship(c.UserContext(), rel); err != nil {
+		return err
+	}
+	return httptransport.WriteCreated(c, ToRelationshipResponse(rel))
+}
+
+// DeleteRelationship handles DELETE /contacts/:id/relationships/:relationshipId
+// to remove a relationship of the contact.
+func (h *Handler) DeleteRelationship(c *fiber.Ctx) error {
+	if err := h.service.DeleteRelationship(c.UserContext(), c.Params("id"), c.Params("relationshipId")); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListPeople handles GET /contacts/:id/people to retrieve the people related to a company.
+func (h *Handler) ListPeople(c *fiber.Ctx) error {
+	return h.listRelated(c, h.service.ListPeople)
+}
+
+// ListCompanies handles GET /contacts/:id/companies to retrieve the companies
+// related to a contact.
+func (h *Handler) ListCompanies(c *fiber.Ctx) error {
+	return h.listRelated(c, h.service.ListCompanies)
+}
+
+// listRelated renders the contacts returned by list, restricted to the
+// relationships valid today when ?active=true.
+func (h *Handler) listRelated(c *fiber.Ctx, list relatedLister) error {
+	var query RelatedContactsQuery
+	if err := c.QueryParser(&query); err != nil {
+		logger.FromContext(c.UserContext()).Debugw("Failed to parse query", zap.Error(err))
+		return httptransport.ErrInvalidQuery
+	}
+
+	var activeOn time.Time
+	if query.Active {
+		activeOn = time.Now()
+	}
+
+	related, err := list(c.UserContext(), c.Params("id"), activeOn)
+	if err != nil {
+		return err
+	}
+	return httptransport.WriteSuccess(c, ToRelatedContactResponses(related))
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateRelationship_ReturnsCreated verifies the path contact becomes the subject of the relationship.
+func TestCreateRelationship_ReturnsCreated(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	svc.On("CreateRelationship", mock.Anything, &domain.Relationship{
+		Type: domain.RelationEmployeeOf, FromID: "p1", ToID: "c1", Role: "purchasing manager", ValidFrom: &from,
+	}).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.Relationship).ID = "r1" }).
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/contacts/p1/relationships",
+		strings.NewReader(`{"type":"employee_of","contactId":"c1","role":"purchasing manager","validFrom":"2024-03-01"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newTestApp(svc).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 201, resp.StatusCode)
+
+	var body envelope[RelationshipResponse]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "r1", body.Data.ID)
+	require.Equal(t, "2024-03-01", body.Data.ValidFrom)
+	require.Empty(t, body.Data.ValidUntil)
+}
+
+// TestCreateRelationship_InvalidDate verifies dates must be YYYY-MM-DD.
+func TestCreateRelationship_InvalidDate(t *testing.T) {
+	req := httptest.NewRequest("POST", "/contacts/p1/relationships",
+		strings.NewReader(`{"type":"employee_of","contactId":"c1","validUntil":"31/12/2024"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newTestApp(mocks.NewContactService(t)).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 400, resp.StatusCode)
+}
+
+// TestListPeople_Active verifies ?active=true restricts the listing to today.
+func TestListPeople_Active(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("ListPeople", mock.Anything, "c1", mock.MatchedBy(func(at time.Time) bool { return !at.IsZero() })).
+		Return([]*domain.RelatedContact{{
+			Relationship: &domain.Relationship{ID: "r1", Type: domain.RelationEmployeeOf, FromID: "p1", ToID: "c1"},
+			Contact:      &domain.Contact{ID: "p1", FirstName: "Ana", LastName: "Gomez"},
+		}}, nil)
+
+	resp, err := newTestApp(svc).Test(httptest.NewRequest("GET", "/contacts/c1/people?active=true", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	var body envelope[[]RelatedContactResponse]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Data, 1)
+	require.Equal(t, "p1", body.Data[0].Contact.ID)
+	require.Equal(t, "employee_of", body.Data[0].Relationship.Type)
+}
+
+// TestDeleteRelationship_NotFound verifies unknown relationships map to 404.
+func TestDeleteRelationship_NotFound(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("DeleteRelationship", mock.Anything, "p1", "r9").Return(domain.ErrRelationshipNotFound)
+
+	resp, err := newTestApp(svc).Test(httptest.NewRequest("DELETE", "/contacts/p1/relationships/r9", nil))
+	require.NoError(t, err)
+	require.Equal(t, 404, resp.StatusCode)
+}
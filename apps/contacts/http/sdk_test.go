@@ -0,0 +1,358 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/repository"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/service"
+	sdk "github.com/flockstore/mannaiah-backend/clients/contacts"
+	"github.com/flockstore/mannaiah-backend/common/auth"
+	"github.com/flockstore/mannaiah-backend/common/config"
+	"github.com/flockstore/mannaiah-backend/common/database"
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"github.com/flockstore/mannaiah-backend/common/ratelimit"
+	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appDoer sends SDK requests to a Fiber app in memory, optionally rewriting
+// them first to reach errors valid SDK input cannot trigger.
+type appDoer struct {
+	app     *fiber.App
+	rewrite func(r *http.Request)
+}
+
+// Do implements sdk.Doer through App.Test.
+func (d *appDoer) Do(r *http.Request) (*http.Response, error) {
+	if d.rewrite != nil {
+		d.rewrite(r)
+	}
+	return d.app.Test(r, -1)
+}
+
+// newSDKClient serves the contact routes backed by the memory repository with
+// opts and returns an SDK client bound to them, together with its transport.
+func newSDKClient(t *testing.T, opts httptransport.Options, client sdk.Options) (*sdk.Client, *appDoer) {
+	t.Helper()
+
+	handler := New(service.NewContactService(repository.NewMemoryContactRepository(), database.NopTransactor{}))
+	middlewares := opts.Routes
+	opts.Routes = func(router fiber.Router) {
+		if middlewares != nil {
+			middlewares(router)
+		}
+		handler.RegisterRoutes(router.Group(BasePath))
+	}
+
+	doer := &appDoer{app: httptransport.New(opts).App()}
+	client.BaseURL = "http://contacts.test"
+	client.HTTPClient = doer
+	return sdk.New(client), doer
+}
+
+// sdkPerson returns a complete natural person payload with the given document number.
+func sdkPerson(docNumber string) sdk.CreateInput {
+	return sdk.CreateInput{
+		DocumentType: "CC", DocumentNumber: docNumber, FirstName: "Ana", LastName: "Gomez",
+		Address: "Calle 1", AddressExtra: "Apto 2", CityCode: "05001", Phone: "3001234567", Email: "ana@flock.com",
+	}
+}
+
+// sdkCompany returns a complete legal entity payload with the given document number.
+func sdkCompany(docNumber string) sdk.CreateInput {
+	return sdk.CreateInput{
+		DocumentType: "NIT", DocumentNumber: docNumber, LegalName: "Flock SAS",
+		Address: "Carrera 7", CityCode: "11001", Phone: "6017654321", Email: "info@flock.com",
+	}
+}
+
+// TestSDK_RoundTrip drives every SDK call against the real contact routes, so
+// renaming a JSON field on either side breaks this test.
+func TestSDK_RoundTrip(t *testing.T) {
+	client, _ := newSDKClient(t, httptransport.Options{}, sdk.Options{Tenant: "acme"})
+	ctx := context.Background()
+
+	min := 30.0
+	field, err := client.CreateFieldDefinition(ctx, sdk.FieldDefinitionInput{
+		Key: "shoe_size", Type: sdk.FieldNumber, Required: true, Rules: sdk.FieldRules{Min: &min},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, field.ID)
+	assert.Equal(t, "shoe_size", field.Key)
+	assert.Equal(t, sdk.FieldNumber, field.Type)
+	assert.True(t, field.Required)
+	assert.Equal(t, &min, field.Rules.Min)
+	fields, err := client.ListFieldDefinitions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.FieldDefinition{*field}, fields)
+
+	input := sdkPerson("100")
+	input.CustomFields = map[string]any{"shoe_size": 40.0}
+	person, err := client.Create(ctx, input)
+	require.NoError(t, err)
+	assert.NotEmpty(t, person.ID)
+	assert.Equal(t, input.DocumentType, person.DocumentType)
+	assert.Equal(t, input.DocumentNumber, person.DocumentNumber)
+	assert.Equal(t, input.FirstName, person.FirstName)
+	assert.Equal(t, input.LastName, person.LastName)
+	assert.Equal(t, input.Address, person.Address)
+	assert.Equal(t, input.AddressExtra, person.AddressExtra)
+	assert.Equal(t, input.CityCode, person.CityCode)
+	assert.Equal(t, input.Phone, person.Phone)
+	assert.Equal(t, input.Email, person.Email)
+	assert.Equal(t, input.CustomFields, person.CustomFields)
+	assert.NotEmpty(t, person.CreatedAt)
+	assert.NotEmpty(t, person.UpdatedAt)
+
+	got, err := client.Get(ctx, person.ID)
+	require.NoError(t, err)
+	assert.Equal(t, person, got)
+
+	phone := "3109876543"
+	updated, err := client.Update(ctx, person.ID, sdk.PatchInput{Phone: &phone, CustomFields: map[string]any{"shoe_size": 41.0}})
+	require.NoError(t, err)
+	assert.Equal(t, phone, updated.Phone)
+	assert.Equal(t, map[string]any{"shoe_size": 41.0}, updated.CustomFields)
+
+	tag, err := client.CreateTag(ctx, sdk.TagInput{Name: "VIP", Color: "#ff8800", Description: "Top customers"})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.Tag{ID: tag.ID, Name: "VIP", Color: "#ff8800", Description: "Top customers"}, *tag)
+	tags, err := client.ListTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.Tag{*tag}, tags)
+
+	tagged, err := client.TagContact(ctx, person.ID, []string{"vip"})
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.Tag{*tag}, tagged.Tags)
+
+	companyInput := sdkCompany("900")
+	companyInput.CustomFields = map[string]any{"shoe_size": 45.0}
+	company, err := client.Create(ctx, companyInput)
+	require.NoError(t, err)
+	assert.Equal(t, "Flock SAS", company.LegalName)
+	affected, err := client.BulkTag(ctx, sdk.BulkTagInput{
+		Action: sdk.BulkAdd, Tags: []string{"VIP"}, Filter: sdk.ContactFilter{IDs: []string{person.ID, company.ID}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, affected)
+
+	listed, err := client.List(ctx, sdk.ListFilter{
+		Tags: []string{"vip"}, Match: sdk.MatchAll, Email: "ANA@flock.com", Phone: phone,
+		CustomFields: map[string]string{"shoe_size": "41"},
+	})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, person.ID, listed[0].ID)
+
+	rel, err := client.CreateRelationship(ctx, person.ID, sdk.RelationshipInput{
+		Type: sdk.RelationEmployeeOf, ContactID: company.ID, Role: "buyer", ValidFrom: "2020-01-01",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, rel.ID)
+	assert.Equal(t, sdk.RelationEmployeeOf, rel.Type)
+	assert.Equal(t, person.ID, rel.FromID)
+	assert.Equal(t, company.ID, rel.ToID)
+	assert.Equal(t, "buyer", rel.Role)
+	assert.Equal(t, "2020-01-01", rel.ValidFrom)
+	assert.Empty(t, rel.ValidUntil)
+	assert.NotEmpty(t, rel.CreatedAt)
+
+	people, err := client.ListPeople(ctx, company.ID, sdk.RelatedFilter{Active: true})
+	require.NoError(t, err)
+	require.Len(t, people, 1)
+	assert.Equal(t, *rel, people[0].Relationship)
+	assert.Equal(t, person.ID, people[0].Contact.ID)
+	companies, err := client.ListCompanies(ctx, person.ID, sdk.RelatedFilter{})
+	require.NoError(t, err)
+	require.Len(t, companies, 1)
+	assert.Equal(t, company.ID, companies[0].Contact.ID)
+
+	consent := sdk.ConsentInput{
+		Purpose: sdk.PurposeMarketing, Channel: sdk.ChannelEmail, Source: "web_form", PolicyVersion: "v1", IP: "203.0.113.7",
+	}
+	granted, err := client.GrantConsent(ctx, person.ID, consent)
+	require.NoError(t, err)
+	assert.NotEmpty(t, granted.ID)
+	assert.Equal(t, sdk.ConsentRecord{
+		ID: granted.ID, Purpose: consent.Purpose, Channel: consent.Channel, Action: sdk.ConsentGrant,
+		Source: consent.Source, PolicyVersion: consent.PolicyVersion, IP: consent.IP, RecordedAt: granted.RecordedAt,
+	}, *granted)
+	assert.NotEmpty(t, granted.RecordedAt)
+
+	reachable, err := client.List(ctx, sdk.ListFilter{Marketing: sdk.ChannelEmail})
+	require.NoError(t, err)
+	require.Len(t, reachable, 1)
+	require.Len(t, reachable[0].Consents, 1)
+	assert.Equal(t, sdk.ConsentState{
+		Purpose: sdk.PurposeMarketing, Channel: sdk.ChannelEmail, Granted: true,
+		PolicyVersion: "v1", UpdatedAt: granted.RecordedAt,
+	}, reachable[0].Consents[0])
+
+	revoked, err := client.RevokeConsent(ctx, person.ID, consent)
+	require.NoError(t, err)
+	assert.Equal(t, sdk.ConsentRevoke, revoked.Action)
+	history, err := client.ConsentHistory(ctx, person.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.ConsentRecord{*granted, *revoked}, history)
+
+	untagged, err := client.UntagContact(ctx, person.ID, []string{"VIP"})
+	require.NoError(t, err)
+	assert.Empty(t, untagged.Tags)
+	require.NoError(t, client.DeleteRelationship(ctx, person.ID, rel.ID))
+	require.NoError(t, client.DeleteTag(ctx, tag.ID))
+	require.NoError(t, client.DeleteFieldDefinition(ctx, field.ID))
+	require.NoError(t, client.Delete(ctx, person.ID))
+	_, err = client.Get(ctx, person.ID)
+	assert.ErrorIs(t, err, sdk.ErrContactNotFound)
+}
+
+// TestSDK_ErrorSentinels triggers every error code through the real routes and
+// checks the SDK decodes it into the sentinel matching the server error.
+func TestSDK_ErrorSentinels(t *testing.T) {
+	client, doer := newSDKClient(t, httptransport.Options{}, sdk.Options{Tenant: "acme"})
+	ctx := context.Background()
+
+	person, err := client.Create(ctx, sdkPerson("100"))
+	require.NoError(t, err)
+	company, err := client.Create(ctx, sdkCompany("900"))
+	require.NoError(t, err)
+	_, err = client.CreateTag(ctx, sdk.TagInput{Name: "VIP"})
+	require.NoError(t, err)
+	_, err = client.CreateFieldDefinition(ctx, sdk.FieldDefinitionInput{Key: "shoe_size", Type: sdk.FieldNumber})
+	require.NoError(t, err)
+	_, err = client.CreateRelationship(ctx, person.ID, sdk.RelationshipInput{Type: sdk.RelationEmployeeOf, ContactID: company.ID})
+	require.NoError(t, err)
+
+	both := sdkPerson("200")
+	both.LegalName = "Flock SAS"
+	nameless := sdkPerson("300")
+	nameless.FirstName, nameless.LastName = "", ""
+	undefined := sdkPerson("400")
+	undefined.CustomFields = map[string]any{"unknown": 1}
+
+	cases := []struct {
+		name    string
+		call    func() error
+		rewrite func(r *http.Request)
+		server  error
+		want    error
+	}{
+		{name: "contact not found", server: domain.ErrContactNotFound, want: sdk.ErrContactNotFound,
+			call: func() error { _, err := client.Get(ctx, "missing"); return err }},
+		{name: "duplicate document", server: domain.ErrDuplicateDocument, want: sdk.ErrDuplicateDocument,
+			call: func() error { _, err := client.Create(ctx, sdkPerson("100")); return err }},
+		{name: "invalid name combination", server: domain.ErrInvalidNameCombination, want: sdk.ErrInvalidNameCombination,
+			call: func() error { _, err := client.Create(ctx, both); return err }},
+		{name: "missing name", server: domain.ErrMissingName, want: sdk.ErrMissingName,
+			call: func() error { _, err := client.Create(ctx, nameless); return err }},
+		{name: "invalid custom fields", server: domain.ErrInvalidCustomFields, want: sdk.ErrInvalidCustomFields,
+			call: func() error { _, err := client.Create(ctx, undefined); return err }},
+		{name: "empty filter", server: domain.ErrEmptyFilter, want: sdk.ErrEmptyFilter,
+			call: func() error {
+				_, err := client.BulkTag(ctx, sdk.BulkTagInput{Action: sdk.BulkAdd, Tags: []string{"VIP"}})
+				return err
+			}},
+		{name: "tag not found", server: domain.ErrTagNotFound, want: sdk.ErrTagNotFound,
+			call: func() error { _, err := client.TagContact(ctx, person.ID, []string{"missing"}); return err }},
+		{name: "duplicate tag", server: domain.ErrDuplicateTag, want: sdk.ErrDuplicateTag,
+			call: func() error { _, err := client.CreateTag(ctx, sdk.TagInput{Name: "vip"}); return err }},
+		{name: "invalid tag name", server: domain.ErrInvalidTagName, want: sdk.ErrInvalidTagName,
+			call: func() error { _, err := client.CreateTag(ctx, sdk.TagInput{Name: " "}); return err }},
+		{name: "invalid field definition", server: domain.ErrInvalidFieldDefinition, want: sdk.ErrInvalidFieldDefinition,
+			call: func() error {
+				_, err := client.CreateFieldDefinition(ctx, sdk.FieldDefinitionInput{Key: "Shoe Size", Type: sdk.FieldNumber})
+				return err
+			}},
+		{name: "duplicate field key", server: domain.ErrDuplicateFieldDefinition, want: sdk.ErrDuplicateFieldKey,
+			call: func() error {
+				_, err := client.CreateFieldDefinition(ctx, sdk.FieldDefinitionInput{Key: "shoe_size", Type: sdk.FieldString})
+				return err
+			}},
+		{name: "field not found", server: domain.ErrFieldDefinitionNotFound, want: sdk.ErrFieldNotFound,
+			call: func() error { return client.DeleteFieldDefinition(ctx, "missing") }},
+		{name: "invalid relationship", server: domain.ErrInvalidRelationship, want: sdk.ErrInvalidRelationship,
+			call: func() error {
+				_, err := client.CreateRelationship(ctx, company.ID, sdk.RelationshipInput{Type: sdk.RelationEmployeeOf, ContactID: person.ID})
+				return err
+			}},
+		{name: "duplicate relationship", server: domain.ErrDuplicateRelationship, want: sdk.ErrDuplicateRelationship,
+			call: func() error {
+				_, err := client.CreateRelationship(ctx, person.ID, sdk.RelationshipInput{Type: sdk.RelationEmployeeOf, ContactID: company.ID})
+				return err
+			}},
+		{name: "relationship not found", server: domain.ErrRelationshipNotFound, want: sdk.ErrRelationshipNotFound,
+			call: func() error { return client.DeleteRelationship(ctx, person.ID, "missing") }},
+		{name: "invalid consent", server: domain.ErrInvalidConsent, want: sdk.ErrInvalidConsent,
+			call: func() error {
+				_, err := client.GrantConsent(ctx, person.ID, sdk.ConsentInput{
+					Purpose: sdk.PurposeProfiling, Channel: sdk.ChannelEmail, Source: "web_form", PolicyVersion: "v1",
+				})
+				return err
+			}},
+		{name: "validation failed", server: httptransport.ErrValidationFailed, want: sdk.ErrValidationFailed,
+			call: func() error { _, err := client.Create(ctx, sdk.CreateInput{}); return err }},
+		{name: "invalid body", server: httptransport.ErrInvalidBody, want: sdk.ErrInvalidBody,
+			rewrite: func(r *http.Request) { r.Body, r.ContentLength = http.NoBody, 1 },
+			call:    func() error { _, err := client.Create(ctx, sdkPerson("500")); return err }},
+		{name: "invalid query", server: httptransport.ErrInvalidQuery, want: sdk.ErrInvalidQuery,
+			rewrite: func(r *http.Request) { r.URL.RawQuery = "active=sometimes" },
+			call:    func() error { _, err := client.ListPeople(ctx, company.ID, sdk.RelatedFilter{}); return err }},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			doer.rewrite = tc.rewrite
+			t.Cleanup(func() { doer.rewrite = nil })
+			assertSentinel(t, tc.call(), tc.server, tc.want)
+		})
+	}
+}
+
+// TestSDK_RequestErrorSentinels checks the authentication, tenant and rate
+// limit errors of the transport decode into their SDK sentinels.
+func TestSDK_RequestErrorSentinels(t *testing.T) {
+	keys, err := auth.ParseAPIKeys([]string{"crm@acme=secret"})
+	require.NoError(t, err)
+	opts, err := httptransport.NewRateLimitOptions("contacts", config.RateLimitConfig{
+		Requests: 1, Window: 60, Algorithm: "sliding_window", KeyBy: "api_key",
+	}, ratelimit.NewMemoryStore())
+	require.NoError(t, err)
+	server := httptransport.Options{
+		APIKeys: keys,
+		Routes: func(router fiber.Router) {
+			router.Use(BasePath, httptransport.RateLimitMiddleware(opts))
+		},
+	}
+	ctx := context.Background()
+
+	anonymous, _ := newSDKClient(t, server, sdk.Options{})
+	_, err = anonymous.List(ctx, sdk.ListFilter{})
+	assertSentinel(t, err, httptransport.ErrUnauthenticated, sdk.ErrUnauthenticated)
+
+	intruder, _ := newSDKClient(t, server, sdk.Options{APIKey: "secret", Tenant: "globex"})
+	_, err = intruder.List(ctx, sdk.ListFilter{})
+	assertSentinel(t, err, httptransport.ErrTenantForbidden, sdk.ErrTenantForbidden)
+
+	client, _ := newSDKClient(t, server, sdk.Options{APIKey: "secret"})
+	_, err = client.List(ctx, sdk.ListFilter{})
+	require.NoError(t, err)
+	_, err = client.List(ctx, sdk.ListFilter{})
+	assertSentinel(t, err, httptransport.ErrRateLimited, sdk.ErrRateLimited)
+}
+
+// assertSentinel checks err carries the code of the server error and matches the SDK sentinel.
+func assertSentinel(t *testing.T, err, server, want error) {
+	t.Helper()
+
+	var apiErr *sdk.Error
+	require.True(t, errors.As(err, &apiErr), "want an API error, got %v", err)
+	assert.Equal(t, apperrors.From(server).Code, apiErr.Reason)
+	assert.Equal(t, apperrors.From(server).Status, apiErr.StatusCode)
+	assert.ErrorIs(t, err, want)
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
+	"github.com/gofiber/fiber/v2"
+)
+
+// bulkActionAdd links tags in a BulkTagInput; "remove" unlinks them.
+const bulkActionAdd = "add"
+
+// CreateTag handles POST /contacts/tags to add a tag to the catalog.
+func (h *Handler) CreateTag(c *fiber.Ctx) error {
+	var input TagInput
+	if err := h.parse(c, &input); err != nil {
+		return err
+	}
+
+	tag := ToDomainTag(input)
+	if err := h.service.CreateTag(c.UserContext(), tag); err != nil {
+		return err
+	}
+	return httptransport.WriteCreated(c, ToTagResponse(*tag))
+}
+
+// ListTags handles GET /contacts/tags to retrieve the tag catalog.
+func (h *Handler) ListTags(c *fiber.Ctx) error {
+	tags, err := h.service.ListTags(c.UserContext())
+	if err != nil {
+		return err
+	}
+	response := make([]TagResponse, len(tags))
+	for i, t := range tags {
+		response[i] = ToTagResponse(*t)
+	}
+	return httptransport.WriteSuccess(c, response)
+}
+
+// DeleteTag handles DELETE /contacts/tags/:tagId to remove a tag from the
+// catalog and from every contact.
+func (h *Handler) DeleteTag(c *fiber.Ctx) error {
+	if err := h.service.DeleteTag(c.UserContext(), c.Params("tagId")); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// BulkTag handles POST /contacts/tags/bulk to link or unlink tags on every
+// contact matching a filter.
+func (h *Handler) BulkTag(c *fiber.Ctx) error {
+	var input BulkTagInput
+	if err := h.parse(c, &input); err != nil {
+		return err
+	}
+
+	change := h.service.BulkUntag
+	if input.Action == bulkActionAdd {
+		change = h.service.BulkTag
+	}
+	affected, err := change(c.UserContext(), ToDomainFilter(input.Filter), input.Tags)
+	if err != nil {
+		return err
+	}
+	return httptransport.WriteSuccess(c, BulkTagResponse{Affected: affected})
+}
+
+// TagContact handles POST /contacts/:id/tags to link tags to a contact.
+func (h *Handler) TagContact(c *fiber.Ctx) error {
+	var input ContactTagsInput
+	if err := h.parse(c, &input); err != nil {
+		return err
+	}
+
+	contact, err := h.service.TagContact(c.UserContext(), c.Params("id"), input.Tags)
+	if err != nil {
+		return err
+	}
+	return httptransport.WriteSuccess(c, ToResponseDTO(contact))
+}
+
+// UntagContact handles DELETE /contacts/:id/tags to unlink tags from a contact.
+func (h *Handler) UntagContact(c *fiber.Ctx) error {
+	var input ContactTagsInput
+	if err := h.parse(c, &input); err != nil {
+		return err
+	}
+
+	contact, err := h.service.UntagContact(c.UserContext(), c.Params("id"), input.Tags)
+	if err != nil {
+		return err
+	}
+	return httptransport.WriteSuccess(c, ToResponseDTO(contact))
+}
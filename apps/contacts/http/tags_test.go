@@ -0,0 +1,113 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	httptransport "github.com/flockstore/mannaiah-backend/common/transport/http"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListContacts_FiltersByTags verifies the tags query is split into a filter.
+func TestListContacts_FiltersByTags(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	filter := domain.ContactFilter{Tags: []string{"vip", "wholesale"}, Match: domain.MatchAll}
+	svc.On("List", mock.Anything, filter).
+		Return([]*domain.Contact{{ID: "c1", Tags: []domain.Tag{{ID: "t1", Name: "VIP", Color: "#ff8800"}}}}, nil)
+
+	resp, err := newTestApp(svc).Test(httptest.NewRequest("GET", "/contacts?tags=vip,wholesale&match=all", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	var body envelope[[]ContactResponse]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Data, 1)
+	require.Equal(t, []TagResponse{{ID: "t1", Name: "VIP", Color: "#ff8800"}}, body.Data[0].Tags)
+}
+
+// TestListContacts_InvalidMatch verifies unknown match modes are rejected.
+func TestListContacts_InvalidMatch(t *testing.T) {
+	resp, err := newTestApp(mocks.NewContactService(t)).Test(httptest.NewRequest("GET", "/contacts?tags=vip&match=some", nil))
+	require.NoError(t, err)
+	require.Equal(t, 400, resp.StatusCode)
+}
+
+// TestCreateTag_ReturnsCreated verifies tag creation is routed ahead of contact IDs.
+func TestCreateTag_ReturnsCreated(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("CreateTag", mock.Anything, &domain.Tag{Name: "VIP", Color: "#ff8800"}).
+		Run(func(args mock.Arguments) { args.Get(1).(*domain.Tag).ID = "t1" }).
+		Return(nil)
+
+	req := httptest.NewRequest("POST", "/contacts/tags", strings.NewReader(`{"name":"VIP","color":"#ff8800"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newTestApp(svc).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 201, resp.StatusCode)
+
+	var body envelope[TagResponse]
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "t1", body.Data.ID)
+}
+
+// TestCreateTag_InvalidColor verifies colors must be hex.
+func TestCreateTag_InvalidColor(t *testing.T) {
+	req := httptest.NewRequest("POST", "/contacts/tags", strings.NewReader(`{"name":"VIP","color":"orange"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newTestApp(mocks.NewContactService(t)).Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 400, resp.StatusCode)
+}
+
+// TestTagAndUntagContact verifies POST and DELETE on a contact's tags call the matching operation.
+func TestTagAndUntagContact(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	svc.On("TagContact", mock.Anything, "c1", []string{"VIP"}).Return(&domain.Contact{ID: "c1"}, nil)
+	svc.On("UntagContact", mock.Anything, "c1", []string{"VIP"}).Return(&domain.Contact{ID: "c1"}, nil)
+	app := newTestApp(svc)
+
+	for _, method := range []string{"POST", "DELETE"} {
+		req := httptest.NewRequest(method, "/contacts/c1/tags", strings.NewReader(`{"tags":["VIP"]}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode, method)
+	}
+}
+
+// TestBulkTag verifies bulk requests are dispatched by action and report affected links.
+func TestBulkTag(t *testing.T) {
+	svc := mocks.NewContactService(t)
+	filter := domain.ContactFilter{Tags: []string{"wholesale"}, Match: domain.MatchAny}
+	svc.On("BulkTag", mock.Anything, filter, []string{"Black Friday 2025"}).Return(3, nil)
+	svc.On("BulkUntag", mock.Anything, filter, []string{"Black Friday 2025"}).Return(0, domain.ErrEmptyFilter)
+	app := newTestApp(svc)
+
+	for action, want := range map[string]int{"add": 200, "remove": 400} {
+		payload := `{"action":"` + action + `","tags":["Black Friday 2025"],"filter":{"tags":["wholesale"],"match":"any"}}`
+		req := httptest.NewRequest("POST", "/contacts/tags/bulk", strings.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, want, resp.StatusCode, action)
+
+		if want == 200 {
+			var body envelope[BulkTagResponse]
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			require.Equal(t, 3, body.Data.Affected)
+		} else {
+			var body httptransport.ErrorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			require.Equal(t, "contact.empty_filter", body.Error.Reason)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE rate_limit_windows;
+DROP TABLE rate_limit_buckets;
\ No newline at end of file
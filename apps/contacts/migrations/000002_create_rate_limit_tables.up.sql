@@ -0,0 +1,15 @@
+CREATE TABLE rate_limit_buckets (
+                          key TEXT PRIMARY KEY,
+                          tat BIGINT NOT NULL
+);
+
+CREATE TABLE rate_limit_windows (
+                          key TEXT NOT NULL,
+                          window_start BIGINT NOT NULL,
+                          hits INTEGER NOT NULL,
+                          expires_at BIGINT NOT NULL,
+                          PRIMARY KEY (key, window_start)
+);
+
+CREATE INDEX idx_rate_limit_buckets_tat ON rate_limit_buckets (tat);
+CREATE INDEX idx_rate_limit_windows_expires_at ON rate_limit_windows (expires_at);
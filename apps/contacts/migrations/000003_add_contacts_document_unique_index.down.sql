@@ -0,0 +1 @@
+DROP INDEX idx_contacts_document_active;
\ No newline at end of file
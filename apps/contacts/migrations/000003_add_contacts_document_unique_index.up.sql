@@ -0,0 +1,21 @@
+-- Older rows may already share a document. Keep the oldest active contact of
+-- each document and soft-delete the rest so the unique index can be built.
+-- Rows without a document never conflict and are left alone.
+UPDATE contacts
+SET deleted_at = NOW(),
+    updated_at = NOW()
+WHERE deleted_at IS NULL
+  AND id IN (
+    SELECT id
+    FROM (
+        SELECT id,
+               ROW_NUMBER() OVER (PARTITION BY doc_type, doc_number ORDER BY created_at, id) AS position
+        FROM contacts
+        WHERE deleted_at IS NULL
+          AND doc_type IS NOT NULL
+          AND doc_number IS NOT NULL
+    ) ranked
+    WHERE position > 1
+);
+
+CREATE UNIQUE INDEX idx_contacts_document_active ON contacts (doc_type, doc_number) WHERE deleted_at IS NULL;
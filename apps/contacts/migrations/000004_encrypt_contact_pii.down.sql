@@ -0,0 +1,13 @@
+-- Encrypted values are left as they are; decrypt them before rolling back.
+DROP INDEX idx_contacts_key_version;
+DROP INDEX idx_contacts_phone_bidx;
+DROP INDEX idx_contacts_email_bidx;
+
+DROP INDEX idx_contacts_document_active;
+CREATE UNIQUE INDEX idx_contacts_document_active ON contacts (doc_type, doc_number) WHERE deleted_at IS NULL;
+
+ALTER TABLE contacts
+    DROP COLUMN key_version,
+    DROP COLUMN phone_bidx,
+    DROP COLUMN email_bidx,
+    DROP COLUMN doc_number_bidx;
@@ -0,0 +1,16 @@
+-- doc_number, email and phone hold AES-GCM ciphertexts once encrypted; the
+-- *_bidx columns hold HMAC blind indexes used for equality lookups.
+-- key_version is NULL for rows written before encryption, until
+-- `contacts keys reencrypt` seals them.
+ALTER TABLE contacts
+    ADD COLUMN doc_number_bidx TEXT,
+    ADD COLUMN email_bidx TEXT,
+    ADD COLUMN phone_bidx TEXT,
+    ADD COLUMN key_version INTEGER;
+
+DROP INDEX idx_contacts_document_active;
+CREATE UNIQUE INDEX idx_contacts_document_active ON contacts (doc_type, COALESCE(doc_number_bidx, doc_number)) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_contacts_email_bidx ON contacts (email_bidx);
+CREATE INDEX idx_contacts_phone_bidx ON contacts (phone_bidx);
+CREATE INDEX idx_contacts_key_version ON contacts (key_version);
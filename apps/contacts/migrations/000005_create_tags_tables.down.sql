@@ -0,0 +1,2 @@
+DROP TABLE contact_tags;
+DROP TABLE tags;
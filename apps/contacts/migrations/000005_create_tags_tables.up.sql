@@ -0,0 +1,19 @@
+CREATE TABLE tags (
+                          id TEXT PRIMARY KEY,
+                          name TEXT NOT NULL,
+                          color TEXT,
+                          description TEXT,
+                          created_at TIMESTAMP NOT NULL,
+                          updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_tags_name ON tags (lower(name));
+
+CREATE TABLE contact_tags (
+                          contact_id TEXT NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+                          tag_id TEXT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+                          created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                          PRIMARY KEY (contact_id, tag_id)
+);
+
+CREATE INDEX idx_contact_tags_tag_id ON contact_tags (tag_id);
@@ -0,0 +1,3 @@
+DROP TABLE custom_field_definitions;
+DROP INDEX IF EXISTS idx_contacts_custom_fields;
+ALTER TABLE contacts DROP COLUMN custom_fields;
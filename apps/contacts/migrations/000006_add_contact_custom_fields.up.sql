@@ -0,0 +1,16 @@
+ALTER TABLE contacts ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_contacts_custom_fields ON contacts USING GIN (custom_fields jsonb_path_ops);
+
+CREATE TABLE custom_field_definitions (
+                          id TEXT PRIMARY KEY,
+                          tenant TEXT NOT NULL DEFAULT '',
+                          key TEXT NOT NULL,
+                          type TEXT NOT NULL,
+                          required BOOLEAN NOT NULL DEFAULT FALSE,
+                          rules JSONB NOT NULL DEFAULT '{}',
+                          created_at TIMESTAMP NOT NULL,
+                          updated_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_custom_field_definitions_key ON custom_field_definitions (tenant, key);
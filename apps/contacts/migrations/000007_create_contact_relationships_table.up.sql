@@ -0,0 +1,15 @@
+CREATE TABLE contact_relationships (
+                          id TEXT PRIMARY KEY,
+                          type TEXT NOT NULL,
+                          from_id TEXT NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+                          to_id TEXT NOT NULL REFERENCES contacts (id) ON DELETE CASCADE,
+                          role TEXT,
+                          valid_from DATE,
+                          valid_until DATE,
+                          created_at TIMESTAMP NOT NULL,
+                          updated_at TIMESTAMP NOT NULL,
+                          CHECK (from_id <> to_id)
+);
+
+CREATE UNIQUE INDEX idx_contact_relationships_link ON contact_relationships (from_id, to_id, type);
+CREATE INDEX idx_contact_relationships_to_id ON contact_relationships (to_id);
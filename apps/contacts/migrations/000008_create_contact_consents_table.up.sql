@@ -0,0 +1,13 @@
+CREATE TABLE contact_consents (
+                          id TEXT PRIMARY KEY,
+                          contact_id TEXT NOT NULL REFERENCES contacts (id),
+                          purpose TEXT NOT NULL,
+                          channel TEXT NOT NULL DEFAULT '',
+                          action TEXT NOT NULL,
+                          source TEXT NOT NULL,
+                          policy_version TEXT NOT NULL,
+                          ip TEXT,
+                          recorded_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_contact_consents_latest ON contact_consents (contact_id, purpose, channel, recorded_at DESC, id DESC);
@@ -0,0 +1,8 @@
+-- Fails when two tenants hold an active contact with the same document;
+-- resolve those before rolling back.
+DROP INDEX idx_contacts_tenant;
+
+DROP INDEX idx_contacts_document_active;
+CREATE UNIQUE INDEX idx_contacts_document_active ON contacts (doc_type, COALESCE(doc_number_bidx, doc_number)) WHERE deleted_at IS NULL;
+
+ALTER TABLE contacts DROP COLUMN tenant;
@@ -0,0 +1,8 @@
+-- Existing contacts belong to the default (empty) tenant, like custom field
+-- definitions created without one.
+ALTER TABLE contacts ADD COLUMN tenant TEXT NOT NULL DEFAULT '';
+
+DROP INDEX idx_contacts_document_active;
+CREATE UNIQUE INDEX idx_contacts_document_active ON contacts (tenant, doc_type, COALESCE(doc_number_bidx, doc_number)) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_contacts_tenant ON contacts (tenant, created_at, id) WHERE deleted_at IS NULL;
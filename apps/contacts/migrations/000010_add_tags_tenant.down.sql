@@ -0,0 +1,6 @@
+-- Fails when two tenants hold a tag with the same name; resolve those before
+-- rolling back.
+DROP INDEX idx_tags_name;
+CREATE UNIQUE INDEX idx_tags_name ON tags (lower(name));
+
+ALTER TABLE tags DROP COLUMN tenant;
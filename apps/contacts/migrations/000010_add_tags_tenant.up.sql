@@ -0,0 +1,5 @@
+-- Existing tags belong to the default (empty) tenant, like existing contacts.
+ALTER TABLE tags ADD COLUMN tenant TEXT NOT NULL DEFAULT '';
+
+DROP INDEX idx_tags_name;
+CREATE UNIQUE INDEX idx_tags_name ON tags (tenant, lower(name));
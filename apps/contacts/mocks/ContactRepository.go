@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	domain "github.com/flockstore/mannaiah-backend/apps/contacts/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,17 +22,122 @@ func (_m *ContactRepository) EXPECT() *ContactRepository_Expecter {
 	return &ContactRepository_Expecter{mock: &_m.Mock}
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *ContactRepository) Delete(id string) error {
-	ret := _m.Called(id)
+// AddConsent provides a mock function with given fields: ctx, r
+func (_m *ContactRepository) AddConsent(ctx context.Context, r *domain.ConsentRecord) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddConsent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ConsentRecord) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepository_AddConsent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddConsent'
+type ContactRepository_AddConsent_Call struct {
+	*mock.Call
+}
+
+// AddConsent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *domain.ConsentRecord
+func (_e *ContactRepository_Expecter) AddConsent(ctx interface{}, r interface{}) *ContactRepository_AddConsent_Call {
+	return &ContactRepository_AddConsent_Call{Call: _e.mock.On("AddConsent", ctx, r)}
+}
+
+func (_c *ContactRepository_AddConsent_Call) Run(run func(ctx context.Context, r *domain.ConsentRecord)) *ContactRepository_AddConsent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ConsentRecord))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_AddConsent_Call) Return(_a0 error) *ContactRepository_AddConsent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepository_AddConsent_Call) RunAndReturn(run func(context.Context, *domain.ConsentRecord) error) *ContactRepository_AddConsent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddTags provides a mock function with given fields: ctx, filter, tagIDs
+func (_m *ContactRepository) AddTags(ctx context.Context, filter domain.ContactFilter, tagIDs []string) (int, error) {
+	ret := _m.Called(ctx, filter, tagIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTags")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter, []string) (int, error)); ok {
+		return rf(ctx, filter, tagIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter, []string) int); ok {
+		r0 = rf(ctx, filter, tagIDs)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ContactFilter, []string) error); ok {
+		r1 = rf(ctx, filter, tagIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactRepository_AddTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTags'
+type ContactRepository_AddTags_Call struct {
+	*mock.Call
+}
+
+// AddTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ContactFilter
+//   - tagIDs []string
+func (_e *ContactRepository_Expecter) AddTags(ctx interface{}, filter interface{}, tagIDs interface{}) *ContactRepository_AddTags_Call {
+	return &ContactRepository_AddTags_Call{Call: _e.mock.On("AddTags", ctx, filter, tagIDs)}
+}
+
+func (_c *ContactRepository_AddTags_Call) Run(run func(ctx context.Context, filter domain.ContactFilter, tagIDs []string)) *ContactRepository_AddTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ContactFilter), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_AddTags_Call) Return(_a0 int, _a1 error) *ContactRepository_AddTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactRepository_AddTags_Call) RunAndReturn(run func(context.Context, domain.ContactFilter, []string) (int, error)) *ContactRepository_AddTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, tenant, id
+func (_m *ContactRepository) Delete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -44,14 +151,16 @@ type ContactRepository_Delete_Call struct {
 }
 
 // Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
 //   - id string
-func (_e *ContactRepository_Expecter) Delete(id interface{}) *ContactRepository_Delete_Call {
-	return &ContactRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+func (_e *ContactRepository_Expecter) Delete(ctx interface{}, tenant interface{}, id interface{}) *ContactRepository_Delete_Call {
+	return &ContactRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, tenant, id)}
 }
 
-func (_c *ContactRepository_Delete_Call) Run(run func(id string)) *ContactRepository_Delete_Call {
+func (_c *ContactRepository_Delete_Call) Run(run func(ctx context.Context, tenant string, id string)) *ContactRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -61,14 +170,158 @@ func (_c *ContactRepository_Delete_Call) Return(_a0 error) *ContactRepository_De
 	return _c
 }
 
-func (_c *ContactRepository_Delete_Call) RunAndReturn(run func(string) error) *ContactRepository_Delete_Call {
+func (_c *ContactRepository_Delete_Call) RunAndReturn(run func(context.Context, string, string) error) *ContactRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFieldDefinition provides a mock function with given fields: ctx, tenant, id
+func (_m *ContactRepository) DeleteFieldDefinition(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFieldDefinition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepository_DeleteFieldDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFieldDefinition'
+type ContactRepository_DeleteFieldDefinition_Call struct {
+	*mock.Call
+}
+
+// DeleteFieldDefinition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - id string
+func (_e *ContactRepository_Expecter) DeleteFieldDefinition(ctx interface{}, tenant interface{}, id interface{}) *ContactRepository_DeleteFieldDefinition_Call {
+	return &ContactRepository_DeleteFieldDefinition_Call{Call: _e.mock.On("DeleteFieldDefinition", ctx, tenant, id)}
+}
+
+func (_c *ContactRepository_DeleteFieldDefinition_Call) Run(run func(ctx context.Context, tenant string, id string)) *ContactRepository_DeleteFieldDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_DeleteFieldDefinition_Call) Return(_a0 error) *ContactRepository_DeleteFieldDefinition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepository_DeleteFieldDefinition_Call) RunAndReturn(run func(context.Context, string, string) error) *ContactRepository_DeleteFieldDefinition_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByDocument provides a mock function with given fields: docType, docNumber
-func (_m *ContactRepository) GetByDocument(docType domain.DocumentType, docNumber string) (*domain.Contact, error) {
-	ret := _m.Called(docType, docNumber)
+// DeleteRelationship provides a mock function with given fields: ctx, contactID, id
+func (_m *ContactRepository) DeleteRelationship(ctx context.Context, contactID string, id string) error {
+	ret := _m.Called(ctx, contactID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRelationship")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, contactID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepository_DeleteRelationship_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRelationship'
+type ContactRepository_DeleteRelationship_Call struct {
+	*mock.Call
+}
+
+// DeleteRelationship is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contactID string
+//   - id string
+func (_e *ContactRepository_Expecter) DeleteRelationship(ctx interface{}, contactID interface{}, id interface{}) *ContactRepository_DeleteRelationship_Call {
+	return &ContactRepository_DeleteRelationship_Call{Call: _e.mock.On("DeleteRelationship", ctx, contactID, id)}
+}
+
+func (_c *ContactRepository_DeleteRelationship_Call) Run(run func(ctx context.Context, contactID string, id string)) *ContactRepository_DeleteRelationship_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_DeleteRelationship_Call) Return(_a0 error) *ContactRepository_DeleteRelationship_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepository_DeleteRelationship_Call) RunAndReturn(run func(context.Context, string, string) error) *ContactRepository_DeleteRelationship_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTag provides a mock function with given fields: ctx, tenant, id
+func (_m *ContactRepository) DeleteTag(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepository_DeleteTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTag'
+type ContactRepository_DeleteTag_Call struct {
+	*mock.Call
+}
+
+// DeleteTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - id string
+func (_e *ContactRepository_Expecter) DeleteTag(ctx interface{}, tenant interface{}, id interface{}) *ContactRepository_DeleteTag_Call {
+	return &ContactRepository_DeleteTag_Call{Call: _e.mock.On("DeleteTag", ctx, tenant, id)}
+}
+
+func (_c *ContactRepository_DeleteTag_Call) Run(run func(ctx context.Context, tenant string, id string)) *ContactRepository_DeleteTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_DeleteTag_Call) Return(_a0 error) *ContactRepository_DeleteTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepository_DeleteTag_Call) RunAndReturn(run func(context.Context, string, string) error) *ContactRepository_DeleteTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByDocument provides a mock function with given fields: ctx, tenant, docType, docNumber
+func (_m *ContactRepository) GetByDocument(ctx context.Context, tenant string, docType domain.DocumentType, docNumber string) (*domain.Contact, error) {
+	ret := _m.Called(ctx, tenant, docType, docNumber)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByDocument")
@@ -76,19 +329,19 @@ func (_m *ContactRepository) GetByDocument(docType domain.DocumentType, docNumbe
 
 	var r0 *domain.Contact
 	var r1 error
-	if rf, ok := ret.Get(0).(func(domain.DocumentType, string) (*domain.Contact, error)); ok {
-		return rf(docType, docNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.DocumentType, string) (*domain.Contact, error)); ok {
+		return rf(ctx, tenant, docType, docNumber)
 	}
-	if rf, ok := ret.Get(0).(func(domain.DocumentType, string) *domain.Contact); ok {
-		r0 = rf(docType, docNumber)
+	if rf, ok := ret.Get(0).(func(context.Context, string, domain.DocumentType, string) *domain.Contact); ok {
+		r0 = rf(ctx, tenant, docType, docNumber)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Contact)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(domain.DocumentType, string) error); ok {
-		r1 = rf(docType, docNumber)
+	if rf, ok := ret.Get(1).(func(context.Context, string, domain.DocumentType, string) error); ok {
+		r1 = rf(ctx, tenant, docType, docNumber)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,15 +355,17 @@ type ContactRepository_GetByDocument_Call struct {
 }
 
 // GetByDocument is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
 //   - docType domain.DocumentType
 //   - docNumber string
-func (_e *ContactRepository_Expecter) GetByDocument(docType interface{}, docNumber interface{}) *ContactRepository_GetByDocument_Call {
-	return &ContactRepository_GetByDocument_Call{Call: _e.mock.On("GetByDocument", docType, docNumber)}
+func (_e *ContactRepository_Expecter) GetByDocument(ctx interface{}, tenant interface{}, docType interface{}, docNumber interface{}) *ContactRepository_GetByDocument_Call {
+	return &ContactRepository_GetByDocument_Call{Call: _e.mock.On("GetByDocument", ctx, tenant, docType, docNumber)}
 }
 
-func (_c *ContactRepository_GetByDocument_Call) Run(run func(docType domain.DocumentType, docNumber string)) *ContactRepository_GetByDocument_Call {
+func (_c *ContactRepository_GetByDocument_Call) Run(run func(ctx context.Context, tenant string, docType domain.DocumentType, docNumber string)) *ContactRepository_GetByDocument_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(domain.DocumentType), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.DocumentType), args[3].(string))
 	})
 	return _c
 }
@@ -120,14 +375,14 @@ func (_c *ContactRepository_GetByDocument_Call) Return(_a0 *domain.Contact, _a1
 	return _c
 }
 
-func (_c *ContactRepository_GetByDocument_Call) RunAndReturn(run func(domain.DocumentType, string) (*domain.Contact, error)) *ContactRepository_GetByDocument_Call {
+func (_c *ContactRepository_GetByDocument_Call) RunAndReturn(run func(context.Context, string, domain.DocumentType, string) (*domain.Contact, error)) *ContactRepository_GetByDocument_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByID provides a mock function with given fields: id
-func (_m *ContactRepository) GetByID(id string) (*domain.Contact, error) {
-	ret := _m.Called(id)
+// GetByID provides a mock function with given fields: ctx, tenant, id
+func (_m *ContactRepository) GetByID(ctx context.Context, tenant string, id string) (*domain.Contact, error) {
+	ret := _m.Called(ctx, tenant, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByID")
@@ -135,19 +390,19 @@ func (_m *ContactRepository) GetByID(id string) (*domain.Contact, error) {
 
 	var r0 *domain.Contact
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*domain.Contact, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*domain.Contact, error)); ok {
+		return rf(ctx, tenant, id)
 	}
-	if rf, ok := ret.Get(0).(func(string) *domain.Contact); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *domain.Contact); ok {
+		r0 = rf(ctx, tenant, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Contact)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -161,14 +416,16 @@ type ContactRepository_GetByID_Call struct {
 }
 
 // GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
 //   - id string
-func (_e *ContactRepository_Expecter) GetByID(id interface{}) *ContactRepository_GetByID_Call {
-	return &ContactRepository_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+func (_e *ContactRepository_Expecter) GetByID(ctx interface{}, tenant interface{}, id interface{}) *ContactRepository_GetByID_Call {
+	return &ContactRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, tenant, id)}
 }
 
-func (_c *ContactRepository_GetByID_Call) Run(run func(id string)) *ContactRepository_GetByID_Call {
+func (_c *ContactRepository_GetByID_Call) Run(run func(ctx context.Context, tenant string, id string)) *ContactRepository_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -178,14 +435,74 @@ func (_c *ContactRepository_GetByID_Call) Return(_a0 *domain.Contact, _a1 error)
 	return _c
 }
 
-func (_c *ContactRepository_GetByID_Call) RunAndReturn(run func(string) (*domain.Contact, error)) *ContactRepository_GetByID_Call {
+func (_c *ContactRepository_GetByID_Call) RunAndReturn(run func(context.Context, string, string) (*domain.Contact, error)) *ContactRepository_GetByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// List provides a mock function with no fields
-func (_m *ContactRepository) List() ([]*domain.Contact, error) {
-	ret := _m.Called()
+// GetTagsByName provides a mock function with given fields: ctx, tenant, names
+func (_m *ContactRepository) GetTagsByName(ctx context.Context, tenant string, names []string) ([]*domain.Tag, error) {
+	ret := _m.Called(ctx, tenant, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTagsByName")
+	}
+
+	var r0 []*domain.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]*domain.Tag, error)); ok {
+		return rf(ctx, tenant, names)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []*domain.Tag); ok {
+		r0 = rf(ctx, tenant, names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, tenant, names)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactRepository_GetTagsByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTagsByName'
+type ContactRepository_GetTagsByName_Call struct {
+	*mock.Call
+}
+
+// GetTagsByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - names []string
+func (_e *ContactRepository_Expecter) GetTagsByName(ctx interface{}, tenant interface{}, names interface{}) *ContactRepository_GetTagsByName_Call {
+	return &ContactRepository_GetTagsByName_Call{Call: _e.mock.On("GetTagsByName", ctx, tenant, names)}
+}
+
+func (_c *ContactRepository_GetTagsByName_Call) Run(run func(ctx context.Context, tenant string, names []string)) *ContactRepository_GetTagsByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_GetTagsByName_Call) Return(_a0 []*domain.Tag, _a1 error) *ContactRepository_GetTagsByName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactRepository_GetTagsByName_Call) RunAndReturn(run func(context.Context, string, []string) ([]*domain.Tag, error)) *ContactRepository_GetTagsByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *ContactRepository) List(ctx context.Context, filter domain.ContactFilter) ([]*domain.Contact, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
@@ -193,19 +510,19 @@ func (_m *ContactRepository) List() ([]*domain.Contact, error) {
 
 	var r0 []*domain.Contact
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*domain.Contact, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter) ([]*domain.Contact, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func() []*domain.Contact); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter) []*domain.Contact); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Contact)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ContactFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -219,13 +536,15 @@ type ContactRepository_List_Call struct {
 }
 
 // List is a helper method to define mock.On call
-func (_e *ContactRepository_Expecter) List() *ContactRepository_List_Call {
-	return &ContactRepository_List_Call{Call: _e.mock.On("List")}
+//   - ctx context.Context
+//   - filter domain.ContactFilter
+func (_e *ContactRepository_Expecter) List(ctx interface{}, filter interface{}) *ContactRepository_List_Call {
+	return &ContactRepository_List_Call{Call: _e.mock.On("List", ctx, filter)}
 }
 
-func (_c *ContactRepository_List_Call) Run(run func()) *ContactRepository_List_Call {
+func (_c *ContactRepository_List_Call) Run(run func(ctx context.Context, filter domain.ContactFilter)) *ContactRepository_List_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(domain.ContactFilter))
 	})
 	return _c
 }
@@ -235,22 +554,316 @@ func (_c *ContactRepository_List_Call) Return(_a0 []*domain.Contact, _a1 error)
 	return _c
 }
 
-func (_c *ContactRepository_List_Call) RunAndReturn(run func() ([]*domain.Contact, error)) *ContactRepository_List_Call {
+func (_c *ContactRepository_List_Call) RunAndReturn(run func(context.Context, domain.ContactFilter) ([]*domain.Contact, error)) *ContactRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListConsents provides a mock function with given fields: ctx, contactID
+func (_m *ContactRepository) ListConsents(ctx context.Context, contactID string) ([]*domain.ConsentRecord, error) {
+	ret := _m.Called(ctx, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListConsents")
+	}
+
+	var r0 []*domain.ConsentRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.ConsentRecord, error)); ok {
+		return rf(ctx, contactID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.ConsentRecord); ok {
+		r0 = rf(ctx, contactID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ConsentRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, contactID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactRepository_ListConsents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListConsents'
+type ContactRepository_ListConsents_Call struct {
+	*mock.Call
+}
+
+// ListConsents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contactID string
+func (_e *ContactRepository_Expecter) ListConsents(ctx interface{}, contactID interface{}) *ContactRepository_ListConsents_Call {
+	return &ContactRepository_ListConsents_Call{Call: _e.mock.On("ListConsents", ctx, contactID)}
+}
+
+func (_c *ContactRepository_ListConsents_Call) Run(run func(ctx context.Context, contactID string)) *ContactRepository_ListConsents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_ListConsents_Call) Return(_a0 []*domain.ConsentRecord, _a1 error) *ContactRepository_ListConsents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactRepository_ListConsents_Call) RunAndReturn(run func(context.Context, string) ([]*domain.ConsentRecord, error)) *ContactRepository_ListConsents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFieldDefinitions provides a mock function with given fields: ctx, tenant
+func (_m *ContactRepository) ListFieldDefinitions(ctx context.Context, tenant string) ([]*domain.FieldDefinition, error) {
+	ret := _m.Called(ctx, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFieldDefinitions")
+	}
+
+	var r0 []*domain.FieldDefinition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.FieldDefinition, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.FieldDefinition); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.FieldDefinition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactRepository_ListFieldDefinitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFieldDefinitions'
+type ContactRepository_ListFieldDefinitions_Call struct {
+	*mock.Call
+}
+
+// ListFieldDefinitions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+func (_e *ContactRepository_Expecter) ListFieldDefinitions(ctx interface{}, tenant interface{}) *ContactRepository_ListFieldDefinitions_Call {
+	return &ContactRepository_ListFieldDefinitions_Call{Call: _e.mock.On("ListFieldDefinitions", ctx, tenant)}
+}
+
+func (_c *ContactRepository_ListFieldDefinitions_Call) Run(run func(ctx context.Context, tenant string)) *ContactRepository_ListFieldDefinitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_ListFieldDefinitions_Call) Return(_a0 []*domain.FieldDefinition, _a1 error) *ContactRepository_ListFieldDefinitions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactRepository_ListFieldDefinitions_Call) RunAndReturn(run func(context.Context, string) ([]*domain.FieldDefinition, error)) *ContactRepository_ListFieldDefinitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRelationships provides a mock function with given fields: ctx, contactID
+func (_m *ContactRepository) ListRelationships(ctx context.Context, contactID string) ([]*domain.Relationship, error) {
+	ret := _m.Called(ctx, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRelationships")
+	}
+
+	var r0 []*domain.Relationship
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.Relationship, error)); ok {
+		return rf(ctx, contactID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Relationship); ok {
+		r0 = rf(ctx, contactID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Relationship)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, contactID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactRepository_ListRelationships_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRelationships'
+type ContactRepository_ListRelationships_Call struct {
+	*mock.Call
+}
+
+// ListRelationships is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contactID string
+func (_e *ContactRepository_Expecter) ListRelationships(ctx interface{}, contactID interface{}) *ContactRepository_ListRelationships_Call {
+	return &ContactRepository_ListRelationships_Call{Call: _e.mock.On("ListRelationships", ctx, contactID)}
+}
+
+func (_c *ContactRepository_ListRelationships_Call) Run(run func(ctx context.Context, contactID string)) *ContactRepository_ListRelationships_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_ListRelationships_Call) Return(_a0 []*domain.Relationship, _a1 error) *ContactRepository_ListRelationships_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactRepository_ListRelationships_Call) RunAndReturn(run func(context.Context, string) ([]*domain.Relationship, error)) *ContactRepository_ListRelationships_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTags provides a mock function with given fields: ctx, tenant
+func (_m *ContactRepository) ListTags(ctx context.Context, tenant string) ([]*domain.Tag, error) {
+	ret := _m.Called(ctx, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 []*domain.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.Tag, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.Tag); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactRepository_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type ContactRepository_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+func (_e *ContactRepository_Expecter) ListTags(ctx interface{}, tenant interface{}) *ContactRepository_ListTags_Call {
+	return &ContactRepository_ListTags_Call{Call: _e.mock.On("ListTags", ctx, tenant)}
+}
+
+func (_c *ContactRepository_ListTags_Call) Run(run func(ctx context.Context, tenant string)) *ContactRepository_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_ListTags_Call) Return(_a0 []*domain.Tag, _a1 error) *ContactRepository_ListTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactRepository_ListTags_Call) RunAndReturn(run func(context.Context, string) ([]*domain.Tag, error)) *ContactRepository_ListTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Save provides a mock function with given fields: contact
-func (_m *ContactRepository) Save(contact *domain.Contact) error {
-	ret := _m.Called(contact)
+// RemoveTags provides a mock function with given fields: ctx, filter, tagIDs
+func (_m *ContactRepository) RemoveTags(ctx context.Context, filter domain.ContactFilter, tagIDs []string) (int, error) {
+	ret := _m.Called(ctx, filter, tagIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTags")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter, []string) (int, error)); ok {
+		return rf(ctx, filter, tagIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter, []string) int); ok {
+		r0 = rf(ctx, filter, tagIDs)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ContactFilter, []string) error); ok {
+		r1 = rf(ctx, filter, tagIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactRepository_RemoveTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveTags'
+type ContactRepository_RemoveTags_Call struct {
+	*mock.Call
+}
+
+// RemoveTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ContactFilter
+//   - tagIDs []string
+func (_e *ContactRepository_Expecter) RemoveTags(ctx interface{}, filter interface{}, tagIDs interface{}) *ContactRepository_RemoveTags_Call {
+	return &ContactRepository_RemoveTags_Call{Call: _e.mock.On("RemoveTags", ctx, filter, tagIDs)}
+}
+
+func (_c *ContactRepository_RemoveTags_Call) Run(run func(ctx context.Context, filter domain.ContactFilter, tagIDs []string)) *ContactRepository_RemoveTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ContactFilter), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_RemoveTags_Call) Return(_a0 int, _a1 error) *ContactRepository_RemoveTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactRepository_RemoveTags_Call) RunAndReturn(run func(context.Context, domain.ContactFilter, []string) (int, error)) *ContactRepository_RemoveTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, contact
+func (_m *ContactRepository) Save(ctx context.Context, contact *domain.Contact) error {
+	ret := _m.Called(ctx, contact)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Save")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*domain.Contact) error); ok {
-		r0 = rf(contact)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Contact) error); ok {
+		r0 = rf(ctx, contact)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -264,14 +877,15 @@ type ContactRepository_Save_Call struct {
 }
 
 // Save is a helper method to define mock.On call
+//   - ctx context.Context
 //   - contact *domain.Contact
-func (_e *ContactRepository_Expecter) Save(contact interface{}) *ContactRepository_Save_Call {
-	return &ContactRepository_Save_Call{Call: _e.mock.On("Save", contact)}
+func (_e *ContactRepository_Expecter) Save(ctx interface{}, contact interface{}) *ContactRepository_Save_Call {
+	return &ContactRepository_Save_Call{Call: _e.mock.On("Save", ctx, contact)}
 }
 
-func (_c *ContactRepository_Save_Call) Run(run func(contact *domain.Contact)) *ContactRepository_Save_Call {
+func (_c *ContactRepository_Save_Call) Run(run func(ctx context.Context, contact *domain.Contact)) *ContactRepository_Save_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*domain.Contact))
+		run(args[0].(context.Context), args[1].(*domain.Contact))
 	})
 	return _c
 }
@@ -281,7 +895,148 @@ func (_c *ContactRepository_Save_Call) Return(_a0 error) *ContactRepository_Save
 	return _c
 }
 
-func (_c *ContactRepository_Save_Call) RunAndReturn(run func(*domain.Contact) error) *ContactRepository_Save_Call {
+func (_c *ContactRepository_Save_Call) RunAndReturn(run func(context.Context, *domain.Contact) error) *ContactRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveFieldDefinition provides a mock function with given fields: ctx, def
+func (_m *ContactRepository) SaveFieldDefinition(ctx context.Context, def *domain.FieldDefinition) error {
+	ret := _m.Called(ctx, def)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveFieldDefinition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.FieldDefinition) error); ok {
+		r0 = rf(ctx, def)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepository_SaveFieldDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveFieldDefinition'
+type ContactRepository_SaveFieldDefinition_Call struct {
+	*mock.Call
+}
+
+// SaveFieldDefinition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - def *domain.FieldDefinition
+func (_e *ContactRepository_Expecter) SaveFieldDefinition(ctx interface{}, def interface{}) *ContactRepository_SaveFieldDefinition_Call {
+	return &ContactRepository_SaveFieldDefinition_Call{Call: _e.mock.On("SaveFieldDefinition", ctx, def)}
+}
+
+func (_c *ContactRepository_SaveFieldDefinition_Call) Run(run func(ctx context.Context, def *domain.FieldDefinition)) *ContactRepository_SaveFieldDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.FieldDefinition))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_SaveFieldDefinition_Call) Return(_a0 error) *ContactRepository_SaveFieldDefinition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepository_SaveFieldDefinition_Call) RunAndReturn(run func(context.Context, *domain.FieldDefinition) error) *ContactRepository_SaveFieldDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveRelationship provides a mock function with given fields: ctx, r
+func (_m *ContactRepository) SaveRelationship(ctx context.Context, r *domain.Relationship) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRelationship")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Relationship) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepository_SaveRelationship_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveRelationship'
+type ContactRepository_SaveRelationship_Call struct {
+	*mock.Call
+}
+
+// SaveRelationship is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *domain.Relationship
+func (_e *ContactRepository_Expecter) SaveRelationship(ctx interface{}, r interface{}) *ContactRepository_SaveRelationship_Call {
+	return &ContactRepository_SaveRelationship_Call{Call: _e.mock.On("SaveRelationship", ctx, r)}
+}
+
+func (_c *ContactRepository_SaveRelationship_Call) Run(run func(ctx context.Context, r *domain.Relationship)) *ContactRepository_SaveRelationship_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Relationship))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_SaveRelationship_Call) Return(_a0 error) *ContactRepository_SaveRelationship_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepository_SaveRelationship_Call) RunAndReturn(run func(context.Context, *domain.Relationship) error) *ContactRepository_SaveRelationship_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveTag provides a mock function with given fields: ctx, tag
+func (_m *ContactRepository) SaveTag(ctx context.Context, tag *domain.Tag) error {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Tag) error); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactRepository_SaveTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTag'
+type ContactRepository_SaveTag_Call struct {
+	*mock.Call
+}
+
+// SaveTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tag *domain.Tag
+func (_e *ContactRepository_Expecter) SaveTag(ctx interface{}, tag interface{}) *ContactRepository_SaveTag_Call {
+	return &ContactRepository_SaveTag_Call{Call: _e.mock.On("SaveTag", ctx, tag)}
+}
+
+func (_c *ContactRepository_SaveTag_Call) Run(run func(ctx context.Context, tag *domain.Tag)) *ContactRepository_SaveTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Tag))
+	})
+	return _c
+}
+
+func (_c *ContactRepository_SaveTag_Call) Return(_a0 error) *ContactRepository_SaveTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactRepository_SaveTag_Call) RunAndReturn(run func(context.Context, *domain.Tag) error) *ContactRepository_SaveTag_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,1219 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ContactService is an autogenerated mock type for the ContactService type
+type ContactService struct {
+	mock.Mock
+}
+
+type ContactService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ContactService) EXPECT() *ContactService_Expecter {
+	return &ContactService_Expecter{mock: &_m.Mock}
+}
+
+// BulkTag provides a mock function with given fields: ctx, filter, tags
+func (_m *ContactService) BulkTag(ctx context.Context, filter domain.ContactFilter, tags []string) (int, error) {
+	ret := _m.Called(ctx, filter, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkTag")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter, []string) (int, error)); ok {
+		return rf(ctx, filter, tags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter, []string) int); ok {
+		r0 = rf(ctx, filter, tags)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ContactFilter, []string) error); ok {
+		r1 = rf(ctx, filter, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_BulkTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkTag'
+type ContactService_BulkTag_Call struct {
+	*mock.Call
+}
+
+// BulkTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ContactFilter
+//   - tags []string
+func (_e *ContactService_Expecter) BulkTag(ctx interface{}, filter interface{}, tags interface{}) *ContactService_BulkTag_Call {
+	return &ContactService_BulkTag_Call{Call: _e.mock.On("BulkTag", ctx, filter, tags)}
+}
+
+func (_c *ContactService_BulkTag_Call) Run(run func(ctx context.Context, filter domain.ContactFilter, tags []string)) *ContactService_BulkTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ContactFilter), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *ContactService_BulkTag_Call) Return(_a0 int, _a1 error) *ContactService_BulkTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_BulkTag_Call) RunAndReturn(run func(context.Context, domain.ContactFilter, []string) (int, error)) *ContactService_BulkTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BulkUntag provides a mock function with given fields: ctx, filter, tags
+func (_m *ContactService) BulkUntag(ctx context.Context, filter domain.ContactFilter, tags []string) (int, error) {
+	ret := _m.Called(ctx, filter, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkUntag")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter, []string) (int, error)); ok {
+		return rf(ctx, filter, tags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter, []string) int); ok {
+		r0 = rf(ctx, filter, tags)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ContactFilter, []string) error); ok {
+		r1 = rf(ctx, filter, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_BulkUntag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkUntag'
+type ContactService_BulkUntag_Call struct {
+	*mock.Call
+}
+
+// BulkUntag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ContactFilter
+//   - tags []string
+func (_e *ContactService_Expecter) BulkUntag(ctx interface{}, filter interface{}, tags interface{}) *ContactService_BulkUntag_Call {
+	return &ContactService_BulkUntag_Call{Call: _e.mock.On("BulkUntag", ctx, filter, tags)}
+}
+
+func (_c *ContactService_BulkUntag_Call) Run(run func(ctx context.Context, filter domain.ContactFilter, tags []string)) *ContactService_BulkUntag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ContactFilter), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *ContactService_BulkUntag_Call) Return(_a0 int, _a1 error) *ContactService_BulkUntag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_BulkUntag_Call) RunAndReturn(run func(context.Context, domain.ContactFilter, []string) (int, error)) *ContactService_BulkUntag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConsentHistory provides a mock function with given fields: ctx, contactID
+func (_m *ContactService) ConsentHistory(ctx context.Context, contactID string) ([]*domain.ConsentRecord, error) {
+	ret := _m.Called(ctx, contactID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsentHistory")
+	}
+
+	var r0 []*domain.ConsentRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.ConsentRecord, error)); ok {
+		return rf(ctx, contactID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.ConsentRecord); ok {
+		r0 = rf(ctx, contactID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ConsentRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, contactID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_ConsentHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConsentHistory'
+type ContactService_ConsentHistory_Call struct {
+	*mock.Call
+}
+
+// ConsentHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contactID string
+func (_e *ContactService_Expecter) ConsentHistory(ctx interface{}, contactID interface{}) *ContactService_ConsentHistory_Call {
+	return &ContactService_ConsentHistory_Call{Call: _e.mock.On("ConsentHistory", ctx, contactID)}
+}
+
+func (_c *ContactService_ConsentHistory_Call) Run(run func(ctx context.Context, contactID string)) *ContactService_ConsentHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactService_ConsentHistory_Call) Return(_a0 []*domain.ConsentRecord, _a1 error) *ContactService_ConsentHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_ConsentHistory_Call) RunAndReturn(run func(context.Context, string) ([]*domain.ConsentRecord, error)) *ContactService_ConsentHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, contact
+func (_m *ContactService) Create(ctx context.Context, contact *domain.Contact) error {
+	ret := _m.Called(ctx, contact)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Contact) error); ok {
+		r0 = rf(ctx, contact)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactService_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type ContactService_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contact *domain.Contact
+func (_e *ContactService_Expecter) Create(ctx interface{}, contact interface{}) *ContactService_Create_Call {
+	return &ContactService_Create_Call{Call: _e.mock.On("Create", ctx, contact)}
+}
+
+func (_c *ContactService_Create_Call) Run(run func(ctx context.Context, contact *domain.Contact)) *ContactService_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Contact))
+	})
+	return _c
+}
+
+func (_c *ContactService_Create_Call) Return(_a0 error) *ContactService_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactService_Create_Call) RunAndReturn(run func(context.Context, *domain.Contact) error) *ContactService_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateFieldDefinition provides a mock function with given fields: ctx, def
+func (_m *ContactService) CreateFieldDefinition(ctx context.Context, def *domain.FieldDefinition) error {
+	ret := _m.Called(ctx, def)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFieldDefinition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.FieldDefinition) error); ok {
+		r0 = rf(ctx, def)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactService_CreateFieldDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFieldDefinition'
+type ContactService_CreateFieldDefinition_Call struct {
+	*mock.Call
+}
+
+// CreateFieldDefinition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - def *domain.FieldDefinition
+func (_e *ContactService_Expecter) CreateFieldDefinition(ctx interface{}, def interface{}) *ContactService_CreateFieldDefinition_Call {
+	return &ContactService_CreateFieldDefinition_Call{Call: _e.mock.On("CreateFieldDefinition", ctx, def)}
+}
+
+func (_c *ContactService_CreateFieldDefinition_Call) Run(run func(ctx context.Context, def *domain.FieldDefinition)) *ContactService_CreateFieldDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.FieldDefinition))
+	})
+	return _c
+}
+
+func (_c *ContactService_CreateFieldDefinition_Call) Return(_a0 error) *ContactService_CreateFieldDefinition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactService_CreateFieldDefinition_Call) RunAndReturn(run func(context.Context, *domain.FieldDefinition) error) *ContactService_CreateFieldDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRelationship provides a mock function with given fields: ctx, r
+func (_m *ContactService) CreateRelationship(ctx context.Context, r *domain.Relationship) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRelationship")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Relationship) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactService_CreateRelationship_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRelationship'
+type ContactService_CreateRelationship_Call struct {
+	*mock.Call
+}
+
+// CreateRelationship is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *domain.Relationship
+func (_e *ContactService_Expecter) CreateRelationship(ctx interface{}, r interface{}) *ContactService_CreateRelationship_Call {
+	return &ContactService_CreateRelationship_Call{Call: _e.mock.On("CreateRelationship", ctx, r)}
+}
+
+func (_c *ContactService_CreateRelationship_Call) Run(run func(ctx context.Context, r *domain.Relationship)) *ContactService_CreateRelationship_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Relationship))
+	})
+	return _c
+}
+
+func (_c *ContactService_CreateRelationship_Call) Return(_a0 error) *ContactService_CreateRelationship_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactService_CreateRelationship_Call) RunAndReturn(run func(context.Context, *domain.Relationship) error) *ContactService_CreateRelationship_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTag provides a mock function with given fields: ctx, tag
+func (_m *ContactService) CreateTag(ctx context.Context, tag *domain.Tag) error {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Tag) error); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactService_CreateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTag'
+type ContactService_CreateTag_Call struct {
+	*mock.Call
+}
+
+// CreateTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tag *domain.Tag
+func (_e *ContactService_Expecter) CreateTag(ctx interface{}, tag interface{}) *ContactService_CreateTag_Call {
+	return &ContactService_CreateTag_Call{Call: _e.mock.On("CreateTag", ctx, tag)}
+}
+
+func (_c *ContactService_CreateTag_Call) Run(run func(ctx context.Context, tag *domain.Tag)) *ContactService_CreateTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Tag))
+	})
+	return _c
+}
+
+func (_c *ContactService_CreateTag_Call) Return(_a0 error) *ContactService_CreateTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactService_CreateTag_Call) RunAndReturn(run func(context.Context, *domain.Tag) error) *ContactService_CreateTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *ContactService) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactService_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ContactService_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ContactService_Expecter) Delete(ctx interface{}, id interface{}) *ContactService_Delete_Call {
+	return &ContactService_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *ContactService_Delete_Call) Run(run func(ctx context.Context, id string)) *ContactService_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactService_Delete_Call) Return(_a0 error) *ContactService_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactService_Delete_Call) RunAndReturn(run func(context.Context, string) error) *ContactService_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFieldDefinition provides a mock function with given fields: ctx, id
+func (_m *ContactService) DeleteFieldDefinition(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFieldDefinition")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactService_DeleteFieldDefinition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFieldDefinition'
+type ContactService_DeleteFieldDefinition_Call struct {
+	*mock.Call
+}
+
+// DeleteFieldDefinition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ContactService_Expecter) DeleteFieldDefinition(ctx interface{}, id interface{}) *ContactService_DeleteFieldDefinition_Call {
+	return &ContactService_DeleteFieldDefinition_Call{Call: _e.mock.On("DeleteFieldDefinition", ctx, id)}
+}
+
+func (_c *ContactService_DeleteFieldDefinition_Call) Run(run func(ctx context.Context, id string)) *ContactService_DeleteFieldDefinition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactService_DeleteFieldDefinition_Call) Return(_a0 error) *ContactService_DeleteFieldDefinition_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactService_DeleteFieldDefinition_Call) RunAndReturn(run func(context.Context, string) error) *ContactService_DeleteFieldDefinition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRelationship provides a mock function with given fields: ctx, contactID, id
+func (_m *ContactService) DeleteRelationship(ctx context.Context, contactID string, id string) error {
+	ret := _m.Called(ctx, contactID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRelationship")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, contactID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactService_DeleteRelationship_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRelationship'
+type ContactService_DeleteRelationship_Call struct {
+	*mock.Call
+}
+
+// DeleteRelationship is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contactID string
+//   - id string
+func (_e *ContactService_Expecter) DeleteRelationship(ctx interface{}, contactID interface{}, id interface{}) *ContactService_DeleteRelationship_Call {
+	return &ContactService_DeleteRelationship_Call{Call: _e.mock.On("DeleteRelationship", ctx, contactID, id)}
+}
+
+func (_c *ContactService_DeleteRelationship_Call) Run(run func(ctx context.Context, contactID string, id string)) *ContactService_DeleteRelationship_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ContactService_DeleteRelationship_Call) Return(_a0 error) *ContactService_DeleteRelationship_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactService_DeleteRelationship_Call) RunAndReturn(run func(context.Context, string, string) error) *ContactService_DeleteRelationship_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTag provides a mock function with given fields: ctx, id
+func (_m *ContactService) DeleteTag(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactService_DeleteTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTag'
+type ContactService_DeleteTag_Call struct {
+	*mock.Call
+}
+
+// DeleteTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ContactService_Expecter) DeleteTag(ctx interface{}, id interface{}) *ContactService_DeleteTag_Call {
+	return &ContactService_DeleteTag_Call{Call: _e.mock.On("DeleteTag", ctx, id)}
+}
+
+func (_c *ContactService_DeleteTag_Call) Run(run func(ctx context.Context, id string)) *ContactService_DeleteTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactService_DeleteTag_Call) Return(_a0 error) *ContactService_DeleteTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactService_DeleteTag_Call) RunAndReturn(run func(context.Context, string) error) *ContactService_DeleteTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *ContactService) Get(ctx context.Context, id string) (*domain.Contact, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.Contact, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Contact); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type ContactService_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ContactService_Expecter) Get(ctx interface{}, id interface{}) *ContactService_Get_Call {
+	return &ContactService_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *ContactService_Get_Call) Run(run func(ctx context.Context, id string)) *ContactService_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ContactService_Get_Call) Return(_a0 *domain.Contact, _a1 error) *ContactService_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_Get_Call) RunAndReturn(run func(context.Context, string) (*domain.Contact, error)) *ContactService_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GrantConsent provides a mock function with given fields: ctx, r
+func (_m *ContactService) GrantConsent(ctx context.Context, r *domain.ConsentRecord) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantConsent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ConsentRecord) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactService_GrantConsent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GrantConsent'
+type ContactService_GrantConsent_Call struct {
+	*mock.Call
+}
+
+// GrantConsent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *domain.ConsentRecord
+func (_e *ContactService_Expecter) GrantConsent(ctx interface{}, r interface{}) *ContactService_GrantConsent_Call {
+	return &ContactService_GrantConsent_Call{Call: _e.mock.On("GrantConsent", ctx, r)}
+}
+
+func (_c *ContactService_GrantConsent_Call) Run(run func(ctx context.Context, r *domain.ConsentRecord)) *ContactService_GrantConsent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ConsentRecord))
+	})
+	return _c
+}
+
+func (_c *ContactService_GrantConsent_Call) Return(_a0 error) *ContactService_GrantConsent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactService_GrantConsent_Call) RunAndReturn(run func(context.Context, *domain.ConsentRecord) error) *ContactService_GrantConsent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *ContactService) List(ctx context.Context, filter domain.ContactFilter) ([]*domain.Contact, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter) ([]*domain.Contact, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ContactFilter) []*domain.Contact); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ContactFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ContactService_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.ContactFilter
+func (_e *ContactService_Expecter) List(ctx interface{}, filter interface{}) *ContactService_List_Call {
+	return &ContactService_List_Call{Call: _e.mock.On("List", ctx, filter)}
+}
+
+func (_c *ContactService_List_Call) Run(run func(ctx context.Context, filter domain.ContactFilter)) *ContactService_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ContactFilter))
+	})
+	return _c
+}
+
+func (_c *ContactService_List_Call) Return(_a0 []*domain.Contact, _a1 error) *ContactService_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_List_Call) RunAndReturn(run func(context.Context, domain.ContactFilter) ([]*domain.Contact, error)) *ContactService_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCompanies provides a mock function with given fields: ctx, contactID, activeOn
+func (_m *ContactService) ListCompanies(ctx context.Context, contactID string, activeOn time.Time) ([]*domain.RelatedContact, error) {
+	ret := _m.Called(ctx, contactID, activeOn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCompanies")
+	}
+
+	var r0 []*domain.RelatedContact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]*domain.RelatedContact, error)); ok {
+		return rf(ctx, contactID, activeOn)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []*domain.RelatedContact); ok {
+		r0 = rf(ctx, contactID, activeOn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RelatedContact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, contactID, activeOn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_ListCompanies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCompanies'
+type ContactService_ListCompanies_Call struct {
+	*mock.Call
+}
+
+// ListCompanies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contactID string
+//   - activeOn time.Time
+func (_e *ContactService_Expecter) ListCompanies(ctx interface{}, contactID interface{}, activeOn interface{}) *ContactService_ListCompanies_Call {
+	return &ContactService_ListCompanies_Call{Call: _e.mock.On("ListCompanies", ctx, contactID, activeOn)}
+}
+
+func (_c *ContactService_ListCompanies_Call) Run(run func(ctx context.Context, contactID string, activeOn time.Time)) *ContactService_ListCompanies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ContactService_ListCompanies_Call) Return(_a0 []*domain.RelatedContact, _a1 error) *ContactService_ListCompanies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_ListCompanies_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]*domain.RelatedContact, error)) *ContactService_ListCompanies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFieldDefinitions provides a mock function with given fields: ctx
+func (_m *ContactService) ListFieldDefinitions(ctx context.Context) ([]*domain.FieldDefinition, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFieldDefinitions")
+	}
+
+	var r0 []*domain.FieldDefinition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.FieldDefinition, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.FieldDefinition); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.FieldDefinition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_ListFieldDefinitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFieldDefinitions'
+type ContactService_ListFieldDefinitions_Call struct {
+	*mock.Call
+}
+
+// ListFieldDefinitions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContactService_Expecter) ListFieldDefinitions(ctx interface{}) *ContactService_ListFieldDefinitions_Call {
+	return &ContactService_ListFieldDefinitions_Call{Call: _e.mock.On("ListFieldDefinitions", ctx)}
+}
+
+func (_c *ContactService_ListFieldDefinitions_Call) Run(run func(ctx context.Context)) *ContactService_ListFieldDefinitions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContactService_ListFieldDefinitions_Call) Return(_a0 []*domain.FieldDefinition, _a1 error) *ContactService_ListFieldDefinitions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_ListFieldDefinitions_Call) RunAndReturn(run func(context.Context) ([]*domain.FieldDefinition, error)) *ContactService_ListFieldDefinitions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPeople provides a mock function with given fields: ctx, companyID, activeOn
+func (_m *ContactService) ListPeople(ctx context.Context, companyID string, activeOn time.Time) ([]*domain.RelatedContact, error) {
+	ret := _m.Called(ctx, companyID, activeOn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPeople")
+	}
+
+	var r0 []*domain.RelatedContact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]*domain.RelatedContact, error)); ok {
+		return rf(ctx, companyID, activeOn)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []*domain.RelatedContact); ok {
+		r0 = rf(ctx, companyID, activeOn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.RelatedContact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, companyID, activeOn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_ListPeople_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPeople'
+type ContactService_ListPeople_Call struct {
+	*mock.Call
+}
+
+// ListPeople is a helper method to define mock.On call
+//   - ctx context.Context
+//   - companyID string
+//   - activeOn time.Time
+func (_e *ContactService_Expecter) ListPeople(ctx interface{}, companyID interface{}, activeOn interface{}) *ContactService_ListPeople_Call {
+	return &ContactService_ListPeople_Call{Call: _e.mock.On("ListPeople", ctx, companyID, activeOn)}
+}
+
+func (_c *ContactService_ListPeople_Call) Run(run func(ctx context.Context, companyID string, activeOn time.Time)) *ContactService_ListPeople_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ContactService_ListPeople_Call) Return(_a0 []*domain.RelatedContact, _a1 error) *ContactService_ListPeople_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_ListPeople_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]*domain.RelatedContact, error)) *ContactService_ListPeople_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTags provides a mock function with given fields: ctx
+func (_m *ContactService) ListTags(ctx context.Context) ([]*domain.Tag, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTags")
+	}
+
+	var r0 []*domain.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Tag, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Tag); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type ContactService_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ContactService_Expecter) ListTags(ctx interface{}) *ContactService_ListTags_Call {
+	return &ContactService_ListTags_Call{Call: _e.mock.On("ListTags", ctx)}
+}
+
+func (_c *ContactService_ListTags_Call) Run(run func(ctx context.Context)) *ContactService_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ContactService_ListTags_Call) Return(_a0 []*domain.Tag, _a1 error) *ContactService_ListTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_ListTags_Call) RunAndReturn(run func(context.Context) ([]*domain.Tag, error)) *ContactService_ListTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeConsent provides a mock function with given fields: ctx, r
+func (_m *ContactService) RevokeConsent(ctx context.Context, r *domain.ConsentRecord) error {
+	ret := _m.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeConsent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.ConsentRecord) error); ok {
+		r0 = rf(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ContactService_RevokeConsent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeConsent'
+type ContactService_RevokeConsent_Call struct {
+	*mock.Call
+}
+
+// RevokeConsent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - r *domain.ConsentRecord
+func (_e *ContactService_Expecter) RevokeConsent(ctx interface{}, r interface{}) *ContactService_RevokeConsent_Call {
+	return &ContactService_RevokeConsent_Call{Call: _e.mock.On("RevokeConsent", ctx, r)}
+}
+
+func (_c *ContactService_RevokeConsent_Call) Run(run func(ctx context.Context, r *domain.ConsentRecord)) *ContactService_RevokeConsent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.ConsentRecord))
+	})
+	return _c
+}
+
+func (_c *ContactService_RevokeConsent_Call) Return(_a0 error) *ContactService_RevokeConsent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ContactService_RevokeConsent_Call) RunAndReturn(run func(context.Context, *domain.ConsentRecord) error) *ContactService_RevokeConsent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagContact provides a mock function with given fields: ctx, id, tags
+func (_m *ContactService) TagContact(ctx context.Context, id string, tags []string) (*domain.Contact, error) {
+	ret := _m.Called(ctx, id, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagContact")
+	}
+
+	var r0 *domain.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*domain.Contact, error)); ok {
+		return rf(ctx, id, tags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *domain.Contact); ok {
+		r0 = rf(ctx, id, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, id, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_TagContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagContact'
+type ContactService_TagContact_Call struct {
+	*mock.Call
+}
+
+// TagContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - tags []string
+func (_e *ContactService_Expecter) TagContact(ctx interface{}, id interface{}, tags interface{}) *ContactService_TagContact_Call {
+	return &ContactService_TagContact_Call{Call: _e.mock.On("TagContact", ctx, id, tags)}
+}
+
+func (_c *ContactService_TagContact_Call) Run(run func(ctx context.Context, id string, tags []string)) *ContactService_TagContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *ContactService_TagContact_Call) Return(_a0 *domain.Contact, _a1 error) *ContactService_TagContact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_TagContact_Call) RunAndReturn(run func(context.Context, string, []string) (*domain.Contact, error)) *ContactService_TagContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UntagContact provides a mock function with given fields: ctx, id, tags
+func (_m *ContactService) UntagContact(ctx context.Context, id string, tags []string) (*domain.Contact, error) {
+	ret := _m.Called(ctx, id, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UntagContact")
+	}
+
+	var r0 *domain.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*domain.Contact, error)); ok {
+		return rf(ctx, id, tags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *domain.Contact); ok {
+		r0 = rf(ctx, id, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, id, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_UntagContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UntagContact'
+type ContactService_UntagContact_Call struct {
+	*mock.Call
+}
+
+// UntagContact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - tags []string
+func (_e *ContactService_Expecter) UntagContact(ctx interface{}, id interface{}, tags interface{}) *ContactService_UntagContact_Call {
+	return &ContactService_UntagContact_Call{Call: _e.mock.On("UntagContact", ctx, id, tags)}
+}
+
+func (_c *ContactService_UntagContact_Call) Run(run func(ctx context.Context, id string, tags []string)) *ContactService_UntagContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
+	})
+	return _c
+}
+
+func (_c *ContactService_UntagContact_Call) Return(_a0 *domain.Contact, _a1 error) *ContactService_UntagContact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_UntagContact_Call) RunAndReturn(run func(context.Context, string, []string) (*domain.Contact, error)) *ContactService_UntagContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, id, patch
+func (_m *ContactService) Update(ctx context.Context, id string, patch *domain.ContactPatch) (*domain.Contact, error) {
+	ret := _m.Called(ctx, id, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *domain.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.ContactPatch) (*domain.Contact, error)); ok {
+		return rf(ctx, id, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.ContactPatch) *domain.Contact); ok {
+		r0 = rf(ctx, id, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.ContactPatch) error); ok {
+		r1 = rf(ctx, id, patch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ContactService_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ContactService_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - patch *domain.ContactPatch
+func (_e *ContactService_Expecter) Update(ctx interface{}, id interface{}, patch interface{}) *ContactService_Update_Call {
+	return &ContactService_Update_Call{Call: _e.mock.On("Update", ctx, id, patch)}
+}
+
+func (_c *ContactService_Update_Call) Run(run func(ctx context.Context, id string, patch *domain.ContactPatch)) *ContactService_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*domain.ContactPatch))
+	})
+	return _c
+}
+
+func (_c *ContactService_Update_Call) Return(_a0 *domain.Contact, _a1 error) *ContactService_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ContactService_Update_Call) RunAndReturn(run func(context.Context, string, *domain.ContactPatch) (*domain.Contact, error)) *ContactService_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewContactService creates a new instance of ContactService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewContactService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ContactService {
+	mock := &ContactService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
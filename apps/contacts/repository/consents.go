@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+)
+
+// consentColumns lists the columns read by queryConsents, in order.
+const consentColumns = `id, contact_id, purpose, channel, action, source, policy_version, COALESCE(ip, ''), recorded_at`
+
+// AddConsent appends a consent record to the history of its contact.
+func (r *postgresContactRepository) AddConsent(ctx context.Context, c *domain.ConsentRecord) error {
+	query := `
+		INSERT INTO contact_consents (id, contact_id, purpose, channel, action, source, policy_version, ip, recorded_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		c.ID, c.ContactID, c.Purpose, c.Channel, c.Action, c.Source, c.PolicyVersion, c.IP, c.RecordedAt,
+	)
+	return err
+}
+
+// ListConsents returns the consent history of a contact, oldest first.
+func (r *postgresContactRepository) ListConsents(ctx context.Context, contactID string) ([]*domain.ConsentRecord, error) {
+	query := `SELECT ` + consentColumns + ` FROM contact_consents WHERE contact_id = $1 ORDER BY recorded_at, id`
+	return r.queryConsents(ctx, query, contactID)
+}
+
+// queryConsents runs a query selecting consentColumns.
+func (r *postgresContactRepository) queryConsents(ctx context.Context, query string, args ...any) ([]*domain.ConsentRecord, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*domain.ConsentRecord
+	for rows.Next() {
+		var c domain.ConsentRecord
+		if err := rows.Scan(&c.ID, &c.ContactID, &c.Purpose, &c.Channel, &c.Action,
+			&c.Source, &c.PolicyVersion, &c.IP, &c.RecordedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, &c)
+	}
+	return records, rows.Err()
+}
+
+// loadConsents fills the current Consents of contacts with a single query
+// reading the latest record of each purpose and channel.
+func (r *postgresContactRepository) loadConsents(ctx context.Context, contacts ...*domain.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.Contact, len(contacts))
+	ids := make([]string, len(contacts))
+	for i, c := range contacts {
+		byID[c.ID] = c
+		ids[i] = c.ID
+	}
+
+	query := `
+		SELECT DISTINCT ON (contact_id, purpose, channel) ` + consentColumns + `
+		FROM contact_consents
+		WHERE contact_id = ANY($1)
+		ORDER BY contact_id, purpose, channel, recorded_at DESC, id DESC
+	`
+
+	records, err := r.queryConsents(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+
+	latest := make(map[string][]*domain.ConsentRecord, len(contacts))
+	for _, rec := range records {
+		latest[rec.ContactID] = append(latest[rec.ContactID], rec)
+	}
+	for id, c := range byID {
+		c.Consents = domain.CurrentConsents(latest[id])
+	}
+	return nil
+}
+
+// marketingClause renders the condition selecting contacts, aliased c, whose
+// latest marketing record for the channel at placeholder n is a grant.
+func marketingClause(n int) string {
+	return fmt.Sprintf(`(
+		SELECT cc.action FROM contact_consents cc
+		WHERE cc.contact_id = c.id AND cc.purpose = '%s' AND cc.channel = $%d
+		ORDER BY cc.recorded_at DESC, cc.id DESC LIMIT 1
+	) = '%s'`, domain.PurposeMarketing, n, domain.ConsentGrant)
+}
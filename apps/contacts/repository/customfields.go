@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+)
+
+// fieldKeyIndex enforces unique custom field keys per tenant.
+const fieldKeyIndex = "idx_custom_field_definitions_key"
+
+// SaveFieldDefinition inserts or updates a custom field definition.
+// Returns domain.ErrDuplicateFieldDefinition when the tenant already has a field with the same key.
+// A definition never moves to another tenant: saving an ID held by another
+// tenant returns domain.ErrFieldDefinitionNotFound.
+func (r *postgresContactRepository) SaveFieldDefinition(ctx context.Context, d *domain.FieldDefinition) error {
+	query := `
+		INSERT INTO custom_field_definitions (id, tenant, key, type, required, rules, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		ON CONFLICT (id) DO UPDATE SET
+			key=$3, type=$4, required=$5, rules=$6, created_at=$7, updated_at=$8
+		WHERE custom_field_definitions.tenant = EXCLUDED.tenant
+	`
+
+	tag, err := r.db.Exec(ctx, query, d.ID, d.Tenant, d.Key, d.Type, d.Required, d.Rules, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		return translateError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrFieldDefinitionNotFound
+	}
+	return nil
+}
+
+// ListFieldDefinitions returns the custom field definitions of a tenant ordered by key.
+func (r *postgresContactRepository) ListFieldDefinitions(ctx context.Context, tenant string) ([]*domain.FieldDefinition, error) {
+	query := `
+		SELECT id, tenant, key, type, required, rules, created_at, updated_at
+		FROM custom_field_definitions
+		WHERE tenant = $1
+		ORDER BY key
+	`
+
+	rows, err := r.db.Query(ctx, query, tenant)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var defs []*domain.FieldDefinition
+	for rows.Next() {
+		var d domain.FieldDefinition
+		if err := rows.Scan(&d.ID, &d.Tenant, &d.Key, &d.Type, &d.Required, &d.Rules, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, err
+		}
+		defs = append(defs, &d)
+	}
+	return defs, rows.Err()
+}
+
+// DeleteFieldDefinition removes a custom field definition of a tenant and, in
+// the same statement, strips its key from the custom fields of the tenant's contacts.
+// Returns domain.ErrFieldDefinitionNotFound when the tenant has no such definition.
+func (r *postgresContactRepository) DeleteFieldDefinition(ctx context.Context, tenant, id string) error {
+	query := `
+		WITH deleted AS (
+			DELETE FROM custom_field_definitions WHERE tenant = $1 AND id = $2 RETURNING key
+		), stripped AS (
+			UPDATE contacts c SET custom_fields = c.custom_fields - d.key
+			FROM deleted d
+			WHERE c.tenant = $1 AND c.custom_fields ? d.key
+		)
+		SELECT count(*) FROM deleted
+	`
+
+	var deleted int
+	if err := r.db.QueryRow(ctx, query, tenant, id).Scan(&deleted); err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrFieldDefinitionNotFound
+	}
+	return nil
+}
+
+// customFields returns values ready to store, never nil so the column stays an object.
+func customFields(values map[string]any) map[string]any {
+	if values == nil {
+		return map[string]any{}
+	}
+	return values
+}
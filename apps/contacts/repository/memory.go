@@ -0,0 +1,482 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+)
+
+// memoryContactRepository implements domain.ContactRepository in process memory.
+// It is safe for concurrent use and is meant for tests and demos; data is lost on restart.
+type memoryContactRepository struct {
+	mu       sync.RWMutex
+	contacts map[string]*domain.Contact
+	tags     map[string]*domain.Tag
+	fields   map[string]*domain.FieldDefinition
+	rels     map[string]*domain.Relationship
+
+	// consents holds the consent history of each contact ID, in insertion order.
+	consents map[string][]*domain.ConsentRecord
+
+	// links holds the tag IDs of each contact ID.
+	links map[string]map[string]bool
+}
+
+// NewMemoryContactRepository creates an empty in-memory ContactRepository.
+func NewMemoryContactRepository() domain.ContactRepository {
+	return &memoryContactRepository{
+		contacts: make(map[string]*domain.Contact),
+		tags:     make(map[string]*domain.Tag),
+		fields:   make(map[string]*domain.FieldDefinition),
+		rels:     make(map[string]*domain.Relationship),
+		consents: make(map[string][]*domain.ConsentRecord),
+		links:    make(map[string]map[string]bool),
+	}
+}
+
+// Save inserts or updates a Contact.
+// Returns domain.ErrDuplicateDocument when another active contact of the tenant holds the same document.
+// A contact never moves to another tenant: saving an ID held by another tenant
+// returns domain.ErrContactNotFound.
+func (r *memoryContactRepository) Save(_ context.Context, c *domain.Contact) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.contacts[c.ID]; ok && existing.Tenant != c.Tenant {
+		return domain.ErrContactNotFound
+	}
+	for id, other := range r.contacts {
+		if id != c.ID && other.DeletedAt == nil && other.Tenant == c.Tenant &&
+			other.DocumentType == c.DocumentType && other.DocumentNumber == c.DocumentNumber {
+			return domain.ErrDuplicateDocument
+		}
+	}
+
+	stored := clone(c)
+	stored.Tags = nil
+	stored.Consents = nil
+	if existing, ok := r.contacts[c.ID]; ok {
+		stored.DeletedAt = existing.DeletedAt
+	}
+	r.contacts[c.ID] = stored
+	return nil
+}
+
+// GetByID retrieves an active Contact of a tenant by its ID.
+func (r *memoryContactRepository) GetByID(_ context.Context, tenant, id string) (*domain.Contact, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.contacts[id]
+	if !ok || c.DeletedAt != nil || c.Tenant != tenant {
+		return nil, domain.ErrContactNotFound
+	}
+	return r.loaded(c), nil
+}
+
+// GetByDocument retrieves an active Contact of a tenant by its document type and number.
+func (r *memoryContactRepository) GetByDocument(_ context.Context, tenant string, docType domain.DocumentType, docNumber string) (*domain.Contact, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.contacts {
+		if c.DeletedAt == nil && c.Tenant == tenant && c.DocumentType == docType && c.DocumentNumber == docNumber {
+			return r.loaded(c), nil
+		}
+	}
+	return nil, domain.ErrContactNotFound
+}
+
+// Delete soft-deletes a Contact of a tenant by ID and removes its relationships.
+// Unknown, already deleted or other tenants' IDs are ignored.
+func (r *memoryContactRepository) Delete(_ context.Context, tenant, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.contacts[id]
+	if !ok || c.DeletedAt != nil || c.Tenant != tenant {
+		return nil
+	}
+
+	for relID, rel := range r.rels {
+		if rel.FromID == id || rel.ToID == id {
+			delete(r.rels, relID)
+		}
+	}
+
+	now := time.Now()
+	c.DeletedAt = &now
+	c.UpdatedAt = now
+	return nil
+}
+
+// List returns the active Contacts matching filter, oldest first.
+func (r *memoryContactRepository) List(_ context.Context, filter domain.ContactFilter) ([]*domain.Contact, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var contacts []*domain.Contact
+	for _, c := range r.matching(filter) {
+		contacts = append(contacts, r.loaded(c))
+	}
+
+	slices.SortFunc(contacts, func(a, b *domain.Contact) int {
+		if n := a.CreatedAt.Compare(b.CreatedAt); n != 0 {
+			return n
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return contacts, nil
+}
+
+// SaveTag inserts or updates a catalog tag.
+// Returns domain.ErrDuplicateTag when another tag of the tenant has the same name.
+// A tag never moves to another tenant: saving an ID held by another tenant
+// returns domain.ErrTagNotFound.
+func (r *memoryContactRepository) SaveTag(_ context.Context, t *domain.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.tags[t.ID]; ok && existing.Tenant != t.Tenant {
+		return domain.ErrTagNotFound
+	}
+	for id, other := range r.tags {
+		if id != t.ID && other.Tenant == t.Tenant && domain.NormalizeTagName(other.Name) == domain.NormalizeTagName(t.Name) {
+			return domain.ErrDuplicateTag
+		}
+	}
+
+	stored := *t
+	r.tags[t.ID] = &stored
+	return nil
+}
+
+// ListTags returns the tag catalog of a tenant ordered by name.
+func (r *memoryContactRepository) ListTags(_ context.Context, tenant string) ([]*domain.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tags []*domain.Tag
+	for _, t := range r.tags {
+		if t.Tenant != tenant {
+			continue
+		}
+		cp := *t
+		tags = append(tags, &cp)
+	}
+	sortTags(tags)
+	return tags, nil
+}
+
+// GetTagsByName returns the catalog tags of a tenant with the given names, ignoring case.
+func (r *memoryContactRepository) GetTagsByName(_ context.Context, tenant string, names []string) ([]*domain.Tag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := domain.NormalizeTagNames(names)
+	var tags []*domain.Tag
+	for _, t := range r.tags {
+		if t.Tenant == tenant && slices.Contains(wanted, domain.NormalizeTagName(t.Name)) {
+			cp := *t
+			tags = append(tags, &cp)
+		}
+	}
+	sortTags(tags)
+	return tags, nil
+}
+
+// DeleteTag removes a tag of a tenant from the catalog and from every contact.
+// Returns domain.ErrTagNotFound when the tenant has no such tag.
+func (r *memoryContactRepository) DeleteTag(_ context.Context, tenant, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t, ok := r.tags[id]; !ok || t.Tenant != tenant {
+		return domain.ErrTagNotFound
+	}
+	delete(r.tags, id)
+	for _, tags := range r.links {
+		delete(tags, id)
+	}
+	return nil
+}
+
+// AddTags links the tags to every active contact matching filter, skipping
+// tags of other tenants.
+func (r *memoryContactRepository) AddTags(_ context.Context, filter domain.ContactFilter, tagIDs []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	added := 0
+	for _, c := range r.matching(filter) {
+		for _, tagID := range tagIDs {
+			if t, ok := r.tags[tagID]; !ok || t.Tenant != c.Tenant || r.links[c.ID][tagID] {
+				continue
+			}
+			if r.links[c.ID] == nil {
+				r.links[c.ID] = make(map[string]bool)
+			}
+			r.links[c.ID][tagID] = true
+			added++
+		}
+	}
+	return added, nil
+}
+
+// RemoveTags unlinks the tags from every active contact matching filter.
+func (r *memoryContactRepository) RemoveTags(_ context.Context, filter domain.ContactFilter, tagIDs []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removed := 0
+	for _, c := range r.matching(filter) {
+		for _, tagID := range tagIDs {
+			if r.links[c.ID][tagID] {
+				delete(r.links[c.ID], tagID)
+				removed++
+			}
+		}
+	}
+	return removed, nil
+}
+
+// SaveFieldDefinition inserts or updates a custom field definition.
+// Returns domain.ErrDuplicateFieldDefinition when the tenant already has a field with the same key.
+// A definition never moves to another tenant: saving an ID held by another
+// tenant returns domain.ErrFieldDefinitionNotFound.
+func (r *memoryContactRepository) SaveFieldDefinition(_ context.Context, d *domain.FieldDefinition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.fields[d.ID]; ok && existing.Tenant != d.Tenant {
+		return domain.ErrFieldDefinitionNotFound
+	}
+	for id, other := range r.fields {
+		if id != d.ID && other.Tenant == d.Tenant && other.Key == d.Key {
+			return domain.ErrDuplicateFieldDefinition
+		}
+	}
+	stored := *d
+	stored.Rules.Options = slices.Clone(d.Rules.Options)
+	r.fields[d.ID] = &stored
+	return nil
+}
+
+// ListFieldDefinitions returns the custom field definitions of a tenant ordered by key.
+func (r *memoryContactRepository) ListFieldDefinitions(_ context.Context, tenant string) ([]*domain.FieldDefinition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var defs []*domain.FieldDefinition
+	for _, d := range r.fields {
+		if d.Tenant == tenant {
+			cp := *d
+			defs = append(defs, &cp)
+		}
+	}
+	slices.SortFunc(defs, func(a, b *domain.FieldDefinition) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return defs, nil
+}
+
+// DeleteFieldDefinition removes a custom field definition of a tenant and
+// strips its key from the custom fields of the tenant's contacts.
+// Returns domain.ErrFieldDefinitionNotFound when the tenant has no such definition.
+func (r *memoryContactRepository) DeleteFieldDefinition(_ context.Context, tenant, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d, ok := r.fields[id]
+	if !ok || d.Tenant != tenant {
+		return domain.ErrFieldDefinitionNotFound
+	}
+	delete(r.fields, id)
+	for _, c := range r.contacts {
+		if c.Tenant == tenant {
+			delete(c.CustomFields, d.Key)
+		}
+	}
+	return nil
+}
+
+// SaveRelationship inserts or updates a relationship between two contacts.
+// Returns domain.ErrDuplicateRelationship when both contacts are already linked with the same type.
+func (r *memoryContactRepository) SaveRelationship(_ context.Context, rel *domain.Relationship) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, other := range r.rels {
+		if id != rel.ID && other.FromID == rel.FromID && other.ToID == rel.ToID && other.Type == rel.Type {
+			return domain.ErrDuplicateRelationship
+		}
+	}
+	stored := *rel
+	r.rels[rel.ID] = &stored
+	return nil
+}
+
+// ListRelationships returns the relationships on either side of a contact, oldest first.
+func (r *memoryContactRepository) ListRelationships(_ context.Context, contactID string) ([]*domain.Relationship, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rels []*domain.Relationship
+	for _, rel := range r.rels {
+		if rel.FromID == contactID || rel.ToID == contactID {
+			cp := *rel
+			rels = append(rels, &cp)
+		}
+	}
+	slices.SortFunc(rels, func(a, b *domain.Relationship) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return rels, nil
+}
+
+// DeleteRelationship removes a relationship on either side of a contact.
+// Returns domain.ErrRelationshipNotFound when the contact has no such relationship.
+func (r *memoryContactRepository) DeleteRelationship(_ context.Context, contactID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rel, ok := r.rels[id]; !ok || (rel.FromID != contactID && rel.ToID != contactID) {
+		return domain.ErrRelationshipNotFound
+	}
+	delete(r.rels, id)
+	return nil
+}
+
+// AddConsent appends a consent record to the history of its contact.
+func (r *memoryContactRepository) AddConsent(_ context.Context, c *domain.ConsentRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *c
+	r.consents[c.ContactID] = append(r.consents[c.ContactID], &stored)
+	return nil
+}
+
+// ListConsents returns the consent history of a contact, oldest first.
+func (r *memoryContactRepository) ListConsents(_ context.Context, contactID string) ([]*domain.ConsentRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []*domain.ConsentRecord
+	for _, c := range r.consents[contactID] {
+		cp := *c
+		records = append(records, &cp)
+	}
+	slices.SortStableFunc(records, func(a, b *domain.ConsentRecord) int {
+		return a.RecordedAt.Compare(b.RecordedAt)
+	})
+	return records, nil
+}
+
+// matching returns the active contacts selected by filter. Callers hold the lock.
+func (r *memoryContactRepository) matching(filter domain.ContactFilter) []*domain.Contact {
+	names := domain.NormalizeTagNames(filter.Tags)
+
+	var contacts []*domain.Contact
+	for _, c := range r.contacts {
+		if c.DeletedAt != nil || c.Tenant != filter.Tenant || (len(filter.IDs) > 0 && !slices.Contains(filter.IDs, c.ID)) {
+			continue
+		}
+
+		if len(names) > 0 {
+			matched := 0
+			for tagID := range r.links[c.ID] {
+				if slices.Contains(names, domain.NormalizeTagName(r.tags[tagID].Name)) {
+					matched++
+				}
+			}
+			if matched == 0 || (filter.Match == domain.MatchAll && matched < len(names)) {
+				continue
+			}
+		}
+
+		if !containsFields(c.CustomFields, filter.CustomFields) {
+			continue
+		}
+
+		if filter.MarketingChannel != "" && !r.marketable(c.ID, filter.MarketingChannel) {
+			continue
+		}
+
+		if email := strings.TrimSpace(filter.Email); email != "" && !strings.EqualFold(strings.TrimSpace(c.Email), email) {
+			continue
+		}
+		if phone := strings.TrimSpace(filter.Phone); phone != "" && strings.TrimSpace(c.Phone) != phone {
+			continue
+		}
+
+		contacts = append(contacts, c)
+	}
+	return contacts
+}
+
+// marketable reports whether the latest marketing consent of the contact for
+// channel is a grant. Callers hold the lock.
+func (r *memoryContactRepository) marketable(contactID string, channel domain.ConsentChannel) bool {
+	for _, state := range domain.CurrentConsents(r.consents[contactID]) {
+		if state.Purpose == domain.PurposeMarketing && state.Channel == channel {
+			return state.Granted
+		}
+	}
+	return false
+}
+
+// containsFields reports whether values holds every key of want with an equal value.
+func containsFields(values, want map[string]any) bool {
+	for k, v := range want {
+		got, ok := values[k]
+		if !ok || !reflect.DeepEqual(got, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// loaded clones c with its tags and current consents attached. Callers hold the lock.
+func (r *memoryContactRepository) loaded(c *domain.Contact) *domain.Contact {
+	cp := clone(c)
+	var tags []*domain.Tag
+	for tagID := range r.links[c.ID] {
+		tags = append(tags, r.tags[tagID])
+	}
+	sortTags(tags)
+	for _, t := range tags {
+		cp.Tags = append(cp.Tags, *t)
+	}
+	cp.Consents = domain.CurrentConsents(r.consents[c.ID])
+	return cp
+}
+
+// sortTags orders tags by name, ignoring case.
+func sortTags(tags []*domain.Tag) {
+	slices.SortFunc(tags, func(a, b *domain.Tag) int {
+		return strings.Compare(domain.NormalizeTagName(a.Name), domain.NormalizeTagName(b.Name))
+	})
+}
+
+// clone copies c so callers never share state with the store.
+func clone(c *domain.Contact) *domain.Contact {
+	cp := *c
+	if c.DeletedAt != nil {
+		deletedAt := *c.DeletedAt
+		cp.DeletedAt = &deletedAt
+	}
+	cp.Tags = slices.Clone(c.Tags)
+	cp.CustomFields = maps.Clone(c.CustomFields)
+	cp.Consents = slices.Clone(c.Consents)
+	return &cp
+}
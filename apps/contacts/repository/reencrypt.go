@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/helper"
+	"github.com/flockstore/mannaiah-backend/common/database"
+	"github.com/flockstore/mannaiah-backend/common/encryption"
+)
+
+// defaultReencryptBatch is the batch size used when Reencrypt gets a non-positive one.
+const defaultReencryptBatch = 500
+
+// Reencrypt seals every contact, deleted ones included, that is not yet
+// encrypted with the current key, and returns how many rows it rewrote. Run it
+// after rotating keys and after the encryption migration; old keys must stay
+// in the provider until it finishes.
+//
+// Rows are read from the primary in batches of batchSize, ordered by ID. Each
+// row is rewritten only while its updated_at is unchanged, so contacts saved
+// concurrently keep their newer values.
+func Reencrypt(ctx context.Context, db database.DB, cipher *encryption.Cipher, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = defaultReencryptBatch
+	}
+
+	current, err := cipher.CurrentVersion()
+	if err != nil {
+		return 0, err
+	}
+	ctx = database.WithPrimary(ctx)
+
+	var (
+		total  int
+		lastID string
+	)
+	for {
+		batch, err := staleContacts(ctx, db, cipher, current, lastID, batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		for _, c := range batch {
+			updated, err := reseal(ctx, db, cipher, c, current)
+			if err != nil {
+				return total, err
+			}
+			if updated {
+				total++
+			}
+			lastID = c.ID
+		}
+
+		if len(batch) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+// staleContacts reads the next batch of contacts after lastID not sealed with
+// the current key version.
+func staleContacts(ctx context.Context, db database.DB, cipher *encryption.Cipher, current uint32, lastID string, limit int) ([]*domain.Contact, error) {
+	query := `
+		SELECT ` + contactColumns + `
+		FROM contacts
+		WHERE key_version IS DISTINCT FROM $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := db.Query(ctx, query, current, lastID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batch []*domain.Contact
+	for rows.Next() {
+		c, err := helper.ScanContact(rows, cipher)
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, c)
+	}
+	return batch, rows.Err()
+}
+
+// reseal writes c sealed with the current key, unless it changed since it was read.
+func reseal(ctx context.Context, db database.DB, cipher *encryption.Cipher, c *domain.Contact, current uint32) (bool, error) {
+	sealed, err := helper.SealContact(c, cipher)
+	if err != nil {
+		return false, err
+	}
+
+	query := `
+		UPDATE contacts SET
+			doc_number=$3, email=$4, phone=$5,
+			doc_number_bidx=$6, email_bidx=$7, phone_bidx=$8, key_version=$9
+		WHERE id = $1 AND updated_at = $2 AND key_version IS DISTINCT FROM $9
+	`
+
+	tag, err := db.Exec(ctx, query, c.ID, c.UpdatedAt,
+		sealed.DocumentNumber, sealed.Email, sealed.Phone,
+		sealed.DocumentIndex, sealed.EmailIndex, sealed.PhoneIndex, current,
+	)
+	if err != nil {
+		return false, translateError(err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+)
+
+// relationshipIndex enforces a single relationship of each type between two contacts.
+const relationshipIndex = "idx_contact_relationships_link"
+
+// SaveRelationship inserts or updates a relationship between two contacts.
+// Returns domain.ErrDuplicateRelationship when both contacts are already linked with the same type.
+func (r *postgresContactRepository) SaveRelationship(ctx context.Context, rel *domain.Relationship) error {
+	query := `
+		INSERT INTO contact_relationships (id, type, from_id, to_id, role, valid_from, valid_until, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+		ON CONFLICT (id) DO UPDATE SET
+			type=$2, from_id=$3, to_id=$4, role=$5, valid_from=$6, valid_until=$7, created_at=$8, updated_at=$9
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		rel.ID, rel.Type, rel.FromID, rel.ToID, rel.Role,
+		rel.ValidFrom, rel.ValidUntil, rel.CreatedAt, rel.UpdatedAt,
+	)
+	return translateError(err)
+}
+
+// ListRelationships returns the relationships on either side of a contact, oldest first.
+func (r *postgresContactRepository) ListRelationships(ctx context.Context, contactID string) ([]*domain.Relationship, error) {
+	query := `
+		SELECT id, type, from_id, to_id, COALESCE(role, ''), valid_from, valid_until, created_at, updated_at
+		FROM contact_relationships
+		WHERE from_id = $1 OR to_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, contactID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rels []*domain.Relationship
+	for rows.Next() {
+		var rel domain.Relationship
+		if err := rows.Scan(&rel.ID, &rel.Type, &rel.FromID, &rel.ToID, &rel.Role,
+			&rel.ValidFrom, &rel.ValidUntil, &rel.CreatedAt, &rel.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rels = append(rels, &rel)
+	}
+	return rels, rows.Err()
+}
+
+// DeleteRelationship removes a relationship on either side of a contact.
+// Returns domain.ErrRelationshipNotFound when the contact has no such relationship.
+func (r *postgresContactRepository) DeleteRelationship(ctx context.Context, contactID, id string) error {
+	query := `DELETE FROM contact_relationships WHERE id = $1 AND (from_id = $2 OR to_id = $2)`
+	tag, err := r.db.Exec(ctx, query, id, contactID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrRelationshipNotFound
+	}
+	return nil
+}
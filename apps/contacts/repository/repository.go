@@ -2,93 +2,182 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
 	"github.com/flockstore/mannaiah-backend/apps/contacts/helper"
 	"github.com/flockstore/mannaiah-backend/common/database"
+	"github.com/flockstore/mannaiah-backend/common/encryption"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// sqlStateUniqueViolation is the Postgres SQLSTATE for unique constraint violations.
+	sqlStateUniqueViolation = "23505"
+
+	// documentIndex enforces one active contact per document type and number.
+	documentIndex = "idx_contacts_document_active"
+
+	// contactColumns lists the columns read by helper.ScanContact, in order.
+	contactColumns = `id, doc_type, doc_number, legal_name, first_name, last_name,
+		       address, address_extra, city_code, phone, email,
+		       created_at, updated_at, deleted_at, custom_fields, key_version, tenant`
 )
 
 // postgresContactRepository implements domain.ContactRepository using PostgreSQL and pgx.
+// Document numbers, emails and phones are stored encrypted, and looked up
+// through their blind indexes.
 type postgresContactRepository struct {
-	db database.DB
+	db     database.DB
+	cipher *encryption.Cipher
 }
 
 // NewPostgresContactRepository creates a new instance of ContactRepository using PostgreSQL.
-func NewPostgresContactRepository(db database.DB) domain.ContactRepository {
-	return &postgresContactRepository{db: db}
+// cipher encrypts and decrypts the personal fields.
+func NewPostgresContactRepository(db database.DB, cipher *encryption.Cipher) domain.ContactRepository {
+	return &postgresContactRepository{db: db, cipher: cipher}
 }
 
 // Save inserts or updates a Contact in the database.
 // Assumes the Contact entity has already been fully constructed (ID, timestamps, etc.) by the domain/service layer.
-func (r *postgresContactRepository) Save(c *domain.Contact) error {
+// Returns domain.ErrDuplicateDocument when another active contact of the tenant holds the same document.
+// A contact never moves to another tenant: saving an ID held by another tenant
+// returns domain.ErrContactNotFound.
+func (r *postgresContactRepository) Save(ctx context.Context, c *domain.Contact) error {
+	sealed, err := helper.SealContact(c, r.cipher)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO contacts (
 			id, doc_type, doc_number, legal_name,
 			first_name, last_name, address, address_extra,
 			city_code, phone, email,
-			created_at, updated_at
+			created_at, updated_at,
+			doc_number_bidx, email_bidx, phone_bidx, key_version,
+			custom_fields, tenant
 		)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19)
 		ON CONFLICT (id) DO UPDATE SET
 			doc_type=$2, doc_number=$3, legal_name=$4,
 			first_name=$5, last_name=$6, address=$7, address_extra=$8,
-			city_code=$9, phone=$10, email=$11, created_at=$12, updated_at=$13
+			city_code=$9, phone=$10, email=$11, created_at=$12, updated_at=$13,
+			doc_number_bidx=$14, email_bidx=$15, phone_bidx=$16, key_version=$17,
+			custom_fields=$18
+		WHERE contacts.tenant = EXCLUDED.tenant
 	`
 
-	_, err := r.db.Exec(context.Background(), query,
-		c.ID, c.DocumentType, c.DocumentNumber, c.LegalName,
+	tag, err := r.db.Exec(ctx, query,
+		c.ID, c.DocumentType, sealed.DocumentNumber, c.LegalName,
 		c.FirstName, c.LastName, c.Address, c.AddressExtra,
-		c.CityCode, c.Phone, c.Email,
+		c.CityCode, sealed.Phone, sealed.Email,
 		c.CreatedAt, c.UpdatedAt,
+		sealed.DocumentIndex, sealed.EmailIndex, sealed.PhoneIndex, sealed.KeyVersion,
+		customFields(c.CustomFields), c.Tenant,
 	)
+	if err != nil {
+		return translateError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrContactNotFound
+	}
+	return nil
+}
+
+// translateError maps constraint violations to domain errors.
+func translateError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == sqlStateUniqueViolation {
+		switch pgErr.ConstraintName {
+		case documentIndex:
+			return domain.ErrDuplicateDocument
+		case tagNameIndex:
+			return domain.ErrDuplicateTag
+		case fieldKeyIndex:
+			return domain.ErrDuplicateFieldDefinition
+		case relationshipIndex:
+			return domain.ErrDuplicateRelationship
+		}
+	}
 	return err
 }
 
-// GetByID retrieves a Contact by its ID.
-func (r *postgresContactRepository) GetByID(id string) (*domain.Contact, error) {
+// GetByID retrieves an active Contact of a tenant by its ID.
+func (r *postgresContactRepository) GetByID(ctx context.Context, tenant, id string) (*domain.Contact, error) {
 	query := `
-		SELECT id, doc_type, doc_number, legal_name, first_name, last_name,
-		       address, address_extra, city_code, phone, email,
-		       created_at, updated_at
+		SELECT ` + contactColumns + `
 		FROM contacts
-		WHERE id = $1
+		WHERE tenant = $1 AND id = $2 AND deleted_at IS NULL
 	`
 
-	row := r.db.QueryRow(context.Background(), query, id)
-	return helper.ScanContact(row)
+	row := r.db.QueryRow(ctx, query, tenant, id)
+	return r.scanAndLoad(ctx, row)
 }
 
-// GetByDocument retrieves a Contact by its document type and number.
-func (r *postgresContactRepository) GetByDocument(docType domain.DocumentType, docNumber string) (*domain.Contact, error) {
+// GetByDocument retrieves a Contact of a tenant by its document type and number.
+// The number is matched through its blind index, or in clear for rows not yet encrypted.
+func (r *postgresContactRepository) GetByDocument(ctx context.Context, tenant string, docType domain.DocumentType, docNumber string) (*domain.Contact, error) {
+	index, err := helper.BlindIndex(r.cipher, helper.FieldDocumentNumber, docNumber)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		SELECT id, doc_type, doc_number, legal_name, first_name, last_name,
-		       address, address_extra, city_code, phone, email,
-		       created_at, updated_at
+		SELECT ` + contactColumns + `
 		FROM contacts
-		WHERE doc_type = $1 AND doc_number = $2 AND deleted_at is NULL
+		WHERE tenant = $1 AND doc_type = $2 AND deleted_at is NULL
+		  AND (doc_number_bidx = $3 OR (key_version IS NULL AND doc_number = $4))
 	`
 
-	row := r.db.QueryRow(context.Background(), query, docType, docNumber)
-	return helper.ScanContact(row)
+	row := r.db.QueryRow(ctx, query, tenant, docType, index, docNumber)
+	return r.scanAndLoad(ctx, row)
+}
+
+// scanAndLoad scans a single contact and loads its tags and consents.
+func (r *postgresContactRepository) scanAndLoad(ctx context.Context, row pgx.Row) (*domain.Contact, error) {
+	c, err := helper.ScanContact(row, r.cipher)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadTags(ctx, c); err != nil {
+		return nil, err
+	}
+	if err := r.loadConsents(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
 }
 
-// Delete removes a Contact by ID from the database.
-func (r *postgresContactRepository) Delete(id string) error {
-	query := `UPDATE contacts SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
-	_, err := r.db.Exec(context.Background(), query, id)
+// Delete soft-deletes a Contact of a tenant by ID and removes its relationships in the same statement,
+// since soft deletes do not trigger the foreign key cascade.
+func (r *postgresContactRepository) Delete(ctx context.Context, tenant, id string) error {
+	query := `
+		WITH deleted AS (
+			UPDATE contacts SET deleted_at = NOW(), updated_at = NOW()
+			WHERE tenant = $1 AND id = $2 AND deleted_at IS NULL
+			RETURNING id
+		)
+		DELETE FROM contact_relationships r USING deleted d WHERE r.from_id = d.id OR r.to_id = d.id
+	`
+	_, err := r.db.Exec(ctx, query, tenant, id)
 	return err
 }
 
-// List returns all Contacts from the database.
-func (r *postgresContactRepository) List() ([]*domain.Contact, error) {
+// List returns the active Contacts matching filter, oldest first.
+func (r *postgresContactRepository) List(ctx context.Context, filter domain.ContactFilter) ([]*domain.Contact, error) {
+	where, args, err := r.filterClause(filter, nil)
+	if err != nil {
+		return nil, err
+	}
 	query := `
-		SELECT id, doc_type, doc_number, legal_name, first_name, last_name,
-		       address, address_extra, city_code, phone, email,
-		       created_at, updated_at
-		FROM contacts WHERE deleted_at is NULL
+		SELECT ` + contactColumns + `
+		FROM contacts c WHERE ` + where + `
+		ORDER BY created_at, id
 	`
 
-	rows, err := r.db.Query(context.Background(), query)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -96,11 +185,22 @@ func (r *postgresContactRepository) List() ([]*domain.Contact, error) {
 
 	var contacts []*domain.Contact
 	for rows.Next() {
-		c, err := helper.ScanContact(rows)
+		c, err := helper.ScanContact(rows, r.cipher)
 		if err != nil {
 			return nil, err
 		}
 		contacts = append(contacts, c)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := r.loadTags(ctx, contacts...); err != nil {
+		return nil, err
+	}
+	if err := r.loadConsents(ctx, contacts...); err != nil {
+		return nil, err
+	}
 	return contacts, nil
 }
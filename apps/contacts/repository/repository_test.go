@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/repository/repotest"
+	"github.com/flockstore/mannaiah-backend/common/config"
+	"github.com/flockstore/mannaiah-backend/common/database"
+	"github.com/flockstore/mannaiah-backend/common/encryption"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDB connects to TEST_DATABASE_URL inside a throwaway schema with all
+// migrations applied. The test is skipped when the variable is unset.
+func newTestDB(t *testing.T) database.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	ctx := context.Background()
+
+	admin, err := database.Connect(ctx, config.DatabaseConfig{DatabaseURL: dsn, MaxPool: 1, ConnectTimeout: 5}, nil)
+	require.NoError(t, err)
+	t.Cleanup(admin.Close)
+
+	schema := fmt.Sprintf("contacts_test_%d", rand.Uint32())
+	_, err = admin.Exec(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE") })
+
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+
+	db, err := database.Connect(ctx, config.DatabaseConfig{DatabaseURL: u.String(), MaxPool: 20, ConnectTimeout: 5}, nil)
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+
+	migrations, err := filepath.Glob("../migrations/*.up.sql")
+	require.NoError(t, err)
+	sort.Strings(migrations)
+	for _, m := range migrations {
+		sql, err := os.ReadFile(m)
+		require.NoError(t, err)
+		_, err = db.Exec(ctx, string(sql))
+		require.NoError(t, err, m)
+	}
+
+	return db
+}
+
+// newTestCipher returns a cipher whose current key is version current.
+func newTestCipher(t *testing.T, current uint32) *encryption.Cipher {
+	t.Helper()
+	keys := make(map[uint32][]byte)
+	for v := uint32(1); v <= current; v++ {
+		keys[v] = bytes.Repeat([]byte{byte(v)}, encryption.KeySize)
+	}
+	ring, err := encryption.NewKeyring(current, keys, bytes.Repeat([]byte{0xff}, encryption.KeySize))
+	require.NoError(t, err)
+	return encryption.NewCipher(ring)
+}
+
+// TestTranslateError verifies only violations of the document index become ErrDuplicateDocument.
+func TestTranslateError(t *testing.T) {
+	dup := &pgconn.PgError{Code: sqlStateUniqueViolation, ConstraintName: documentIndex}
+	pk := &pgconn.PgError{Code: sqlStateUniqueViolation, ConstraintName: "contacts_pkey"}
+
+	assert.ErrorIs(t, translateError(dup), domain.ErrDuplicateDocument)
+	assert.Same(t, pk, translateError(pk))
+	assert.NoError(t, translateError(nil))
+	assert.Equal(t, assert.AnError, translateError(assert.AnError))
+}
+
+// TestPostgresContactRepository runs the repository contract against Postgres.
+func TestPostgresContactRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) domain.ContactRepository {
+		return NewPostgresContactRepository(newTestDB(t), newTestCipher(t, 1))
+	})
+}
+
+// TestPostgresContactRepository_EncryptedAtRest verifies personal fields are
+// stored encrypted with blind indexes, and that legacy plaintext rows and
+// rotated keys are re-encrypted by Reencrypt.
+func TestPostgresContactRepository_EncryptedAtRest(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	c := &domain.Contact{ID: "c1", DocumentType: domain.DocumentCC, DocumentNumber: "100",
+		FirstName: "Ana", LastName: "Gomez", Email: "ana@flock.com", Phone: "3001234567"}
+	c.CreatedAt, c.UpdatedAt = now, now
+	require.NoError(t, NewPostgresContactRepository(db, newTestCipher(t, 1)).Save(ctx, c))
+
+	_, err := db.Exec(ctx, `INSERT INTO contacts (id, doc_type, doc_number, first_name, last_name, email, phone, created_at, updated_at)
+		VALUES ('legacy', 'CC', '200', 'Luis', 'Diaz', 'luis@flock.com', '3007654321', $1, $1)`, now)
+	require.NoError(t, err)
+
+	var docNumber, email, phone, docIndex string
+	require.NoError(t, db.QueryRow(ctx, `SELECT doc_number, email, phone, doc_number_bidx FROM contacts WHERE id = 'c1'`).
+		Scan(&docNumber, &email, &phone, &docIndex))
+	assert.NotContains(t, docNumber, "100")
+	assert.NotContains(t, email, "ana")
+	assert.NotContains(t, phone, "300")
+	assert.NotEmpty(t, docIndex)
+
+	rotated := NewPostgresContactRepository(db, newTestCipher(t, 2))
+	legacy, err := rotated.GetByDocument(ctx, "", domain.DocumentCC, "200")
+	require.NoError(t, err)
+	assert.Equal(t, "luis@flock.com", legacy.Email)
+
+	count, err := Reencrypt(ctx, db, newTestCipher(t, 2), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = Reencrypt(ctx, db, newTestCipher(t, 2), 1)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	for id, doc := range map[string]string{"c1": "100", "legacy": "200"} {
+		got, err := rotated.GetByDocument(ctx, "", domain.DocumentCC, doc)
+		require.NoError(t, err)
+		assert.Equal(t, id, got.ID)
+
+		var version int
+		require.NoError(t, db.QueryRow(ctx, `SELECT key_version FROM contacts WHERE id = $1`, id).Scan(&version))
+		assert.Equal(t, 2, version)
+	}
+}
+
+// TestMemoryContactRepository runs the repository contract against the in-memory store.
+func TestMemoryContactRepository(t *testing.T) {
+	repotest.Run(t, func(*testing.T) domain.ContactRepository {
+		return NewMemoryContactRepository()
+	})
+}
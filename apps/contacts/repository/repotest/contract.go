@@ -0,0 +1,687 @@
+// Package repotest provides the contract every domain.ContactRepository
+// implementation must satisfy, as a reusable test suite.
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns an empty repository for a single test.
+type Factory func(t *testing.T) domain.ContactRepository
+
+// Run executes the contract suite, creating a fresh repository per case.
+func Run(t *testing.T, newRepo Factory) {
+	cases := []struct {
+		name string
+		run  func(t *testing.T, repo domain.ContactRepository)
+	}{
+		{"SaveAndGetByID", testSaveAndGetByID},
+		{"GetByIDNotFound", testGetByIDNotFound},
+		{"SaveUpdatesExisting", testSaveUpdatesExisting},
+		{"GetByDocument", testGetByDocument},
+		{"DuplicateDocument", testDuplicateDocument},
+		{"ConcurrentDuplicateDocument", testConcurrentDuplicateDocument},
+		{"DeleteHidesContact", testDeleteHidesContact},
+		{"TenantIsolation", testTenantIsolation},
+		{"ListOrderedByCreation", testListOrderedByCreation},
+		{"ReturnsCopies", testReturnsCopies},
+		{"TagCatalog", testTagCatalog},
+		{"AddAndRemoveTags", testAddAndRemoveTags},
+		{"FilterByTags", testFilterByTags},
+		{"DeleteTagUnlinks", testDeleteTagUnlinks},
+		{"FieldDefinitions", testFieldDefinitions},
+		{"FilterByCustomFields", testFilterByCustomFields},
+		{"Relationships", testRelationships},
+		{"DeleteRemovesRelationships", testDeleteRemovesRelationships},
+		{"ConsentHistory", testConsentHistory},
+		{"FilterByMarketingConsent", testFilterByMarketingConsent},
+		{"FilterByEmailAndPhone", testFilterByEmailAndPhone},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newRepo(t))
+		})
+	}
+}
+
+// newContact returns a valid natural person with the given ID and document number.
+// Timestamps are in UTC at microsecond precision so they survive a database round trip.
+func newContact(id, docNumber string) *domain.Contact {
+	c := &domain.Contact{
+		ID:             id,
+		DocumentType:   domain.DocumentCC,
+		DocumentNumber: docNumber,
+		FirstName:      "Ana",
+		LastName:       "Gomez",
+		Email:          "ana@flock.com",
+		Phone:          "3001234567",
+		Address:        "Calle 1",
+		AddressExtra:   "Apto 2",
+		CityCode:       "05001",
+	}
+	c.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	c.UpdatedAt = c.CreatedAt
+	return c
+}
+
+// assertContact compares every persisted field of want and got.
+func assertContact(t *testing.T, want, got *domain.Contact) {
+	t.Helper()
+	require.NotNil(t, got)
+
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.Tenant, got.Tenant)
+	assert.Equal(t, want.DocumentType, got.DocumentType)
+	assert.Equal(t, want.DocumentNumber, got.DocumentNumber)
+	assert.Equal(t, want.LegalName, got.LegalName)
+	assert.Equal(t, want.FirstName, got.FirstName)
+	assert.Equal(t, want.LastName, got.LastName)
+	assert.Equal(t, want.Email, got.Email)
+	assert.Equal(t, want.Phone, got.Phone)
+	assert.Equal(t, want.Address, got.Address)
+	assert.Equal(t, want.AddressExtra, got.AddressExtra)
+	assert.Equal(t, want.CityCode, got.CityCode)
+	assert.True(t, want.CreatedAt.Equal(got.CreatedAt), "createdAt: want %s, got %s", want.CreatedAt, got.CreatedAt)
+	assert.True(t, want.UpdatedAt.Equal(got.UpdatedAt), "updatedAt: want %s, got %s", want.UpdatedAt, got.UpdatedAt)
+	assert.Nil(t, got.DeletedAt)
+}
+
+// testSaveAndGetByID verifies a saved contact reads back unchanged.
+func testSaveAndGetByID(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	c := newContact("c-1", "100")
+
+	require.NoError(t, repo.Save(ctx, c))
+
+	got, err := repo.GetByID(ctx, "", c.ID)
+	require.NoError(t, err)
+	assertContact(t, c, got)
+}
+
+// testGetByIDNotFound verifies unknown IDs report ErrContactNotFound.
+func testGetByIDNotFound(t *testing.T, repo domain.ContactRepository) {
+	_, err := repo.GetByID(context.Background(), "", "missing")
+	require.ErrorIs(t, err, domain.ErrContactNotFound)
+}
+
+// testSaveUpdatesExisting verifies saving an existing ID updates it in place.
+func testSaveUpdatesExisting(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	c := newContact("c-1", "100")
+	require.NoError(t, repo.Save(ctx, c))
+
+	c.Email = "new@flock.com"
+	c.UpdatedAt = c.UpdatedAt.Add(time.Minute)
+	require.NoError(t, repo.Save(ctx, c))
+
+	got, err := repo.GetByID(ctx, "", c.ID)
+	require.NoError(t, err)
+	assertContact(t, c, got)
+
+	all, err := repo.List(ctx, domain.ContactFilter{})
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+// testGetByDocument verifies lookups by document type and number.
+func testGetByDocument(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	c := newContact("c-1", "100")
+	require.NoError(t, repo.Save(ctx, c))
+
+	got, err := repo.GetByDocument(ctx, "", domain.DocumentCC, "100")
+	require.NoError(t, err)
+	assertContact(t, c, got)
+
+	_, err = repo.GetByDocument(ctx, "", domain.DocumentNIT, "100")
+	require.ErrorIs(t, err, domain.ErrContactNotFound)
+}
+
+// testDuplicateDocument verifies two active contacts cannot share a document.
+func testDuplicateDocument(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, newContact("c-1", "100")))
+
+	err := repo.Save(ctx, newContact("c-2", "100"))
+	require.ErrorIs(t, err, domain.ErrDuplicateDocument)
+
+	_, err = repo.GetByID(ctx, "", "c-2")
+	require.ErrorIs(t, err, domain.ErrContactNotFound)
+}
+
+// testConcurrentDuplicateDocument verifies exactly one of many concurrent
+// inserts of the same document wins.
+func testConcurrentDuplicateDocument(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+
+	const writers = 10
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		won   int
+		start = make(chan struct{})
+	)
+
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := newContact(fmt.Sprintf("c-%d", i), "100")
+			<-start
+
+			err := repo.Save(ctx, c)
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				won++
+				return
+			}
+			assert.ErrorIs(t, err, domain.ErrDuplicateDocument)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, 1, won)
+}
+
+// testDeleteHidesContact verifies deleted contacts disappear from reads and
+// free their document for reuse.
+func testDeleteHidesContact(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, newContact("c-1", "100")))
+	require.NoError(t, repo.Delete(ctx, "", "c-1"))
+
+	_, err := repo.GetByID(ctx, "", "c-1")
+	require.ErrorIs(t, err, domain.ErrContactNotFound)
+
+	_, err = repo.GetByDocument(ctx, "", domain.DocumentCC, "100")
+	require.ErrorIs(t, err, domain.ErrContactNotFound)
+
+	all, err := repo.List(ctx, domain.ContactFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	require.NoError(t, repo.Delete(ctx, "", "c-1"))
+	require.NoError(t, repo.Delete(ctx, "", "missing"))
+	require.NoError(t, repo.Save(ctx, newContact("c-2", "100")))
+}
+
+// testTenantIsolation verifies contacts and tags are only visible to their
+// tenant, documents and tag names are unique per tenant and no tenant can
+// change another's contacts or tags.
+func testTenantIsolation(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	acme := newContact("c-1", "100")
+	acme.Tenant = "acme"
+	globex := newContact("c-2", "100")
+	globex.Tenant = "globex"
+	require.NoError(t, repo.Save(ctx, acme))
+	require.NoError(t, repo.Save(ctx, globex), "documents are unique per tenant")
+
+	dup := newContact("c-3", "100")
+	dup.Tenant = "acme"
+	require.ErrorIs(t, repo.Save(ctx, dup), domain.ErrDuplicateDocument)
+
+	_, err := repo.GetByID(ctx, "globex", "c-1")
+	require.ErrorIs(t, err, domain.ErrContactNotFound)
+	got, err := repo.GetByDocument(ctx, "globex", domain.DocumentCC, "100")
+	require.NoError(t, err)
+	assert.Equal(t, "c-2", got.ID)
+
+	all, err := repo.List(ctx, domain.ContactFilter{Tenant: "acme"})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "c-1", all[0].ID)
+
+	hijack := newContact("c-1", "200")
+	hijack.Tenant = "globex"
+	require.ErrorIs(t, repo.Save(ctx, hijack), domain.ErrContactNotFound, "saving another tenant's ID fails")
+	require.NoError(t, repo.Delete(ctx, "globex", "c-1"))
+
+	got, err = repo.GetByID(ctx, "acme", "c-1")
+	require.NoError(t, err)
+	assertContact(t, acme, got)
+
+	acmeVIP := newTag("t-1", "VIP")
+	acmeVIP.Tenant = "acme"
+	globexVIP := newTag("t-2", "vip")
+	globexVIP.Tenant = "globex"
+	require.NoError(t, repo.SaveTag(ctx, acmeVIP))
+	require.NoError(t, repo.SaveTag(ctx, globexVIP), "tag names are unique per tenant")
+
+	tags, err := repo.ListTags(ctx, "acme")
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, *acmeVIP, *tags[0])
+	found, err := repo.GetTagsByName(ctx, "globex", []string{"VIP"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "t-2", found[0].ID)
+
+	added, err := repo.AddTags(ctx, domain.ContactFilter{Tenant: "acme", IDs: []string{"c-1"}}, []string{"t-1", "t-2"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, added, "tags of other tenants are not linked")
+	_, err = repo.AddTags(ctx, domain.ContactFilter{Tenant: "globex", IDs: []string{"c-2"}}, []string{"t-2"})
+	require.NoError(t, err)
+
+	hijackTag := newTag("t-1", "Stolen")
+	hijackTag.Tenant = "globex"
+	require.ErrorIs(t, repo.SaveTag(ctx, hijackTag), domain.ErrTagNotFound, "saving another tenant's ID fails")
+	require.ErrorIs(t, repo.DeleteTag(ctx, "globex", "t-1"), domain.ErrTagNotFound)
+
+	got, err = repo.GetByID(ctx, "acme", "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"VIP"}, tagNames(got))
+
+	require.NoError(t, repo.DeleteTag(ctx, "acme", "t-1"))
+	got, err = repo.GetByID(ctx, "globex", "c-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vip"}, tagNames(got), "deleting a tag keeps other tenants' links")
+}
+
+// testListOrderedByCreation verifies List returns active contacts oldest first.
+func testListOrderedByCreation(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+
+	newer := newContact("c-a", "200")
+	older := newContact("c-b", "100")
+	older.CreatedAt = newer.CreatedAt.Add(-time.Hour)
+
+	require.NoError(t, repo.Save(ctx, newer))
+	require.NoError(t, repo.Save(ctx, older))
+
+	all, err := repo.List(ctx, domain.ContactFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "c-b", all[0].ID)
+	assert.Equal(t, "c-a", all[1].ID)
+}
+
+// testReturnsCopies verifies callers cannot mutate stored state through
+// values passed to or returned from the repository.
+func testReturnsCopies(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	c := newContact("c-1", "100")
+	require.NoError(t, repo.Save(ctx, c))
+	c.Email = "changed@flock.com"
+
+	got, err := repo.GetByID(ctx, "", "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, "ana@flock.com", got.Email)
+
+	got.Email = "changed@flock.com"
+	again, err := repo.GetByID(ctx, "", "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, "ana@flock.com", again.Email)
+}
+
+// newTag returns a tag with the given ID and name.
+func newTag(id, name string) *domain.Tag {
+	t := &domain.Tag{ID: id, Name: name, Color: "#ff8800", Description: name + " customers"}
+	t.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	t.UpdatedAt = t.CreatedAt
+	return t
+}
+
+// tagNames returns the names of the tags of c.
+func tagNames(c *domain.Contact) []string {
+	var names []string
+	for _, t := range c.Tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// contactIDs returns the IDs of contacts.
+func contactIDs(contacts []*domain.Contact) []string {
+	var ids []string
+	for _, c := range contacts {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+// testTagCatalog verifies tags are stored, listed by name and unique regardless of case.
+func testTagCatalog(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	vip := newTag("t-1", "VIP")
+	require.NoError(t, repo.SaveTag(ctx, vip))
+	require.NoError(t, repo.SaveTag(ctx, newTag("t-2", "Black Friday 2025")))
+
+	err := repo.SaveTag(ctx, newTag("t-3", "vip"))
+	require.ErrorIs(t, err, domain.ErrDuplicateTag)
+
+	all, err := repo.ListTags(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "Black Friday 2025", all[0].Name)
+	assert.Equal(t, *vip, *all[1])
+
+	found, err := repo.GetTagsByName(ctx, "", []string{" vip ", "missing"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "t-1", found[0].ID)
+}
+
+// testAddAndRemoveTags verifies links are created once, returned with the
+// contact and removed again.
+func testAddAndRemoveTags(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, newContact("c-1", "100")))
+	require.NoError(t, repo.SaveTag(ctx, newTag("t-1", "wholesale")))
+	require.NoError(t, repo.SaveTag(ctx, newTag("t-2", "VIP")))
+	only := domain.ContactFilter{IDs: []string{"c-1"}}
+
+	added, err := repo.AddTags(ctx, only, []string{"t-1", "t-2"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	added, err = repo.AddTags(ctx, only, []string{"t-1"})
+	require.NoError(t, err)
+	assert.Zero(t, added)
+
+	got, err := repo.GetByID(ctx, "", "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"VIP", "wholesale"}, tagNames(got))
+
+	removed, err := repo.RemoveTags(ctx, only, []string{"t-2"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	got, err = repo.GetByID(ctx, "", "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wholesale"}, tagNames(got))
+}
+
+// testFilterByTags verifies List and bulk operations select contacts by any or all tags.
+func testFilterByTags(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	for i, id := range []string{"c-1", "c-2", "c-3"} {
+		require.NoError(t, repo.Save(ctx, newContact(id, fmt.Sprint(100+i))))
+	}
+	require.NoError(t, repo.SaveTag(ctx, newTag("t-1", "VIP")))
+	require.NoError(t, repo.SaveTag(ctx, newTag("t-2", "wholesale")))
+
+	_, err := repo.AddTags(ctx, domain.ContactFilter{IDs: []string{"c-1", "c-2"}}, []string{"t-1"})
+	require.NoError(t, err)
+	_, err = repo.AddTags(ctx, domain.ContactFilter{IDs: []string{"c-2", "c-3"}}, []string{"t-2"})
+	require.NoError(t, err)
+
+	either, err := repo.List(ctx, domain.ContactFilter{Tags: []string{"vip", "WHOLESALE"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"c-1", "c-2", "c-3"}, contactIDs(either))
+
+	all, err := repo.List(ctx, domain.ContactFilter{Tags: []string{"vip", "wholesale"}, Match: domain.MatchAll})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c-2"}, contactIDs(all))
+
+	none, err := repo.List(ctx, domain.ContactFilter{Tags: []string{"missing"}})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	removed, err := repo.RemoveTags(ctx, domain.ContactFilter{Tags: []string{"wholesale"}}, []string{"t-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	vip, err := repo.List(ctx, domain.ContactFilter{Tags: []string{"VIP"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c-1"}, contactIDs(vip))
+}
+
+// testDeleteTagUnlinks verifies deleting a tag removes it from contacts.
+func testDeleteTagUnlinks(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, newContact("c-1", "100")))
+	require.NoError(t, repo.SaveTag(ctx, newTag("t-1", "VIP")))
+	_, err := repo.AddTags(ctx, domain.ContactFilter{IDs: []string{"c-1"}}, []string{"t-1"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.DeleteTag(ctx, "", "t-1"))
+	require.ErrorIs(t, repo.DeleteTag(ctx, "", "t-1"), domain.ErrTagNotFound)
+
+	got, err := repo.GetByID(ctx, "", "c-1")
+	require.NoError(t, err)
+	assert.Empty(t, got.Tags)
+}
+
+// newFieldDefinition returns a field definition of tenant with the given ID and key.
+func newFieldDefinition(id, tenant, key string) *domain.FieldDefinition {
+	d := &domain.FieldDefinition{ID: id, Tenant: tenant, Key: key, Type: domain.FieldNumber}
+	d.Rules.Max = new(float64)
+	*d.Rules.Max = 50
+	d.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	d.UpdatedAt = d.CreatedAt
+	return d
+}
+
+// testFieldDefinitions verifies definitions are scoped by tenant, keys are
+// unique per tenant and deleting a definition strips its values from contacts.
+func testFieldDefinitions(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	require.NoError(t, repo.SaveFieldDefinition(ctx, newFieldDefinition("f-1", "acme", "shoe_size")))
+	require.NoError(t, repo.SaveFieldDefinition(ctx, newFieldDefinition("f-2", "acme", "birthday")))
+	require.NoError(t, repo.SaveFieldDefinition(ctx, newFieldDefinition("f-3", "globex", "shoe_size")))
+	require.ErrorIs(t, repo.SaveFieldDefinition(ctx, newFieldDefinition("f-4", "acme", "shoe_size")), domain.ErrDuplicateFieldDefinition)
+	require.ErrorIs(t, repo.SaveFieldDefinition(ctx, newFieldDefinition("f-2", "globex", "birthday")),
+		domain.ErrFieldDefinitionNotFound, "saving another tenant's ID fails")
+
+	defs, err := repo.ListFieldDefinitions(ctx, "acme")
+	require.NoError(t, err)
+	require.Len(t, defs, 2)
+	assert.Equal(t, "birthday", defs[0].Key)
+	assert.Equal(t, "shoe_size", defs[1].Key)
+	assert.Equal(t, domain.FieldNumber, defs[1].Type)
+	require.NotNil(t, defs[1].Rules.Max)
+	assert.Equal(t, 50.0, *defs[1].Rules.Max)
+
+	acme := newContact("c-1", "100")
+	acme.Tenant = "acme"
+	acme.CustomFields = map[string]any{"shoe_size": 42.0, "birthday": "1990-05-01"}
+	globex := newContact("c-2", "100")
+	globex.Tenant = "globex"
+	globex.CustomFields = map[string]any{"shoe_size": 40.0}
+	require.NoError(t, repo.Save(ctx, acme))
+	require.NoError(t, repo.Save(ctx, globex))
+
+	require.ErrorIs(t, repo.DeleteFieldDefinition(ctx, "globex", "f-1"), domain.ErrFieldDefinitionNotFound)
+	require.NoError(t, repo.DeleteFieldDefinition(ctx, "acme", "f-1"))
+
+	defs, err = repo.ListFieldDefinitions(ctx, "acme")
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	assert.Equal(t, "birthday", defs[0].Key)
+
+	got, err := repo.GetByID(ctx, "acme", "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"birthday": "1990-05-01"}, got.CustomFields, "the deleted key is stripped from the tenant's contacts")
+	got, err = repo.GetByID(ctx, "globex", "c-2")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"shoe_size": 40.0}, got.CustomFields, "other tenants keep their values")
+}
+
+// testFilterByCustomFields verifies custom fields are persisted and List selects contacts by exact values.
+func testFilterByCustomFields(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	for i, size := range []float64{38, 42, 42} {
+		c := newContact(fmt.Sprintf("c-%d", i+1), fmt.Sprint(100+i))
+		c.CustomFields = map[string]any{"shoe_size": size, "vip": i == 2}
+		require.NoError(t, repo.Save(ctx, c))
+	}
+
+	got, err := repo.GetByID(ctx, "", "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"shoe_size": 38.0, "vip": false}, got.CustomFields)
+
+	size42, err := repo.List(ctx, domain.ContactFilter{CustomFields: map[string]any{"shoe_size": 42.0}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c-2", "c-3"}, contactIDs(size42))
+
+	vip, err := repo.List(ctx, domain.ContactFilter{CustomFields: map[string]any{"shoe_size": 42.0, "vip": true}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c-3"}, contactIDs(vip))
+}
+
+// newRelationship returns a relationship of type typ from one contact to another.
+func newRelationship(id, from, to string, typ domain.RelationType) *domain.Relationship {
+	r := &domain.Relationship{ID: id, Type: typ, FromID: from, ToID: to, Role: "buyer"}
+	r.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	r.UpdatedAt = r.CreatedAt
+	return r
+}
+
+// relationshipIDs returns the IDs of rels in order.
+func relationshipIDs(rels []*domain.Relationship) []string {
+	ids := make([]string, len(rels))
+	for i, r := range rels {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+// testRelationships verifies relationships are listed from either side, unique per type and deletable.
+func testRelationships(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	for i, id := range []string{"p-1", "c-1", "c-2"} {
+		require.NoError(t, repo.Save(ctx, newContact(id, fmt.Sprint(100+i))))
+	}
+
+	employee := newRelationship("r-1", "p-1", "c-1", domain.RelationEmployeeOf)
+	employee.ValidFrom = new(time.Time)
+	*employee.ValidFrom = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.SaveRelationship(ctx, employee))
+	require.NoError(t, repo.SaveRelationship(ctx, newRelationship("r-2", "c-2", "c-1", domain.RelationParentCompanyOf)))
+	require.ErrorIs(t, repo.SaveRelationship(ctx, newRelationship("r-3", "p-1", "c-1", domain.RelationEmployeeOf)),
+		domain.ErrDuplicateRelationship)
+
+	rels, err := repo.ListRelationships(ctx, "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r-1", "r-2"}, relationshipIDs(rels))
+	assert.Equal(t, "buyer", rels[0].Role)
+	require.NotNil(t, rels[0].ValidFrom)
+	assert.Equal(t, "2024-03-01", rels[0].ValidFrom.Format(time.DateOnly))
+	assert.Nil(t, rels[0].ValidUntil)
+
+	rels, err = repo.ListRelationships(ctx, "p-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r-1"}, relationshipIDs(rels))
+
+	require.ErrorIs(t, repo.DeleteRelationship(ctx, "p-1", "r-2"), domain.ErrRelationshipNotFound)
+	require.NoError(t, repo.DeleteRelationship(ctx, "c-1", "r-2"))
+
+	rels, err = repo.ListRelationships(ctx, "c-2")
+	require.NoError(t, err)
+	assert.Empty(t, rels)
+}
+
+// testDeleteRemovesRelationships verifies deleting either side of a relationship removes it.
+func testDeleteRemovesRelationships(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	for i, id := range []string{"p-1", "p-2", "c-1"} {
+		require.NoError(t, repo.Save(ctx, newContact(id, fmt.Sprint(100+i))))
+	}
+	require.NoError(t, repo.SaveRelationship(ctx, newRelationship("r-1", "p-1", "c-1", domain.RelationEmployeeOf)))
+	require.NoError(t, repo.SaveRelationship(ctx, newRelationship("r-2", "p-2", "c-1", domain.RelationBillingContactFor)))
+
+	require.NoError(t, repo.Delete(ctx, "", "p-1"))
+
+	rels, err := repo.ListRelationships(ctx, "c-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r-2"}, relationshipIDs(rels))
+
+	require.NoError(t, repo.Delete(ctx, "", "c-1"))
+
+	rels, err = repo.ListRelationships(ctx, "p-2")
+	require.NoError(t, err)
+	assert.Empty(t, rels)
+}
+
+// newConsent returns a consent decision of a contact recorded at the given offset from a fixed time.
+func newConsent(id, contactID string, channel domain.ConsentChannel, action domain.ConsentAction, offset time.Duration) *domain.ConsentRecord {
+	return &domain.ConsentRecord{
+		ID: id, ContactID: contactID, Purpose: domain.PurposeMarketing, Channel: channel, Action: action,
+		Source: "web_form", PolicyVersion: "2025-01", IP: "203.0.113.7",
+		RecordedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(offset),
+	}
+}
+
+// testConsentHistory verifies the history is kept in order and contacts carry their current consents.
+func testConsentHistory(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, newContact("c-1", "100")))
+	require.NoError(t, repo.AddConsent(ctx, newConsent("k-2", "c-1", domain.ChannelSMS, domain.ConsentRevoke, time.Hour)))
+	require.NoError(t, repo.AddConsent(ctx, newConsent("k-1", "c-1", domain.ChannelSMS, domain.ConsentGrant, 0)))
+
+	history, err := repo.ListConsents(ctx, "c-1")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "k-1", history[0].ID)
+	assert.Equal(t, "203.0.113.7", history[0].IP)
+	assert.Equal(t, domain.ConsentRevoke, history[1].Action)
+
+	got, err := repo.GetByID(ctx, "", "c-1")
+	require.NoError(t, err)
+	require.Len(t, got.Consents, 1)
+	assert.Equal(t, domain.ChannelSMS, got.Consents[0].Channel)
+	assert.False(t, got.Consents[0].Granted)
+
+	require.NoError(t, repo.Delete(ctx, "", "c-1"))
+	history, err = repo.ListConsents(ctx, "c-1")
+	require.NoError(t, err)
+	assert.Len(t, history, 2, "consent proof outlives the contact")
+}
+
+// testFilterByMarketingConsent verifies List selects contacts whose latest marketing decision for a channel is a grant.
+func testFilterByMarketingConsent(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+	for i, id := range []string{"c-1", "c-2", "c-3"} {
+		require.NoError(t, repo.Save(ctx, newContact(id, fmt.Sprint(100+i))))
+	}
+	require.NoError(t, repo.AddConsent(ctx, newConsent("k-1", "c-1", domain.ChannelEmail, domain.ConsentGrant, 0)))
+	require.NoError(t, repo.AddConsent(ctx, newConsent("k-2", "c-2", domain.ChannelEmail, domain.ConsentGrant, 0)))
+	require.NoError(t, repo.AddConsent(ctx, newConsent("k-3", "c-2", domain.ChannelEmail, domain.ConsentRevoke, time.Hour)))
+	require.NoError(t, repo.AddConsent(ctx, newConsent("k-4", "c-3", domain.ChannelSMS, domain.ConsentGrant, 0)))
+
+	email, err := repo.List(ctx, domain.ContactFilter{MarketingChannel: domain.ChannelEmail})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c-1"}, contactIDs(email))
+
+	sms, err := repo.List(ctx, domain.ContactFilter{MarketingChannel: domain.ChannelSMS})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c-3"}, contactIDs(sms))
+}
+
+// testFilterByEmailAndPhone verifies contacts can be looked up by email,
+// ignoring case and padding, and by phone.
+func testFilterByEmailAndPhone(t *testing.T, repo domain.ContactRepository) {
+	ctx := context.Background()
+
+	ana := newContact("c-1", "100")
+	other := newContact("c-2", "200")
+	other.Email, other.Phone = "luis@flock.com", "3109876543"
+	require.NoError(t, repo.Save(ctx, ana))
+	require.NoError(t, repo.Save(ctx, other))
+
+	byEmail, err := repo.List(ctx, domain.ContactFilter{Email: " ANA@flock.com "})
+	require.NoError(t, err)
+	require.Len(t, byEmail, 1)
+	assert.Equal(t, "c-1", byEmail[0].ID)
+
+	byPhone, err := repo.List(ctx, domain.ContactFilter{Phone: "3109876543"})
+	require.NoError(t, err)
+	require.Len(t, byPhone, 1)
+	assert.Equal(t, "c-2", byPhone[0].ID)
+
+	both, err := repo.List(ctx, domain.ContactFilter{Email: "ana@flock.com", Phone: "3109876543"})
+	require.NoError(t, err)
+	assert.Empty(t, both)
+}
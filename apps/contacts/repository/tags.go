@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/helper"
+)
+
+const (
+	// tagNameIndex enforces case-insensitive unique tag names per tenant.
+	tagNameIndex = "idx_tags_name"
+
+	// tagColumns lists the columns read by scanTag, in order.
+	tagColumns = `id, tenant, name, COALESCE(color, ''), COALESCE(description, ''), created_at, updated_at`
+)
+
+// SaveTag inserts or updates a catalog tag.
+// Returns domain.ErrDuplicateTag when another tag of the tenant has the same name.
+// A tag never moves to another tenant: saving an ID held by another tenant
+// returns domain.ErrTagNotFound.
+func (r *postgresContactRepository) SaveTag(ctx context.Context, t *domain.Tag) error {
+	query := `
+		INSERT INTO tags (id, tenant, name, color, description, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+		ON CONFLICT (id) DO UPDATE SET
+			name=$3, color=$4, description=$5, created_at=$6, updated_at=$7
+		WHERE tags.tenant = EXCLUDED.tenant
+	`
+
+	tag, err := r.db.Exec(ctx, query, t.ID, t.Tenant, t.Name, t.Color, t.Description, t.CreatedAt, t.UpdatedAt)
+	if err != nil {
+		return translateError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTagNotFound
+	}
+	return nil
+}
+
+// ListTags returns the tag catalog of a tenant ordered by name.
+func (r *postgresContactRepository) ListTags(ctx context.Context, tenant string) ([]*domain.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE tenant = $1 ORDER BY lower(name)`
+	return r.queryTags(ctx, query, tenant)
+}
+
+// GetTagsByName returns the catalog tags of a tenant with the given names, ignoring case.
+func (r *postgresContactRepository) GetTagsByName(ctx context.Context, tenant string, names []string) ([]*domain.Tag, error) {
+	names = domain.NormalizeTagNames(names)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE tenant = $1 AND lower(name) = ANY($2) ORDER BY lower(name)`
+	return r.queryTags(ctx, query, tenant, names)
+}
+
+// DeleteTag removes a tag of a tenant; its links to contacts are removed by cascade.
+// Returns domain.ErrTagNotFound when the tenant has no such tag.
+func (r *postgresContactRepository) DeleteTag(ctx context.Context, tenant, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM tags WHERE tenant = $1 AND id = $2`, tenant, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTagNotFound
+	}
+	return nil
+}
+
+// AddTags links the tags to every active contact matching filter, skipping
+// tags of other tenants.
+func (r *postgresContactRepository) AddTags(ctx context.Context, filter domain.ContactFilter, tagIDs []string) (int, error) {
+	where, args, err := r.filterClause(filter, []any{tagIDs})
+	if err != nil {
+		return 0, err
+	}
+	query := `
+		INSERT INTO contact_tags (contact_id, tag_id, created_at)
+		SELECT c.id, t.id, NOW()
+		FROM contacts c JOIN tags t ON t.id = ANY($1) AND t.tenant = c.tenant
+		WHERE ` + where + `
+		ON CONFLICT DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// RemoveTags unlinks the tags from every active contact matching filter.
+func (r *postgresContactRepository) RemoveTags(ctx context.Context, filter domain.ContactFilter, tagIDs []string) (int, error) {
+	where, args, err := r.filterClause(filter, []any{tagIDs})
+	if err != nil {
+		return 0, err
+	}
+	query := `
+		DELETE FROM contact_tags
+		WHERE tag_id = ANY($1) AND contact_id IN (SELECT c.id FROM contacts c WHERE ` + where + `)
+	`
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// queryTags runs a query selecting tagColumns.
+func (r *postgresContactRepository) queryTags(ctx context.Context, query string, args ...any) ([]*domain.Tag, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []*domain.Tag
+	for rows.Next() {
+		var t domain.Tag
+		if err := rows.Scan(&t.ID, &t.Tenant, &t.Name, &t.Color, &t.Description, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, &t)
+	}
+	return tags, rows.Err()
+}
+
+// loadTags fills the Tags of contacts with a single query.
+func (r *postgresContactRepository) loadTags(ctx context.Context, contacts ...*domain.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.Contact, len(contacts))
+	ids := make([]string, len(contacts))
+	for i, c := range contacts {
+		byID[c.ID] = c
+		ids[i] = c.ID
+	}
+
+	query := `
+		SELECT ct.contact_id, t.id, t.tenant, t.name, COALESCE(t.color, ''), COALESCE(t.description, ''), t.created_at, t.updated_at
+		FROM contact_tags ct JOIN tags t ON t.id = ct.tag_id
+		WHERE ct.contact_id = ANY($1)
+		ORDER BY lower(t.name)
+	`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			contactID string
+			t         domain.Tag
+		)
+		if err := rows.Scan(&contactID, &t.ID, &t.Tenant, &t.Name, &t.Color, &t.Description, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return err
+		}
+		c := byID[contactID]
+		c.Tags = append(c.Tags, t)
+	}
+	return rows.Err()
+}
+
+// filterClause renders filter as conditions on the contacts table aliased c,
+// appending its arguments to args. Email and phone are matched through their
+// blind indexes, or in clear for rows not yet encrypted.
+func (r *postgresContactRepository) filterClause(filter domain.ContactFilter, args []any) (string, []any, error) {
+	args = append(args, filter.Tenant)
+	where := fmt.Sprintf("c.deleted_at IS NULL AND c.tenant = $%d", len(args))
+
+	if len(filter.IDs) > 0 {
+		args = append(args, filter.IDs)
+		where += fmt.Sprintf(" AND c.id = ANY($%d)", len(args))
+	}
+
+	if names := domain.NormalizeTagNames(filter.Tags); len(names) > 0 {
+		args = append(args, names)
+		matched := fmt.Sprintf(`
+			SELECT count(DISTINCT ct.tag_id) FROM contact_tags ct JOIN tags t ON t.id = ct.tag_id
+			WHERE ct.contact_id = c.id AND lower(t.name) = ANY($%d)`, len(args))
+
+		if filter.Match == domain.MatchAll {
+			where += fmt.Sprintf(" AND (%s) = %d", matched, len(names))
+		} else {
+			where += fmt.Sprintf(" AND (%s) > 0", matched)
+		}
+	}
+
+	if len(filter.CustomFields) > 0 {
+		args = append(args, filter.CustomFields)
+		where += fmt.Sprintf(" AND c.custom_fields @> $%d::jsonb", len(args))
+	}
+
+	if filter.MarketingChannel != "" {
+		args = append(args, filter.MarketingChannel)
+		where += " AND " + marketingClause(len(args))
+	}
+
+	for _, lookup := range []struct{ field, value, clear string }{
+		{helper.FieldEmail, strings.ToLower(strings.TrimSpace(filter.Email)), "lower(trim(c.email))"},
+		{helper.FieldPhone, strings.TrimSpace(filter.Phone), "trim(c.phone)"},
+	} {
+		if lookup.value == "" {
+			continue
+		}
+		index, err := helper.BlindIndex(r.cipher, lookup.field, lookup.value)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, index, lookup.value)
+		where += fmt.Sprintf(" AND (c.%s_bidx = $%d OR (c.key_version IS NULL AND %s = $%d))",
+			lookup.field, len(args)-1, lookup.clear, len(args))
+	}
+
+	return where, args, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/google/uuid"
+)
+
+// GrantConsent records that a contact gave consent for r.Purpose and r.Channel.
+func (s *contactService) GrantConsent(ctx context.Context, r *domain.ConsentRecord) error {
+	r.Action = domain.ConsentGrant
+	return s.recordConsent(ctx, r)
+}
+
+// RevokeConsent records that a contact withdrew consent for r.Purpose and r.Channel.
+func (s *contactService) RevokeConsent(ctx context.Context, r *domain.ConsentRecord) error {
+	r.Action = domain.ConsentRevoke
+	return s.recordConsent(ctx, r)
+}
+
+// ConsentHistory returns every consent record of an existing contact, oldest first.
+func (s *contactService) ConsentHistory(ctx context.Context, contactID string) ([]*domain.ConsentRecord, error) {
+	if _, err := s.repo.GetByID(ctx, reqctx.Tenant(ctx), contactID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListConsents(ctx, contactID)
+}
+
+// recordConsent validates r and appends it to the history of its contact,
+// which must exist, generating the ID and timestamp.
+func (s *contactService) recordConsent(ctx context.Context, r *domain.ConsentRecord) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	err := s.tx.WithTx(ctx, writeTx, func(ctx context.Context) error {
+		if _, err := s.repo.GetByID(ctx, reqctx.Tenant(ctx), r.ContactID); err != nil {
+			return err
+		}
+
+		r.ID = uuid.NewString()
+		r.RecordedAt = time.Now()
+		return s.repo.AddConsent(ctx, r)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Debugw("consent recorded", "contactId", r.ContactID,
+		"purpose", r.Purpose, "channel", r.Channel, "action", r.Action)
+	return nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newConsent returns a marketing decision for the email channel of contact c1.
+func newConsent() *domain.ConsentRecord {
+	return &domain.ConsentRecord{ContactID: "c1", Purpose: domain.PurposeMarketing, Channel: domain.ChannelEmail,
+		Source: "web_form", PolicyVersion: "2025-01", IP: "203.0.113.7"}
+}
+
+// TestGrantConsent_Success ensures grants are appended with an ID and timestamp.
+func TestGrantConsent_Success(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	consent := newConsent()
+
+	repo.On("GetByID", mock.Anything, "", "c1").Return(&domain.Contact{ID: "c1"}, nil)
+	repo.On("AddConsent", mock.Anything, consent).Return(nil)
+
+	require.NoError(t, svc.GrantConsent(context.Background(), consent))
+	assert.NotEmpty(t, consent.ID)
+	assert.Equal(t, domain.ConsentGrant, consent.Action)
+	assert.False(t, consent.RecordedAt.IsZero())
+}
+
+// TestRevokeConsent_ContactNotFound ensures decisions are only recorded for existing contacts.
+func TestRevokeConsent_ContactNotFound(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+
+	repo.On("GetByID", mock.Anything, "", "c1").Return(nil, domain.ErrContactNotFound)
+
+	err := svc.RevokeConsent(context.Background(), newConsent())
+	assert.ErrorIs(t, err, domain.ErrContactNotFound)
+}
+
+// TestGrantConsent_Invalid ensures malformed decisions never reach the repository.
+func TestGrantConsent_Invalid(t *testing.T) {
+	svc := NewContactService(mocks.NewContactRepository(t), newTransactor(t))
+	consent := newConsent()
+	consent.Channel = ""
+
+	assert.ErrorIs(t, svc.GrantConsent(context.Background(), consent), domain.ErrInvalidConsent)
+}
+
+// TestList_UnknownMarketingChannel ensures unknown channels are rejected before listing.
+func TestList_UnknownMarketingChannel(t *testing.T) {
+	svc := NewContactService(mocks.NewContactRepository(t), newTransactor(t))
+
+	_, err := svc.List(context.Background(), domain.ContactFilter{MarketingChannel: "fax"})
+	assert.ErrorIs(t, err, domain.ErrInvalidConsent)
+}
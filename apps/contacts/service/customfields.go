@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/google/uuid"
+)
+
+// CreateFieldDefinition adds a custom field definition for the tenant in ctx,
+// generating the ID and timestamps.
+func (s *contactService) CreateFieldDefinition(ctx context.Context, d *domain.FieldDefinition) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	d.ID = uuid.NewString()
+	d.Tenant = reqctx.Tenant(ctx)
+	d.CreatedAt = time.Now()
+	d.UpdatedAt = d.CreatedAt
+	if err := s.repo.SaveFieldDefinition(ctx, d); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Debugw("custom field created", "fieldId", d.ID, "key", d.Key)
+	return nil
+}
+
+// ListFieldDefinitions returns the custom field definitions of the tenant in ctx.
+func (s *contactService) ListFieldDefinitions(ctx context.Context) ([]*domain.FieldDefinition, error) {
+	return s.repo.ListFieldDefinitions(ctx, reqctx.Tenant(ctx))
+}
+
+// DeleteFieldDefinition removes a custom field definition of the tenant in ctx
+// together with its values on the tenant's contacts.
+func (s *contactService) DeleteFieldDefinition(ctx context.Context, id string) error {
+	return s.repo.DeleteFieldDefinition(ctx, reqctx.Tenant(ctx), id)
+}
+
+// validateCustomFields checks c.CustomFields against the definitions of the
+// tenant in ctx and replaces them with their normalized values.
+func (s *contactService) validateCustomFields(ctx context.Context, c *domain.Contact) error {
+	defs, err := s.repo.ListFieldDefinitions(ctx, reqctx.Tenant(ctx))
+	if err != nil {
+		return err
+	}
+
+	values, err := domain.ValidateCustomFields(defs, c.CustomFields)
+	if err != nil {
+		return err
+	}
+	c.CustomFields = values
+	return nil
+}
+
+// resolveFilter scopes filter to the tenant in ctx, checks its marketing channel
+// and converts its custom field values to their field types, using the
+// definitions of the tenant.
+func (s *contactService) resolveFilter(ctx context.Context, filter domain.ContactFilter) (domain.ContactFilter, error) {
+	filter.Tenant = reqctx.Tenant(ctx)
+	if filter.MarketingChannel != "" && !filter.MarketingChannel.Valid() {
+		return filter, domain.ErrInvalidConsent.WithDetails(map[string]string{
+			"purpose": string(domain.PurposeMarketing), "reason": "unknown channel",
+		})
+	}
+	if len(filter.CustomFields) == 0 {
+		return filter, nil
+	}
+
+	defs, err := s.repo.ListFieldDefinitions(ctx, reqctx.Tenant(ctx))
+	if err != nil {
+		return filter, err
+	}
+
+	values, err := domain.ParseCustomFieldFilter(defs, filter.CustomFields)
+	if err != nil {
+		return filter, err
+	}
+	filter.CustomFields = values
+	return filter, nil
+}
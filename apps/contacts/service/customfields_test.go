@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// shoeSize is a required number field between 20 and 50.
+func shoeSize() *domain.FieldDefinition {
+	lo, hi := 20.0, 50.0
+	return &domain.FieldDefinition{ID: "f1", Tenant: "acme", Key: "shoe_size", Type: domain.FieldNumber,
+		Required: true, Rules: domain.FieldRules{Min: &lo, Max: &hi}}
+}
+
+// TestCreateFieldDefinition_Success ensures definitions get an ID, timestamps and the request tenant.
+func TestCreateFieldDefinition_Success(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	def := &domain.FieldDefinition{Key: "birthday", Type: domain.FieldDate}
+
+	repo.On("SaveFieldDefinition", mock.Anything, def).Return(nil)
+
+	require.NoError(t, svc.CreateFieldDefinition(reqctx.WithTenant(context.Background(), "acme"), def))
+	assert.NotEmpty(t, def.ID)
+	assert.Equal(t, "acme", def.Tenant)
+	assert.False(t, def.CreatedAt.IsZero())
+}
+
+// TestCreateFieldDefinition_Invalid ensures malformed definitions never reach the repository.
+func TestCreateFieldDefinition_Invalid(t *testing.T) {
+	svc := NewContactService(mocks.NewContactRepository(t), newTransactor(t))
+
+	err := svc.CreateFieldDefinition(context.Background(), &domain.FieldDefinition{Key: "store", Type: domain.FieldEnum})
+	assert.ErrorIs(t, err, domain.ErrInvalidFieldDefinition)
+}
+
+// TestCreate_InvalidCustomFields ensures contacts are validated against the tenant schema.
+func TestCreate_InvalidCustomFields(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	contact := newValidContact()
+	contact.CustomFields = map[string]any{"shoe_size": 51}
+
+	repo.On("GetByDocument", mock.Anything, "acme", contact.DocumentType, contact.DocumentNumber).Return(nil, domain.ErrContactNotFound)
+	repo.On("ListFieldDefinitions", mock.Anything, "acme").Return([]*domain.FieldDefinition{shoeSize()}, nil)
+
+	err := svc.Create(reqctx.WithTenant(context.Background(), "acme"), contact)
+	assert.ErrorIs(t, err, domain.ErrInvalidCustomFields)
+}
+
+// TestUpdate_MergesCustomFields ensures patched custom fields are merged, validated and normalized.
+func TestUpdate_MergesCustomFields(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	existing := &domain.Contact{ID: "c1", CustomFields: map[string]any{"shoe_size": 40.0}}
+
+	repo.On("GetByID", mock.Anything, "", "c1").Return(existing, nil)
+	repo.On("ListFieldDefinitions", mock.Anything, "").Return([]*domain.FieldDefinition{shoeSize()}, nil)
+	repo.On("Save", mock.Anything, existing).Return(nil)
+
+	got, err := svc.Update(context.Background(), "c1", &domain.ContactPatch{CustomFields: map[string]any{"shoe_size": 42}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"shoe_size": 42.0}, got.CustomFields)
+}
+
+// TestList_ParsesCustomFieldFilter ensures raw query values are converted before listing.
+func TestList_ParsesCustomFieldFilter(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+
+	repo.On("ListFieldDefinitions", mock.Anything, "").Return([]*domain.FieldDefinition{shoeSize()}, nil)
+	repo.On("List", mock.Anything, domain.ContactFilter{CustomFields: map[string]any{"shoe_size": 42.0}}).Return(nil, nil)
+
+	_, err := svc.List(context.Background(), domain.ContactFilter{CustomFields: map[string]any{"shoe_size": "42"}})
+	require.NoError(t, err)
+}
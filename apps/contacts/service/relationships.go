@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/google/uuid"
+)
+
+// CreateRelationship links two existing contacts, generating the ID and timestamps.
+// Both contacts must exist and be the kind of contacts the relationship type links.
+func (s *contactService) CreateRelationship(ctx context.Context, r *domain.Relationship) error {
+	err := s.tx.WithTx(ctx, writeTx, func(ctx context.Context) error {
+		from, err := s.repo.GetByID(ctx, reqctx.Tenant(ctx), r.FromID)
+		if err != nil {
+			return err
+		}
+		to, err := s.repo.GetByID(ctx, reqctx.Tenant(ctx), r.ToID)
+		if err != nil {
+			return err
+		}
+		if err := r.Validate(from, to); err != nil {
+			return err
+		}
+
+		r.ID = uuid.NewString()
+		r.CreatedAt = time.Now()
+		r.UpdatedAt = r.CreatedAt
+		return s.repo.SaveRelationship(ctx, r)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Debugw("relationship created", "relationshipId", r.ID, "type", r.Type)
+	return nil
+}
+
+// DeleteRelationship removes a relationship of an existing contact.
+func (s *contactService) DeleteRelationship(ctx context.Context, contactID, id string) error {
+	if _, err := s.repo.GetByID(ctx, reqctx.Tenant(ctx), contactID); err != nil {
+		return err
+	}
+	return s.repo.DeleteRelationship(ctx, contactID, id)
+}
+
+// ListPeople returns the people related to a company.
+func (s *contactService) ListPeople(ctx context.Context, companyID string, activeOn time.Time) ([]*domain.RelatedContact, error) {
+	return s.related(ctx, companyID, activeOn, func(r *domain.Relationship) bool {
+		return r.Type.PersonToCompany() && r.ToID == companyID
+	})
+}
+
+// ListCompanies returns the companies related to a contact.
+func (s *contactService) ListCompanies(ctx context.Context, contactID string, activeOn time.Time) ([]*domain.RelatedContact, error) {
+	return s.related(ctx, contactID, activeOn, func(r *domain.Relationship) bool {
+		return !r.Type.PersonToCompany() || r.FromID == contactID
+	})
+}
+
+// related returns the relationships of an existing contact accepted by keep,
+// paired with the contact on their other side.
+func (s *contactService) related(ctx context.Context, contactID string, activeOn time.Time, keep func(*domain.Relationship) bool) ([]*domain.RelatedContact, error) {
+	if _, err := s.repo.GetByID(ctx, reqctx.Tenant(ctx), contactID); err != nil {
+		return nil, err
+	}
+
+	rels, err := s.repo.ListRelationships(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []*domain.Relationship
+	var ids []string
+	for _, r := range rels {
+		if keep(r) && (activeOn.IsZero() || r.ActiveOn(activeOn)) {
+			kept = append(kept, r)
+			ids = append(ids, r.Other(contactID))
+		}
+	}
+	if len(kept) == 0 {
+		return []*domain.RelatedContact{}, nil
+	}
+
+	contacts, err := s.repo.List(ctx, domain.ContactFilter{Tenant: reqctx.Tenant(ctx), IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.Contact, len(contacts))
+	for _, c := range contacts {
+		byID[c.ID] = c
+	}
+
+	out := make([]*domain.RelatedContact, 0, len(kept))
+	for _, r := range kept {
+		if c, ok := byID[r.Other(contactID)]; ok {
+			out = append(out, &domain.RelatedContact{Relationship: r, Contact: c})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateRelationship_Success ensures valid relationships get an ID and timestamps.
+func TestCreateRelationship_Success(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	rel := &domain.Relationship{Type: domain.RelationEmployeeOf, FromID: "p1", ToID: "c1", Role: "purchasing manager"}
+
+	repo.On("GetByID", mock.Anything, "", "p1").Return(&domain.Contact{ID: "p1", FirstName: "Ana", LastName: "Gomez"}, nil)
+	repo.On("GetByID", mock.Anything, "", "c1").Return(&domain.Contact{ID: "c1", LegalName: "Flock S.A.S."}, nil)
+	repo.On("SaveRelationship", mock.Anything, rel).Return(nil)
+
+	require.NoError(t, svc.CreateRelationship(context.Background(), rel))
+	assert.NotEmpty(t, rel.ID)
+	assert.False(t, rel.CreatedAt.IsZero())
+}
+
+// TestCreateRelationship_PersonAsCompany ensures people cannot be the object of a relationship.
+func TestCreateRelationship_PersonAsCompany(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+
+	repo.On("GetByID", mock.Anything, "", "p1").Return(&domain.Contact{ID: "p1", FirstName: "Ana", LastName: "Gomez"}, nil)
+	repo.On("GetByID", mock.Anything, "", "p2").Return(&domain.Contact{ID: "p2", FirstName: "Luis", LastName: "Diaz"}, nil)
+
+	err := svc.CreateRelationship(context.Background(), &domain.Relationship{Type: domain.RelationEmployeeOf, FromID: "p1", ToID: "p2"})
+	assert.ErrorIs(t, err, domain.ErrInvalidRelationship)
+}
+
+// TestCreateRelationship_MissingContact ensures both contacts must exist.
+func TestCreateRelationship_MissingContact(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+
+	repo.On("GetByID", mock.Anything, "", "p1").Return(&domain.Contact{ID: "p1", FirstName: "Ana", LastName: "Gomez"}, nil)
+	repo.On("GetByID", mock.Anything, "", "missing").Return(nil, domain.ErrContactNotFound)
+
+	err := svc.CreateRelationship(context.Background(), &domain.Relationship{Type: domain.RelationEmployeeOf, FromID: "p1", ToID: "missing"})
+	assert.ErrorIs(t, err, domain.ErrContactNotFound)
+}
+
+// TestListPeople_ActiveOnly ensures only people of the company holding on the given day are returned.
+func TestListPeople_ActiveOnly(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	ended := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	current := &domain.Relationship{ID: "r1", Type: domain.RelationEmployeeOf, FromID: "p1", ToID: "c1"}
+	person := &domain.Contact{ID: "p1", FirstName: "Ana", LastName: "Gomez"}
+
+	repo.On("GetByID", mock.Anything, "", "c1").Return(&domain.Contact{ID: "c1", LegalName: "Flock S.A.S."}, nil)
+	repo.On("ListRelationships", mock.Anything, "c1").Return([]*domain.Relationship{
+		current,
+		{ID: "r2", Type: domain.RelationEmployeeOf, FromID: "p2", ToID: "c1", ValidUntil: &ended},
+		{ID: "r3", Type: domain.RelationParentCompanyOf, FromID: "c1", ToID: "c2"},
+	}, nil)
+	repo.On("List", mock.Anything, domain.ContactFilter{IDs: []string{"p1"}}).Return([]*domain.Contact{person}, nil)
+
+	got, err := svc.ListPeople(context.Background(), "c1", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.RelatedContact{{Relationship: current, Contact: person}}, got)
+}
+
+// TestListCompanies_Person ensures the employers of a person are returned.
+func TestListCompanies_Person(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	rel := &domain.Relationship{ID: "r1", Type: domain.RelationBillingContactFor, FromID: "p1", ToID: "c1"}
+	company := &domain.Contact{ID: "c1", LegalName: "Flock S.A.S."}
+
+	repo.On("GetByID", mock.Anything, "", "p1").Return(&domain.Contact{ID: "p1"}, nil)
+	repo.On("ListRelationships", mock.Anything, "p1").Return([]*domain.Relationship{rel}, nil)
+	repo.On("List", mock.Anything, domain.ContactFilter{IDs: []string{"c1"}}).Return([]*domain.Contact{company}, nil)
+
+	got, err := svc.ListCompanies(context.Background(), "p1", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.RelatedContact{{Relationship: rel, Contact: company}}, got)
+}
+
+// TestDeleteRelationship_ContactOfAnotherTenant ensures relationships of contacts
+// outside the tenant in ctx cannot be removed.
+func TestDeleteRelationship_ContactOfAnotherTenant(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+
+	repo.On("GetByID", mock.Anything, "acme", "c1").Return(nil, domain.ErrContactNotFound)
+
+	err := svc.DeleteRelationship(reqctx.WithTenant(context.Background(), "acme"), "c1", "r1")
+	assert.ErrorIs(t, err, domain.ErrContactNotFound)
+	repo.AssertNotCalled(t, "DeleteRelationship", mock.Anything, mock.Anything, mock.Anything)
+}
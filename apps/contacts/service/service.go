@@ -1,7 +1,11 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"github.com/flockstore/mannaiah-backend/common/database"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
 	"github.com/flockstore/mannaiah-backend/common/util"
 	"time"
 
@@ -12,54 +16,79 @@ import (
 // contactService provides the business logic for managing contacts.
 type contactService struct {
 	repo domain.ContactRepository
+	tx   database.Transactor
 }
 
+// writeTx isolates read-then-write use cases so concurrent requests cannot
+// interleave between the check and the write; conflicts are retried.
+var writeTx = database.TxOptions{Isolation: database.IsolationSerializable}
+
 // NewContactService creates a new instance of ContactService.
-func NewContactService(repo domain.ContactRepository) domain.ContactService {
-	return &contactService{repo: repo}
+// Multi-statement use cases run as a single unit of work through tx.
+func NewContactService(repo domain.ContactRepository, tx database.Transactor) domain.ContactService {
+	return &contactService{repo: repo, tx: tx}
 }
 
-// Create creates a new contact, generating the ID and timestamps.
-func (s *contactService) Create(c *domain.Contact) error {
+// Create creates a new contact for the tenant in ctx, generating the ID and timestamps.
+func (s *contactService) Create(ctx context.Context, c *domain.Contact) error {
+	c.Tenant = reqctx.Tenant(ctx)
 
-	// Validates if exists combination.
-	existing, err := s.repo.GetByDocument(c.DocumentType, c.DocumentNumber)
-	if err != nil && !errors.Is(err, domain.ErrContactNotFound) {
-		return err
-	}
-	if existing != nil {
-		return domain.ErrDuplicateDocument
-	}
+	err := s.tx.WithTx(ctx, writeTx, func(ctx context.Context) error {
+
+		// Validates if exists combination. The unique index on active documents
+		// is the source of truth; this check only avoids a doomed insert.
+		existing, err := s.repo.GetByDocument(ctx, c.Tenant, c.DocumentType, c.DocumentNumber)
+		if err != nil && !errors.Is(err, domain.ErrContactNotFound) {
+			return err
+		}
+		if existing != nil {
+			return domain.ErrDuplicateDocument
+		}
+
+		// Validates legal name and first/last name xor condition (Can not have both)
+		if err := domain.ValidateNames(c.LegalName, c.FirstName, c.LastName); err != nil {
+			return err
+		}
 
-	// Validates legal name and first/last name xor condition (Can not have both)
-	if err := domain.ValidateNames(c.LegalName, c.FirstName, c.LastName); err != nil {
+		if err := s.validateCustomFields(ctx, c); err != nil {
+			return err
+		}
+
+		c.ID = uuid.NewString()
+		c.CreatedAt = time.Now()
+		c.UpdatedAt = c.CreatedAt
+		return s.repo.Save(ctx, c)
+	})
+	if err != nil {
 		return err
 	}
 
-	c.ID = uuid.NewString()
-	c.CreatedAt = time.Now()
-	c.UpdatedAt = c.CreatedAt
-	return s.repo.Save(c)
+	logger.FromContext(ctx).Debugw("contact created", "contactId", c.ID)
+	return nil
 
 }
 
-// Get retrieves a contact by its ID.
-func (s *contactService) Get(id string) (*domain.Contact, error) {
-	return s.repo.GetByID(id)
+// Get retrieves a contact of the tenant in ctx by its ID.
+func (s *contactService) Get(ctx context.Context, id string) (*domain.Contact, error) {
+	return s.repo.GetByID(ctx, reqctx.Tenant(ctx), id)
 }
 
-// Delete removes a contact by its ID.
-func (s *contactService) Delete(id string) error {
-	return s.repo.Delete(id)
+// Delete removes a contact of the tenant in ctx by its ID.
+func (s *contactService) Delete(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, reqctx.Tenant(ctx), id)
 }
 
-// List retrieves all contacts.
-func (s *contactService) List() ([]*domain.Contact, error) {
-	return s.repo.List()
+// List retrieves the contacts of the tenant in ctx matching filter.
+func (s *contactService) List(ctx context.Context, filter domain.ContactFilter) ([]*domain.Contact, error) {
+	filter, err := s.resolveFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, filter)
 }
 
 // Update applies a patch to a contact and updates its timestamp.
-func (s *contactService) Update(id string, patch *domain.ContactPatch) (*domain.Contact, error) {
+func (s *contactService) Update(ctx context.Context, id string, patch *domain.ContactPatch) (*domain.Contact, error) {
 
 	// Check if any of the value exists (Prevents null pointer) and performs validation if
 	// any update candidate is present.
@@ -74,19 +103,32 @@ func (s *contactService) Update(id string, patch *domain.ContactPatch) (*domain.
 		}
 	}
 
-	existing, err := s.repo.GetByID(id)
-	if err != nil {
-		return nil, err
-	}
-	if existing == nil {
-		return nil, domain.ErrContactNotFound
-	}
+	var updated *domain.Contact
+	err := s.tx.WithTx(ctx, writeTx, func(ctx context.Context) error {
+		existing, err := s.repo.GetByID(ctx, reqctx.Tenant(ctx), id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return domain.ErrContactNotFound
+		}
 
-	domain.ApplyPatch(existing, patch)
-	existing.UpdatedAt = time.Now()
+		domain.ApplyPatch(existing, patch)
+		if patch.CustomFields != nil {
+			if err := s.validateCustomFields(ctx, existing); err != nil {
+				return err
+			}
+		}
+		existing.UpdatedAt = time.Now()
 
-	if err := s.repo.Save(existing); err != nil {
+		if err := s.repo.Save(ctx, existing); err != nil {
+			return err
+		}
+		updated = existing
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
-	return existing, nil
+	return updated, nil
 }
@@ -1,18 +1,32 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"github.com/flockstore/mannaiah-backend/common/database"
+	commonmocks "github.com/flockstore/mannaiah-backend/common/mocks"
 	"github.com/flockstore/mannaiah-backend/common/util"
 	"testing"
 	"time"
 
 	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
 	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// newTransactor returns a Transactor that runs units of work inline.
+func newTransactor(t *testing.T) *commonmocks.Transactor {
+	tx := commonmocks.NewTransactor(t)
+	tx.On("WithTx", mock.Anything, mock.Anything, mock.Anything).Maybe().
+		Return(func(ctx context.Context, _ database.TxOptions, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	return tx
+}
+
 // newValidContact returns a natural person with first and last name.
 func newValidContact() *domain.Contact {
 	return &domain.Contact{
@@ -35,13 +49,14 @@ func newLegalEntity() *domain.Contact {
 // TestCreate_Success ensures a valid contact is saved correctly.
 func TestCreate_Success(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 	contact := newValidContact()
 
-	repo.On("GetByDocument", contact.DocumentType, contact.DocumentNumber).Return(nil, domain.ErrContactNotFound)
-	repo.On("Save", mock.AnythingOfType("*domain.Contact")).Return(nil)
+	repo.On("GetByDocument", mock.Anything, "", contact.DocumentType, contact.DocumentNumber).Return(nil, domain.ErrContactNotFound)
+	repo.On("ListFieldDefinitions", mock.Anything, "").Return(nil, nil)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*domain.Contact")).Return(nil)
 
-	err := svc.Create(contact)
+	err := svc.Create(context.Background(), contact)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, contact.ID)
 	assert.WithinDuration(t, time.Now(), contact.CreatedAt, time.Second)
@@ -51,77 +66,78 @@ func TestCreate_Success(t *testing.T) {
 // TestCreate_Duplicate checks rejection of duplicate document numbers.
 func TestCreate_Duplicate(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 	contact := newValidContact()
 
-	repo.On("GetByDocument", contact.DocumentType, contact.DocumentNumber).Return(&domain.Contact{}, nil)
+	repo.On("GetByDocument", mock.Anything, "", contact.DocumentType, contact.DocumentNumber).Return(&domain.Contact{}, nil)
 
-	err := svc.Create(contact)
+	err := svc.Create(context.Background(), contact)
 	assert.ErrorIs(t, err, domain.ErrDuplicateDocument)
 }
 
 // TestCreate_InvalidNameCombination checks if legal + natural name fails.
 func TestCreate_InvalidNameCombination(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 	contact := newValidContact()
 	contact.LegalName = "Empresa S.A."
 
-	repo.On("GetByDocument", contact.DocumentType, contact.DocumentNumber).Return(nil, domain.ErrContactNotFound)
+	repo.On("GetByDocument", mock.Anything, "", contact.DocumentType, contact.DocumentNumber).Return(nil, domain.ErrContactNotFound)
 
-	err := svc.Create(contact)
+	err := svc.Create(context.Background(), contact)
 	assert.ErrorIs(t, err, domain.ErrInvalidNameCombination)
 }
 
 // TestCreate_MissingName checks that missing name values are rejected.
 func TestCreate_MissingName(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 	contact := &domain.Contact{
 		DocumentType:   "CC",
 		DocumentNumber: "999",
 	}
 
-	repo.On("GetByDocument", contact.DocumentType, contact.DocumentNumber).Return(nil, domain.ErrContactNotFound)
+	repo.On("GetByDocument", mock.Anything, "", contact.DocumentType, contact.DocumentNumber).Return(nil, domain.ErrContactNotFound)
 
-	err := svc.Create(contact)
+	err := svc.Create(context.Background(), contact)
 	assert.ErrorIs(t, err, domain.ErrMissingName)
 }
 
-// TestGet_ReturnsContact validates fetching a contact by ID.
+// TestGet_ReturnsContact validates fetching a contact of the tenant in ctx by ID.
 func TestGet_ReturnsContact(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 	expected := newValidContact()
 	expected.ID = "abc"
 
-	repo.On("GetByID", "abc").Return(expected, nil)
+	repo.On("GetByID", mock.Anything, "acme", "abc").Return(expected, nil)
 
-	result, err := svc.Get("abc")
+	result, err := svc.Get(reqctx.WithTenant(context.Background(), "acme"), "abc")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, result)
 }
 
-// TestDelete_CallsRepo ensures delete by ID delegates to repo.
+// TestDelete_CallsRepo ensures delete by ID delegates to repo within the tenant in ctx.
 func TestDelete_CallsRepo(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 
-	repo.On("Delete", "abc").Return(nil)
+	repo.On("Delete", mock.Anything, "acme", "abc").Return(nil)
 
-	err := svc.Delete("abc")
+	err := svc.Delete(reqctx.WithTenant(context.Background(), "acme"), "abc")
 	assert.NoError(t, err)
 }
 
 // TestList_ReturnsContacts checks all contacts are returned.
 func TestList_ReturnsContacts(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 	expected := []*domain.Contact{newValidContact()}
 
-	repo.On("List").Return(expected, nil)
+	filter := domain.ContactFilter{Tags: []string{"vip"}, Match: domain.MatchAll}
+	repo.On("List", mock.Anything, filter).Return(expected, nil)
 
-	result, err := svc.List()
+	result, err := svc.List(context.Background(), filter)
 	assert.NoError(t, err)
 	assert.Equal(t, expected, result)
 }
@@ -129,7 +145,7 @@ func TestList_ReturnsContacts(t *testing.T) {
 // TestUpdate_Success checks if valid patch updates the contact.
 func TestUpdate_Success(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 	id := "abc"
 	existing := newValidContact()
 	existing.ID = id
@@ -140,10 +156,10 @@ func TestUpdate_Success(t *testing.T) {
 		Phone: &ph,
 	}
 
-	repo.On("GetByID", id).Return(existing, nil)
-	repo.On("Save", mock.AnythingOfType("*domain.Contact")).Return(nil)
+	repo.On("GetByID", mock.Anything, "", id).Return(existing, nil)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*domain.Contact")).Return(nil)
 
-	updated, err := svc.Update(id, patch)
+	updated, err := svc.Update(context.Background(), id, patch)
 	assert.NoError(t, err)
 	assert.Equal(t, "5551234", updated.Phone)
 }
@@ -151,15 +167,16 @@ func TestUpdate_Success(t *testing.T) {
 // TestCreate_LegalEntity_Success checks if legal entity is created correctly.
 func TestCreate_LegalEntity_Success(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 	contact := newLegalEntity()
 
-	repo.On("GetByDocument", contact.DocumentType, contact.DocumentNumber).
+	repo.On("GetByDocument", mock.Anything, "", contact.DocumentType, contact.DocumentNumber).
 		Return(nil, domain.ErrContactNotFound)
-	repo.On("Save", mock.AnythingOfType("*domain.Contact")).
+	repo.On("ListFieldDefinitions", mock.Anything, "").Return(nil, nil)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*domain.Contact")).
 		Return(nil)
 
-	err := svc.Create(contact)
+	err := svc.Create(context.Background(), contact)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, contact.ID)
 	assert.WithinDuration(t, time.Now(), contact.CreatedAt, time.Second)
@@ -169,7 +186,7 @@ func TestCreate_LegalEntity_Success(t *testing.T) {
 // TestUpdate_InvalidCombination checks invalid patch combination.
 func TestUpdate_InvalidCombination(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 	id := "abc"
 	existing := newValidContact()
 	existing.ID = id
@@ -179,7 +196,7 @@ func TestUpdate_InvalidCombination(t *testing.T) {
 		FirstName: util.Pointer("Carlos"),
 	}
 
-	_, err := svc.Update(id, patch)
+	_, err := svc.Update(context.Background(), id, patch)
 	fmt.Println(err)
 	assert.ErrorIs(t, err, domain.ErrInvalidNameCombination)
 }
@@ -187,31 +204,31 @@ func TestUpdate_InvalidCombination(t *testing.T) {
 // TestUpdate_NotFound checks that nil entity without error triggers ErrContactNotFound.
 func TestUpdate_NotFound(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 
-	repo.On("GetByID", "abc").Return(nil, nil)
+	repo.On("GetByID", mock.Anything, "", "abc").Return(nil, nil)
 
-	_, err := svc.Update("abc", &domain.ContactPatch{})
+	_, err := svc.Update(context.Background(), "abc", &domain.ContactPatch{})
 	assert.ErrorIs(t, err, domain.ErrContactNotFound)
 }
 
 // TestCreate_UnexpectedRepoError ensures repo errors (not ContactNotFound) are propagated.
 func TestCreate_UnexpectedRepoError(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 	contact := newValidContact()
 
-	repo.On("GetByDocument", contact.DocumentType, contact.DocumentNumber).
+	repo.On("GetByDocument", mock.Anything, "", contact.DocumentType, contact.DocumentNumber).
 		Return(nil, assert.AnError)
 
-	err := svc.Create(contact)
+	err := svc.Create(context.Background(), contact)
 	assert.ErrorIs(t, err, assert.AnError)
 }
 
 // TestUpdate_SaveFails checks if repo.Save errors are propagated.
 func TestUpdate_SaveFails(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 
 	id := "abc"
 	existing := newValidContact()
@@ -220,22 +237,51 @@ func TestUpdate_SaveFails(t *testing.T) {
 		Email: util.Pointer("new@example.com"),
 	}
 
-	repo.On("GetByID", id).Return(existing, nil)
-	repo.On("Save", mock.AnythingOfType("*domain.Contact")).Return(assert.AnError)
+	repo.On("GetByID", mock.Anything, "", id).Return(existing, nil)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*domain.Contact")).Return(assert.AnError)
 
-	_, err := svc.Update(id, patch)
+	_, err := svc.Update(context.Background(), id, patch)
 	assert.ErrorIs(t, err, assert.AnError)
 }
 
 // TestUpdate_GetByIDError returns early if repository.GetByID fails.
 func TestUpdate_GetByIDError(t *testing.T) {
 	repo := mocks.NewContactRepository(t)
-	svc := NewContactService(repo)
+	svc := NewContactService(repo, newTransactor(t))
 
 	expectedErr := errors.New("db unavailable")
 
-	repo.On("GetByID", "abc").Return(nil, expectedErr)
+	repo.On("GetByID", mock.Anything, "", "abc").Return(nil, expectedErr)
 
-	_, err := svc.Update("abc", &domain.ContactPatch{})
+	_, err := svc.Update(context.Background(), "abc", &domain.ContactPatch{})
 	assert.ErrorIs(t, err, expectedErr)
 }
+
+// TestCreate_RunsInSerializableTransaction ensures the duplicate check and save share one unit of work.
+func TestCreate_RunsInSerializableTransaction(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	tx := commonmocks.NewTransactor(t)
+	svc := NewContactService(repo, tx)
+	contact := newValidContact()
+
+	tx.On("WithTx", mock.Anything, database.TxOptions{Isolation: database.IsolationSerializable}, mock.Anything).
+		Return(assert.AnError)
+
+	err := svc.Create(context.Background(), contact)
+	assert.ErrorIs(t, err, assert.AnError)
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+// TestCreate_LostRaceReportsDuplicate ensures a unique violation caught by the repository surfaces as a duplicate.
+func TestCreate_LostRaceReportsDuplicate(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	contact := newValidContact()
+
+	repo.On("GetByDocument", mock.Anything, "", contact.DocumentType, contact.DocumentNumber).Return(nil, domain.ErrContactNotFound)
+	repo.On("ListFieldDefinitions", mock.Anything, "").Return(nil, nil)
+	repo.On("Save", mock.Anything, mock.AnythingOfType("*domain.Contact")).Return(domain.ErrDuplicateDocument)
+
+	err := svc.Create(context.Background(), contact)
+	assert.ErrorIs(t, err, domain.ErrDuplicateDocument)
+}
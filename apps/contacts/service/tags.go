@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/google/uuid"
+)
+
+// CreateTag adds a tag to the catalog, generating the ID and timestamps.
+func (s *contactService) CreateTag(ctx context.Context, t *domain.Tag) error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return domain.ErrInvalidTagName
+	}
+
+	t.ID = uuid.NewString()
+	t.Tenant = reqctx.Tenant(ctx)
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = t.CreatedAt
+	if err := s.repo.SaveTag(ctx, t); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Debugw("tag created", "tagId", t.ID)
+	return nil
+}
+
+// ListTags returns the tag catalog of the request tenant.
+func (s *contactService) ListTags(ctx context.Context) ([]*domain.Tag, error) {
+	return s.repo.ListTags(ctx, reqctx.Tenant(ctx))
+}
+
+// DeleteTag removes a tag of the request tenant from the catalog and from every contact.
+func (s *contactService) DeleteTag(ctx context.Context, id string) error {
+	return s.repo.DeleteTag(ctx, reqctx.Tenant(ctx), id)
+}
+
+// TagContact links the named tags to a contact and returns the updated contact.
+func (s *contactService) TagContact(ctx context.Context, id string, tags []string) (*domain.Contact, error) {
+	return s.changeContactTags(ctx, id, tags, s.repo.AddTags)
+}
+
+// UntagContact unlinks the named tags from a contact and returns the updated contact.
+func (s *contactService) UntagContact(ctx context.Context, id string, tags []string) (*domain.Contact, error) {
+	return s.changeContactTags(ctx, id, tags, s.repo.RemoveTags)
+}
+
+// BulkTag links the named tags to every contact matching filter.
+func (s *contactService) BulkTag(ctx context.Context, filter domain.ContactFilter, tags []string) (int, error) {
+	return s.changeTags(ctx, filter, tags, s.repo.AddTags)
+}
+
+// BulkUntag unlinks the named tags from every contact matching filter.
+func (s *contactService) BulkUntag(ctx context.Context, filter domain.ContactFilter, tags []string) (int, error) {
+	return s.changeTags(ctx, filter, tags, s.repo.RemoveTags)
+}
+
+// tagChange is a repository operation linking or unlinking tags.
+type tagChange func(ctx context.Context, filter domain.ContactFilter, tagIDs []string) (int, error)
+
+// changeContactTags applies change to a single contact, which must exist.
+func (s *contactService) changeContactTags(ctx context.Context, id string, tags []string, change tagChange) (*domain.Contact, error) {
+	var updated *domain.Contact
+	err := s.tx.WithTx(ctx, writeTx, func(ctx context.Context) error {
+		if _, err := s.repo.GetByID(ctx, reqctx.Tenant(ctx), id); err != nil {
+			return err
+		}
+
+		tagIDs, err := s.resolveTags(ctx, tags)
+		if err != nil {
+			return err
+		}
+		if _, err := change(ctx, domain.ContactFilter{Tenant: reqctx.Tenant(ctx), IDs: []string{id}}, tagIDs); err != nil {
+			return err
+		}
+
+		updated, err = s.repo.GetByID(ctx, reqctx.Tenant(ctx), id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// changeTags applies change to every contact matching filter. An empty filter
+// is rejected so a missing criterion cannot touch every contact.
+func (s *contactService) changeTags(ctx context.Context, filter domain.ContactFilter, tags []string, change tagChange) (int, error) {
+	if filter.Empty() {
+		return 0, domain.ErrEmptyFilter
+	}
+
+	var affected int
+	err := s.tx.WithTx(ctx, writeTx, func(ctx context.Context) error {
+		filter, err := s.resolveFilter(ctx, filter)
+		if err != nil {
+			return err
+		}
+		tagIDs, err := s.resolveTags(ctx, tags)
+		if err != nil {
+			return err
+		}
+		affected, err = change(ctx, filter, tagIDs)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	logger.FromContext(ctx).Debugw("contacts tags changed", "tags", tags, "affected", affected)
+	return affected, nil
+}
+
+// resolveTags maps tag names to catalog IDs of the request tenant. Unknown names fail with
+// domain.ErrTagNotFound listing them.
+func (s *contactService) resolveTags(ctx context.Context, names []string) ([]string, error) {
+	names = domain.NormalizeTagNames(names)
+	if len(names) == 0 {
+		return nil, domain.ErrInvalidTagName
+	}
+
+	found, err := s.repo.GetTagsByName(ctx, reqctx.Tenant(ctx), names)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(found))
+	known := make(map[string]bool, len(found))
+	for _, t := range found {
+		ids = append(ids, t.ID)
+		known[domain.NormalizeTagName(t.Name)] = true
+	}
+
+	var missing []string
+	for _, name := range names {
+		if !known[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, domain.ErrTagNotFound.WithDetails(map[string][]string{"tags": missing})
+	}
+	return ids, nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/apps/contacts/domain"
+	"github.com/flockstore/mannaiah-backend/apps/contacts/mocks"
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCreateTag_Success ensures tags get an ID, the request tenant, timestamps and a trimmed name.
+func TestCreateTag_Success(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	tag := &domain.Tag{Name: "  VIP ", Color: "#ff8800"}
+
+	repo.On("SaveTag", mock.Anything, tag).Return(nil)
+
+	require.NoError(t, svc.CreateTag(reqctx.WithTenant(context.Background(), "acme"), tag))
+	assert.NotEmpty(t, tag.ID)
+	assert.Equal(t, "acme", tag.Tenant)
+	assert.Equal(t, "VIP", tag.Name)
+	assert.False(t, tag.CreatedAt.IsZero())
+}
+
+// TestCreateTag_BlankName ensures blank names are rejected before hitting the repository.
+func TestCreateTag_BlankName(t *testing.T) {
+	svc := NewContactService(mocks.NewContactRepository(t), newTransactor(t))
+
+	err := svc.CreateTag(context.Background(), &domain.Tag{Name: " "})
+	assert.ErrorIs(t, err, domain.ErrInvalidTagName)
+}
+
+// TestTagContact_Success ensures tags are resolved by name and the refreshed contact is returned.
+func TestTagContact_Success(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	tagged := &domain.Contact{ID: "c1", Tags: []domain.Tag{{ID: "t1", Name: "VIP"}}}
+
+	repo.On("GetByID", mock.Anything, "", "c1").Return(&domain.Contact{ID: "c1"}, nil).Once()
+	repo.On("GetTagsByName", mock.Anything, "", []string{"vip"}).Return([]*domain.Tag{{ID: "t1", Name: "VIP"}}, nil)
+	repo.On("AddTags", mock.Anything, domain.ContactFilter{IDs: []string{"c1"}}, []string{"t1"}).Return(1, nil)
+	repo.On("GetByID", mock.Anything, "", "c1").Return(tagged, nil).Once()
+
+	got, err := svc.TagContact(context.Background(), "c1", []string{"VIP", "vip "})
+	require.NoError(t, err)
+	assert.Equal(t, tagged, got)
+}
+
+// TestTagContact_NotFound ensures missing contacts are reported without touching tags.
+func TestTagContact_NotFound(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+
+	repo.On("GetByID", mock.Anything, "", "missing").Return(nil, domain.ErrContactNotFound)
+
+	_, err := svc.TagContact(context.Background(), "missing", []string{"VIP"})
+	assert.ErrorIs(t, err, domain.ErrContactNotFound)
+}
+
+// TestUntagContact_UnknownTag ensures unknown tag names are listed in the error details.
+func TestUntagContact_UnknownTag(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+
+	repo.On("GetByID", mock.Anything, "", "c1").Return(&domain.Contact{ID: "c1"}, nil)
+	repo.On("GetTagsByName", mock.Anything, "", []string{"vip", "gold"}).Return([]*domain.Tag{{ID: "t1", Name: "VIP"}}, nil)
+
+	_, err := svc.UntagContact(context.Background(), "c1", []string{"VIP", "Gold"})
+	require.ErrorIs(t, err, domain.ErrTagNotFound)
+	assert.Equal(t, map[string][]string{"tags": {"gold"}}, apperrors.From(err).Details)
+}
+
+// TestBulkTag_Success ensures bulk operations return the number of affected links.
+func TestBulkTag_Success(t *testing.T) {
+	repo := mocks.NewContactRepository(t)
+	svc := NewContactService(repo, newTransactor(t))
+	filter := domain.ContactFilter{Tags: []string{"wholesale"}}
+
+	repo.On("GetTagsByName", mock.Anything, "", []string{"black friday 2025"}).
+		Return([]*domain.Tag{{ID: "t2", Name: "Black Friday 2025"}}, nil)
+	repo.On("AddTags", mock.Anything, filter, []string{"t2"}).Return(7, nil)
+
+	affected, err := svc.BulkTag(context.Background(), filter, []string{"Black Friday 2025"})
+	require.NoError(t, err)
+	assert.Equal(t, 7, affected)
+}
+
+// TestBulkUntag_EmptyFilter ensures bulk operations never apply to every contact implicitly.
+func TestBulkUntag_EmptyFilter(t *testing.T) {
+	svc := NewContactService(mocks.NewContactRepository(t), newTransactor(t))
+
+	_, err := svc.BulkUntag(context.Background(), domain.ContactFilter{Tags: []string{" "}}, []string{"VIP"})
+	assert.ErrorIs(t, err, domain.ErrEmptyFilter)
+}
@@ -0,0 +1,314 @@
+// Package contacts is a Go client for the contacts HTTP API.
+//
+// The client is a standalone module that does not import the service, so
+// API errors decode into this package's own sentinels (ErrContactNotFound,
+// ErrDuplicateDocument, ...) rather than the service domain errors: compare
+// with errors.Is against contacts.Err* values. Each sentinel matches one
+// stable error code; the service test suite drives this client against the
+// real routes to keep codes and payloads in sync.
+package contacts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// basePath is the path under which the contacts API is mounted.
+const basePath = "/contacts"
+
+// Doer sends HTTP requests. *http.Client satisfies it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RequestIDFunc returns the request ID to propagate through X-Request-ID, or
+// an empty string to let the server generate one.
+type RequestIDFunc func(ctx context.Context) string
+
+// RetryPolicy controls how failed idempotent requests are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+
+	// BaseDelay is the delay before the first retry; it doubles on each attempt.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between attempts.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy retries idempotent calls twice with exponential backoff.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 2, BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+// Options configures a Client.
+type Options struct {
+	// BaseURL is the root URL of the contacts service (e.g. "http://contacts:8080").
+	BaseURL string
+
+	// HTTPClient sends the requests. Defaults to a client using Timeout.
+	HTTPClient Doer
+
+	// Timeout bounds each attempt. Zero means no per-attempt timeout.
+	Timeout time.Duration
+
+	// APIKey, when set, is sent in the X-API-Key header to authenticate the caller.
+	APIKey string
+
+	// Tenant, when set, is sent in the X-Tenant-ID header to choose the tenant
+	// of every call. Only keys allowed to act for any tenant may set another
+	// tenant than their own; keys bound to a tenant need not set it.
+	Tenant string
+
+	// RequestID, when set, supplies the X-Request-ID of each call so upstream
+	// request IDs can be propagated.
+	RequestID RequestIDFunc
+
+	// Retry controls retries of idempotent requests (GET, DELETE).
+	Retry RetryPolicy
+}
+
+// Client is a typed client for the contacts HTTP API.
+type Client struct {
+	baseURL   string
+	http      Doer
+	timeout   time.Duration
+	apiKey    string
+	tenant    string
+	requestID RequestIDFunc
+	retry     RetryPolicy
+}
+
+// New creates a Client with the given options.
+func New(opts Options) *Client {
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: opts.Timeout}
+	}
+	return &Client{
+		baseURL:   strings.TrimRight(opts.BaseURL, "/") + basePath,
+		http:      opts.HTTPClient,
+		timeout:   opts.Timeout,
+		apiKey:    opts.APIKey,
+		tenant:    opts.Tenant,
+		requestID: opts.RequestID,
+		retry:     opts.Retry,
+	}
+}
+
+// Create creates a new contact.
+func (c *Client) Create(ctx context.Context, input CreateInput) (*Contact, error) {
+	var out Contact
+	if err := c.do(ctx, http.MethodPost, "", input, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Get retrieves a contact by its ID.
+func (c *Client) Get(ctx context.Context, id string) (*Contact, error) {
+	var out Contact
+	if err := c.do(ctx, http.MethodGet, pathOf(id), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// List retrieves the contacts matching filter; the zero filter returns every contact.
+func (c *Client) List(ctx context.Context, filter ListFilter) ([]Contact, error) {
+	var out []Contact
+	if err := c.do(ctx, http.MethodGet, withQuery("", filter.values()), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Update partially updates a contact and returns its new state.
+func (c *Client) Update(ctx context.Context, id string, patch PatchInput) (*Contact, error) {
+	var out Contact
+	if err := c.do(ctx, http.MethodPatch, pathOf(id), patch, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Delete removes a contact by its ID.
+func (c *Client) Delete(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, pathOf(id), nil, nil)
+}
+
+// pathOf joins segments into an escaped path below the base path.
+func pathOf(segments ...string) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteByte('/')
+		b.WriteString(url.PathEscape(s))
+	}
+	return b.String()
+}
+
+// withQuery appends the encoded query to path, if any.
+func withQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// envelope is the standard success payload.
+type envelope struct {
+	Data      json.RawMessage `json:"data"`
+	RequestID string          `json:"requestId"`
+}
+
+// do sends the request, retrying idempotent calls, and decodes the envelope into out.
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	attempts := 1
+	if method == http.MethodGet || method == http.MethodDelete {
+		attempts += c.retry.MaxRetries
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.backoff(attempt, lastErr)); err != nil {
+				return err
+			}
+		}
+
+		resp, err := c.send(ctx, method, path, payload)
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				return err
+			}
+			continue
+		}
+
+		lastErr = c.decode(resp, out)
+		if lastErr == nil || !retryable(lastErr) {
+			return lastErr
+		}
+	}
+	return lastErr
+}
+
+// send performs a single attempt bounded by the per-attempt timeout.
+func (c *Client) send(ctx context.Context, method, path string, payload []byte) (*http.Response, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.requestID != nil {
+		if id := c.requestID(ctx); id != "" {
+			req.Header.Set("X-Request-ID", id)
+		}
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	if c.tenant != "" {
+		req.Header.Set("X-Tenant-ID", c.tenant)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Buffer the body so the attempt's context can be released safely.
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return resp, nil
+}
+
+// decode reads a response, returning an *Error for non-2xx statuses.
+func (c *Client) decode(resp *http.Response, out any) error {
+	if resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		return fmt.Errorf("decode response data: %w", err)
+	}
+	return nil
+}
+
+// backoff returns the delay before the given retry, honoring Retry-After when present.
+func (c *Client) backoff(attempt int, lastErr error) time.Duration {
+	if e, ok := lastErr.(*Error); ok && e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+
+	delay := c.retry.BaseDelay << (attempt - 1)
+	if c.retry.MaxDelay > 0 && delay > c.retry.MaxDelay {
+		delay = c.retry.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// Full jitter spreads retries from concurrent callers.
+	return time.Duration(rand.Int64N(int64(delay)) + 1)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// parseRetryAfter reads a Retry-After header expressed in seconds.
+func parseRetryAfter(v string) time.Duration {
+	seconds, err := strconv.Atoi(v)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,188 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient serves handler over a local HTTP server and returns a client bound to it.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts Options) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	opts.BaseURL = srv.URL
+	return New(opts)
+}
+
+// writeData answers with the standard success envelope.
+func writeData(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data, "requestId": "req-1"})
+}
+
+// writeError answers with the standard error envelope.
+func writeError(w http.ResponseWriter, status int, reason, message string, details any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"error":     map[string]any{"code": status, "reason": reason, "message": message, "details": details},
+		"requestId": "req-1",
+	})
+}
+
+// validInput returns a complete creation payload.
+func validInput() CreateInput {
+	return CreateInput{
+		DocumentType: "CC", DocumentNumber: "123", FirstName: "Ana", LastName: "Gomez",
+		Address: "Calle 1", CityCode: "05001", Phone: "3001234567", Email: "ana@flock.com",
+	}
+}
+
+// TestCreate_DecodesEnvelope verifies the payload is sent and the created contact decoded from the envelope.
+func TestCreate_DecodesEnvelope(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/contacts", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var in CreateInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		writeData(w, http.StatusCreated, Contact{ID: "new-id", FirstName: in.FirstName})
+	}, Options{})
+
+	contact, err := client.Create(context.Background(), validInput())
+	require.NoError(t, err)
+	assert.Equal(t, "new-id", contact.ID)
+	assert.Equal(t, "Ana", contact.FirstName)
+}
+
+// TestGet_NotFoundMapsToSentinel verifies API errors decode back into sentinels.
+func TestGet_NotFoundMapsToSentinel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, http.StatusNotFound, "contact.not_found", "contact not found", nil)
+	}, Options{})
+
+	_, err := client.Get(context.Background(), "missing")
+	require.ErrorIs(t, err, ErrContactNotFound)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "req-1", apiErr.RequestID)
+}
+
+// TestCreate_ValidationFields verifies field-level validation errors are exposed.
+func TestCreate_ValidationFields(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, http.StatusBadRequest, "request.validation_failed", "validation failed",
+			[]FieldError{{Field: "cityCode", Rule: "len", Param: "5", Message: "cityCode must be exactly 5 characters long"}})
+	}, Options{})
+
+	_, err := client.Create(context.Background(), validInput())
+	require.ErrorIs(t, err, ErrValidationFailed)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Len(t, apiErr.Fields, 1)
+	assert.Equal(t, "cityCode", apiErr.Fields[0].Field)
+}
+
+// TestList_Update_Delete exercises the remaining endpoints.
+func TestList_Update_Delete(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /contacts":
+			if r.URL.Query().Get("email") == "ana@flock.com" {
+				writeData(w, http.StatusOK, []Contact{{ID: "a"}})
+				return
+			}
+			writeData(w, http.StatusOK, []Contact{{ID: "a"}, {ID: "b"}})
+		case "PATCH /contacts/a":
+			var patch PatchInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+			writeData(w, http.StatusOK, Contact{ID: "a", Phone: *patch.Phone})
+		case "DELETE /contacts/a":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}, Options{})
+
+	list, err := client.List(context.Background(), ListFilter{})
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	list, err = client.List(context.Background(), ListFilter{Email: "ana@flock.com"})
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	phone := "3009999999"
+	updated, err := client.Update(context.Background(), "a", PatchInput{Phone: &phone})
+	require.NoError(t, err)
+	assert.Equal(t, phone, updated.Phone)
+
+	require.NoError(t, client.Delete(context.Background(), "a"))
+}
+
+// TestGet_EscapesPathSegments verifies IDs cannot change the requested path.
+func TestGet_EscapesPathSegments(t *testing.T) {
+	var path string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		writeData(w, http.StatusOK, Contact{})
+	}, Options{})
+
+	_, err := client.Get(context.Background(), "../tags?x=1")
+	require.NoError(t, err)
+	assert.Equal(t, "/contacts/..%2Ftags%3Fx=1", path)
+}
+
+// TestHeaders_APIKeyAndRequestID verifies auth and request ID propagation.
+func TestHeaders_APIKeyAndRequestID(t *testing.T) {
+	var header http.Header
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		writeData(w, http.StatusOK, Contact{ID: "a"})
+	}, Options{
+		APIKey:    "secret",
+		RequestID: func(context.Context) string { return "upstream-id" },
+	})
+
+	_, err := client.Get(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", header.Get("X-API-Key"))
+	assert.Equal(t, "upstream-id", header.Get("X-Request-ID"))
+}
+
+// TestRetry_IdempotentOnly verifies GETs are retried on 503 while POSTs are not.
+func TestRetry_IdempotentOnly(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = io.Copy(io.Discard, r.Body)
+		writeError(w, http.StatusServiceUnavailable, "", "unavailable", nil)
+	}, Options{
+		Retry: RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+	})
+
+	_, err := client.Get(context.Background(), "a")
+	require.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+
+	calls.Store(0)
+	_, err = client.Create(context.Background(), validInput())
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,34 @@
+package contacts
+
+import (
+	"context"
+	"net/http"
+)
+
+// GrantConsent records that the contact with the given ID gave consent.
+func (c *Client) GrantConsent(ctx context.Context, contactID string, input ConsentInput) (*ConsentRecord, error) {
+	return c.recordConsent(ctx, pathOf(contactID, "consents", "grant"), input)
+}
+
+// RevokeConsent records that the contact with the given ID withdrew consent.
+func (c *Client) RevokeConsent(ctx context.Context, contactID string, input ConsentInput) (*ConsentRecord, error) {
+	return c.recordConsent(ctx, pathOf(contactID, "consents", "revoke"), input)
+}
+
+// ConsentHistory retrieves every consent record of a contact, oldest first.
+func (c *Client) ConsentHistory(ctx context.Context, contactID string) ([]ConsentRecord, error) {
+	var out []ConsentRecord
+	if err := c.do(ctx, http.MethodGet, pathOf(contactID, "consents"), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// recordConsent posts a consent decision to path.
+func (c *Client) recordConsent(ctx context.Context, path string, input ConsentInput) (*ConsentRecord, error) {
+	var out ConsentRecord
+	if err := c.do(ctx, http.MethodPost, path, input, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,60 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConsents exercises the consent endpoints, the marketing filter and errors.
+func TestConsents(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /contacts/c1/consents/grant", "POST /contacts/c1/consents/revoke":
+			var in ConsentInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			if in.Purpose == PurposeMarketing && in.Channel == "" {
+				writeError(w, http.StatusBadRequest, "consent.invalid", "invalid consent", nil)
+				return
+			}
+			action := ConsentGrant
+			if r.URL.Path == "/contacts/c1/consents/revoke" {
+				action = ConsentRevoke
+			}
+			writeData(w, http.StatusCreated, ConsentRecord{ID: "r1", Purpose: in.Purpose, Channel: in.Channel, Action: action, IP: "203.0.113.7"})
+		case "GET /contacts/c1/consents":
+			writeData(w, http.StatusOK, []ConsentRecord{{ID: "r1", Action: ConsentGrant}, {ID: "r2", Action: ConsentRevoke}})
+		case "GET /contacts":
+			assert.Equal(t, "marketing=email", r.URL.RawQuery)
+			writeData(w, http.StatusOK, []Contact{{ID: "c1", Consents: []ConsentState{{Purpose: PurposeMarketing, Channel: ChannelEmail, Granted: true}}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}, Options{})
+	ctx := context.Background()
+
+	granted, err := client.GrantConsent(ctx, "c1", ConsentInput{Purpose: PurposeMarketing, Channel: ChannelEmail, Source: "web_form", PolicyVersion: "v1"})
+	require.NoError(t, err)
+	assert.Equal(t, ConsentRecord{ID: "r1", Purpose: PurposeMarketing, Channel: ChannelEmail, Action: ConsentGrant, IP: "203.0.113.7"}, *granted)
+
+	revoked, err := client.RevokeConsent(ctx, "c1", ConsentInput{Purpose: PurposeProfiling, Source: "web_form", PolicyVersion: "v1"})
+	require.NoError(t, err)
+	assert.Equal(t, ConsentRevoke, revoked.Action)
+
+	_, err = client.GrantConsent(ctx, "c1", ConsentInput{Purpose: PurposeMarketing, Source: "web_form", PolicyVersion: "v1"})
+	assert.ErrorIs(t, err, ErrInvalidConsent)
+
+	history, err := client.ConsentHistory(ctx, "c1")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, ConsentRevoke, history[1].Action)
+
+	list, err := client.List(ctx, ListFilter{Marketing: ChannelEmail})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.True(t, list[0].Consents[0].Granted)
+}
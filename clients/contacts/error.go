@@ -0,0 +1,138 @@
+package contacts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors matching the stable error codes returned by the API. They
+// mirror the service errors with the same codes but are distinct values.
+var (
+	ErrContactNotFound        = errors.New("contacts: contact not found")
+	ErrDuplicateDocument      = errors.New("contacts: duplicate document number")
+	ErrInvalidNameCombination = errors.New("contacts: invalid name combination")
+	ErrMissingName            = errors.New("contacts: missing required name")
+	ErrInvalidCustomFields    = errors.New("contacts: invalid custom fields")
+	ErrEmptyFilter            = errors.New("contacts: bulk operations require a filter")
+	ErrTagNotFound            = errors.New("contacts: tag not found")
+	ErrDuplicateTag           = errors.New("contacts: duplicate tag name")
+	ErrInvalidTagName         = errors.New("contacts: tag name must not be empty")
+	ErrInvalidFieldDefinition = errors.New("contacts: invalid custom field definition")
+	ErrDuplicateFieldKey      = errors.New("contacts: duplicate custom field key")
+	ErrFieldNotFound          = errors.New("contacts: custom field not found")
+	ErrInvalidRelationship    = errors.New("contacts: invalid relationship")
+	ErrDuplicateRelationship  = errors.New("contacts: duplicate relationship")
+	ErrRelationshipNotFound   = errors.New("contacts: relationship not found")
+	ErrInvalidConsent         = errors.New("contacts: invalid consent")
+	ErrValidationFailed       = errors.New("contacts: validation failed")
+	ErrInvalidBody            = errors.New("contacts: invalid JSON")
+	ErrInvalidQuery           = errors.New("contacts: invalid query parameters")
+	ErrUnauthenticated        = errors.New("contacts: authentication required")
+	ErrTenantForbidden        = errors.New("contacts: tenant not allowed for this API key")
+	ErrRateLimited            = errors.New("contacts: rate limit exceeded")
+)
+
+// sentinels maps stable error codes back to the sentinel errors they represent.
+var sentinels = map[string]error{
+	"contact.not_found":                ErrContactNotFound,
+	"contact.duplicate_document":       ErrDuplicateDocument,
+	"contact.invalid_name_combination": ErrInvalidNameCombination,
+	"contact.missing_name":             ErrMissingName,
+	"contact.invalid_custom_fields":    ErrInvalidCustomFields,
+	"contact.empty_filter":             ErrEmptyFilter,
+	"tag.not_found":                    ErrTagNotFound,
+	"tag.duplicate_name":               ErrDuplicateTag,
+	"tag.invalid_name":                 ErrInvalidTagName,
+	"field.invalid_definition":         ErrInvalidFieldDefinition,
+	"field.duplicate_key":              ErrDuplicateFieldKey,
+	"field.not_found":                  ErrFieldNotFound,
+	"relationship.invalid":             ErrInvalidRelationship,
+	"relationship.duplicate":           ErrDuplicateRelationship,
+	"relationship.not_found":           ErrRelationshipNotFound,
+	"consent.invalid":                  ErrInvalidConsent,
+	"request.validation_failed":        ErrValidationFailed,
+	"request.invalid_body":             ErrInvalidBody,
+	"request.invalid_query":            ErrInvalidQuery,
+	"request.unauthenticated":          ErrUnauthenticated,
+	"request.tenant_forbidden":         ErrTenantForbidden,
+	"request.rate_limited":             ErrRateLimited,
+}
+
+// Error is returned when the API responds with a non-success status.
+// It unwraps to the matching sentinel, so errors.Is(err, ErrContactNotFound) works.
+type Error struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+
+	// Reason is the stable error code (e.g. "contact.not_found").
+	Reason string
+
+	// Message is the public error message.
+	Message string
+
+	// Fields lists field-level validation failures, if any.
+	Fields []FieldError
+
+	// RequestID is the server-side request ID, useful for support.
+	RequestID string
+
+	// RetryAfter is the delay suggested by the server, if any.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("contacts: %d %s: %s (request %s)", e.StatusCode, e.Reason, e.Message, e.RequestID)
+}
+
+// Unwrap returns the sentinel matching Reason, if known.
+func (e *Error) Unwrap() error {
+	return sentinels[e.Reason]
+}
+
+// errorEnvelope is the standard error payload.
+type errorEnvelope struct {
+	Error struct {
+		Reason  string          `json:"reason"`
+		Message string          `json:"message"`
+		Details json.RawMessage `json:"details"`
+	} `json:"error"`
+	RequestID string `json:"requestId"`
+}
+
+// decodeError builds an *Error from an error response envelope.
+func decodeError(resp *http.Response) error {
+	e := &Error{
+		StatusCode: resp.StatusCode,
+		Message:    http.StatusText(resp.StatusCode),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+
+	var body errorEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil {
+		e.Reason = body.Error.Reason
+		e.Message = body.Error.Message
+		e.RequestID = body.RequestID
+
+		// Details only hold field errors for validation and custom field failures.
+		_ = json.Unmarshal(body.Error.Details, &e.Fields)
+	}
+	return e
+}
+
+// retryable reports whether a failed attempt may succeed if repeated.
+func retryable(err error) bool {
+	e, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
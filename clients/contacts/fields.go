@@ -0,0 +1,29 @@
+package contacts
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateFieldDefinition defines a custom field for the tenant of the caller.
+func (c *Client) CreateFieldDefinition(ctx context.Context, input FieldDefinitionInput) (*FieldDefinition, error) {
+	var out FieldDefinition
+	if err := c.do(ctx, http.MethodPost, "/fields", input, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListFieldDefinitions retrieves the custom fields of the tenant of the caller, ordered by key.
+func (c *Client) ListFieldDefinitions(ctx context.Context) ([]FieldDefinition, error) {
+	var out []FieldDefinition
+	if err := c.do(ctx, http.MethodGet, "/fields", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteFieldDefinition removes a custom field together with its values on the tenant's contacts.
+func (c *Client) DeleteFieldDefinition(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, pathOf("fields", id), nil, nil)
+}
@@ -0,0 +1,100 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFieldDefinitions exercises the custom field endpoints and their errors.
+func TestFieldDefinitions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "acme", r.Header.Get("X-Tenant-ID"))
+
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /contacts/fields":
+			var in FieldDefinitionInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			if in.Key == "shoe_size" {
+				writeError(w, http.StatusConflict, "field.duplicate_key", "duplicate custom field key", nil)
+				return
+			}
+			writeData(w, http.StatusCreated, FieldDefinition{ID: "f1", Key: in.Key, Type: in.Type, Rules: in.Rules})
+		case "GET /contacts/fields":
+			writeData(w, http.StatusOK, []FieldDefinition{{ID: "f1", Key: "store", Type: FieldEnum}})
+		case "DELETE /contacts/fields/f1":
+			w.WriteHeader(http.StatusNoContent)
+		case "DELETE /contacts/fields/missing":
+			writeError(w, http.StatusNotFound, "field.not_found", "custom field not found", nil)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}, Options{Tenant: "acme"})
+	ctx := context.Background()
+
+	def, err := client.CreateFieldDefinition(ctx, FieldDefinitionInput{
+		Key: "store", Type: FieldEnum, Rules: FieldRules{Options: []string{"north", "south"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "f1", def.ID)
+	assert.Equal(t, []string{"north", "south"}, def.Rules.Options)
+
+	_, err = client.CreateFieldDefinition(ctx, FieldDefinitionInput{Key: "shoe_size", Type: FieldNumber})
+	assert.ErrorIs(t, err, ErrDuplicateFieldKey)
+
+	defs, err := client.ListFieldDefinitions(ctx)
+	require.NoError(t, err)
+	assert.Len(t, defs, 1)
+
+	require.NoError(t, client.DeleteFieldDefinition(ctx, "f1"))
+	assert.ErrorIs(t, client.DeleteFieldDefinition(ctx, "missing"), ErrFieldNotFound)
+}
+
+// TestCreate_InvalidCustomFields verifies custom fields are sent and their
+// failures decode into field errors.
+func TestCreate_InvalidCustomFields(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var in CreateInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		assert.Equal(t, map[string]any{"shoe_size": 51.0}, in.CustomFields)
+
+		writeError(w, http.StatusBadRequest, "contact.invalid_custom_fields", "invalid custom fields",
+			[]FieldError{{Field: "customFields.shoe_size", Rule: "lte", Param: "50"}})
+	}, Options{})
+
+	input := validInput()
+	input.CustomFields = map[string]any{"shoe_size": 51}
+	_, err := client.Create(context.Background(), input)
+	require.ErrorIs(t, err, ErrInvalidCustomFields)
+
+	var apiErr *Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, []FieldError{{Field: "customFields.shoe_size", Rule: "lte", Param: "50"}}, apiErr.Fields)
+}
+
+// TestList_CustomFieldFilter verifies custom field filters are encoded as cf[key] parameters.
+func TestList_CustomFieldFilter(t *testing.T) {
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		writeData(w, http.StatusOK, []Contact{})
+	}, Options{})
+
+	_, err := client.List(context.Background(), ListFilter{CustomFields: map[string]string{"shoe_size": "42", "store": "north"}})
+	require.NoError(t, err)
+	assert.Equal(t, "cf%5Bshoe_size%5D=42&cf%5Bstore%5D=north", query)
+}
+
+// TestTenantForbidden verifies a tenant the API key is not bound to maps to its sentinel.
+func TestTenantForbidden(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, http.StatusForbidden, "request.tenant_forbidden", "tenant not allowed for this API key", nil)
+	}, Options{Tenant: "globex"})
+
+	_, err := client.List(context.Background(), ListFilter{})
+	assert.ErrorIs(t, err, ErrTenantForbidden)
+}
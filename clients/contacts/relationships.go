@@ -0,0 +1,40 @@
+package contacts
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateRelationship relates the contact with the given ID to input.ContactID.
+func (c *Client) CreateRelationship(ctx context.Context, contactID string, input RelationshipInput) (*Relationship, error) {
+	var out Relationship
+	if err := c.do(ctx, http.MethodPost, pathOf(contactID, "relationships"), input, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteRelationship removes a relationship of a contact.
+func (c *Client) DeleteRelationship(ctx context.Context, contactID, id string) error {
+	return c.do(ctx, http.MethodDelete, pathOf(contactID, "relationships", id), nil, nil)
+}
+
+// ListPeople retrieves the people related to a company.
+func (c *Client) ListPeople(ctx context.Context, companyID string, filter RelatedFilter) ([]RelatedContact, error) {
+	return c.related(ctx, pathOf(companyID, "people"), filter)
+}
+
+// ListCompanies retrieves the companies related to a contact: the employers of
+// a person, or the parent and subsidiaries of a company.
+func (c *Client) ListCompanies(ctx context.Context, contactID string, filter RelatedFilter) ([]RelatedContact, error) {
+	return c.related(ctx, pathOf(contactID, "companies"), filter)
+}
+
+// related lists the contacts related through path.
+func (c *Client) related(ctx context.Context, path string, filter RelatedFilter) ([]RelatedContact, error) {
+	var out []RelatedContact
+	if err := c.do(ctx, http.MethodGet, withQuery(path, filter.values()), nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
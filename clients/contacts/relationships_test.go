@@ -0,0 +1,60 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRelationships exercises the relationship endpoints, their filter and errors.
+func TestRelationships(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /contacts/p1/relationships":
+			var in RelationshipInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			if in.ContactID == "p2" {
+				writeError(w, http.StatusBadRequest, "relationship.invalid", "invalid relationship", nil)
+				return
+			}
+			writeData(w, http.StatusCreated, Relationship{ID: "r1", Type: in.Type, FromID: "p1", ToID: in.ContactID, Role: in.Role})
+		case "DELETE /contacts/p1/relationships/r1":
+			w.WriteHeader(http.StatusNoContent)
+		case "DELETE /contacts/p1/relationships/missing":
+			writeError(w, http.StatusNotFound, "relationship.not_found", "relationship not found", nil)
+		case "GET /contacts/c1/people":
+			assert.Equal(t, "active=true", r.URL.RawQuery)
+			writeData(w, http.StatusOK, []RelatedContact{{Relationship: Relationship{ID: "r1"}, Contact: Contact{ID: "p1"}}})
+		case "GET /contacts/p1/companies":
+			assert.Empty(t, r.URL.RawQuery)
+			writeData(w, http.StatusOK, []RelatedContact{{Relationship: Relationship{ID: "r1"}, Contact: Contact{ID: "c1"}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}, Options{})
+	ctx := context.Background()
+
+	rel, err := client.CreateRelationship(ctx, "p1", RelationshipInput{Type: RelationEmployeeOf, ContactID: "c1", Role: "buyer"})
+	require.NoError(t, err)
+	assert.Equal(t, Relationship{ID: "r1", Type: RelationEmployeeOf, FromID: "p1", ToID: "c1", Role: "buyer"}, *rel)
+
+	_, err = client.CreateRelationship(ctx, "p1", RelationshipInput{Type: RelationEmployeeOf, ContactID: "p2"})
+	assert.ErrorIs(t, err, ErrInvalidRelationship)
+
+	people, err := client.ListPeople(ctx, "c1", RelatedFilter{Active: true})
+	require.NoError(t, err)
+	require.Len(t, people, 1)
+	assert.Equal(t, "p1", people[0].Contact.ID)
+
+	companies, err := client.ListCompanies(ctx, "p1", RelatedFilter{})
+	require.NoError(t, err)
+	require.Len(t, companies, 1)
+	assert.Equal(t, "c1", companies[0].Contact.ID)
+
+	require.NoError(t, client.DeleteRelationship(ctx, "p1", "r1"))
+	assert.ErrorIs(t, client.DeleteRelationship(ctx, "p1", "missing"), ErrRelationshipNotFound)
+}
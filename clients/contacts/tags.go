@@ -0,0 +1,60 @@
+package contacts
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateTag adds a tag to the catalog.
+func (c *Client) CreateTag(ctx context.Context, input TagInput) (*Tag, error) {
+	var out Tag
+	if err := c.do(ctx, http.MethodPost, "/tags", input, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListTags retrieves the tag catalog ordered by name.
+func (c *Client) ListTags(ctx context.Context) ([]Tag, error) {
+	var out []Tag
+	if err := c.do(ctx, http.MethodGet, "/tags", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteTag removes a tag from the catalog and from every contact.
+func (c *Client) DeleteTag(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, pathOf("tags", id), nil, nil)
+}
+
+// TagContact links the named tags to a contact and returns its new state.
+func (c *Client) TagContact(ctx context.Context, id string, tags []string) (*Contact, error) {
+	return c.changeTags(ctx, http.MethodPost, id, tags)
+}
+
+// UntagContact unlinks the named tags from a contact and returns its new state.
+func (c *Client) UntagContact(ctx context.Context, id string, tags []string) (*Contact, error) {
+	return c.changeTags(ctx, http.MethodDelete, id, tags)
+}
+
+// changeTags sends the tag names of a contact with method.
+func (c *Client) changeTags(ctx context.Context, method, id string, tags []string) (*Contact, error) {
+	var out Contact
+	if err := c.do(ctx, method, pathOf(id, "tags"), contactTagsInput{Tags: tags}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BulkTag links or unlinks tags on every contact matching the input filter and
+// returns the number of links created or removed.
+func (c *Client) BulkTag(ctx context.Context, input BulkTagInput) (int, error) {
+	var out struct {
+		Affected int `json:"affected"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/tags/bulk", input, &out); err != nil {
+		return 0, err
+	}
+	return out.Affected, nil
+}
@@ -0,0 +1,84 @@
+package contacts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTags exercises the tag catalog and contact tagging endpoints.
+func TestTags(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /contacts/tags":
+			var in TagInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			writeData(w, http.StatusCreated, Tag{ID: "t1", Name: in.Name})
+		case "GET /contacts/tags":
+			writeData(w, http.StatusOK, []Tag{{ID: "t1", Name: "VIP"}})
+		case "DELETE /contacts/tags/t1":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /contacts/c%2F1/tags", "DELETE /contacts/c%2F1/tags":
+			var in contactTagsInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			tags := []Tag{}
+			if r.Method == http.MethodPost {
+				tags = append(tags, Tag{ID: "t1", Name: in.Tags[0]})
+			}
+			writeData(w, http.StatusOK, Contact{ID: "c/1", Tags: tags})
+		case "POST /contacts/tags/bulk":
+			var in BulkTagInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			if len(in.Filter.Tags) == 0 {
+				writeError(w, http.StatusBadRequest, "contact.empty_filter", "bulk operations require a filter", nil)
+				return
+			}
+			writeData(w, http.StatusOK, map[string]int{"affected": 3})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+		}
+	}, Options{})
+	ctx := context.Background()
+
+	tag, err := client.CreateTag(ctx, TagInput{Name: "VIP"})
+	require.NoError(t, err)
+	assert.Equal(t, "t1", tag.ID)
+
+	tags, err := client.ListTags(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tags, 1)
+
+	tagged, err := client.TagContact(ctx, "c/1", []string{"VIP"})
+	require.NoError(t, err)
+	assert.Equal(t, []Tag{{ID: "t1", Name: "VIP"}}, tagged.Tags)
+
+	untagged, err := client.UntagContact(ctx, "c/1", []string{"VIP"})
+	require.NoError(t, err)
+	assert.Empty(t, untagged.Tags)
+
+	affected, err := client.BulkTag(ctx, BulkTagInput{Action: BulkAdd, Tags: []string{"VIP"}, Filter: ContactFilter{Tags: []string{"lead"}}})
+	require.NoError(t, err)
+	assert.Equal(t, 3, affected)
+
+	_, err = client.BulkTag(ctx, BulkTagInput{Action: BulkAdd, Tags: []string{"VIP"}})
+	assert.ErrorIs(t, err, ErrEmptyFilter)
+
+	require.NoError(t, client.DeleteTag(ctx, "t1"))
+}
+
+// TestList_TagFilter verifies tag filters are encoded as query parameters.
+func TestList_TagFilter(t *testing.T) {
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		writeData(w, http.StatusOK, []Contact{})
+	}, Options{})
+
+	_, err := client.List(context.Background(), ListFilter{Tags: []string{"vip", "wholesale"}, Match: MatchAll})
+	require.NoError(t, err)
+	assert.Equal(t, "match=all&tags=vip%2Cwholesale", query)
+}
@@ -0,0 +1,280 @@
+package contacts
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Contact is the contact representation returned by the API.
+type Contact struct {
+	ID             string         `json:"id"`             // Unique contact identifier (UUID)
+	DocumentType   string         `json:"documentType"`   // Document type (e.g. "CC")
+	DocumentNumber string         `json:"documentNumber"` // Document number
+	LegalName      string         `json:"legalName"`      // Legal name (for legal entities)
+	FirstName      string         `json:"firstName"`      // First name (for individuals)
+	LastName       string         `json:"lastName"`       // Last name (for individuals)
+	Address        string         `json:"address"`        // Main address
+	AddressExtra   string         `json:"addressExtra"`   // Extra address details
+	CityCode       string         `json:"cityCode"`       // 5-digit city code
+	Phone          string         `json:"phone"`          // Phone number
+	Email          string         `json:"email"`          // Email address
+	CreatedAt      string         `json:"createdAt"`      // ISO 8601 creation timestamp
+	UpdatedAt      string         `json:"updatedAt"`      // ISO 8601 last update timestamp
+	Tags           []Tag          `json:"tags"`           // Tags linked to the contact, ordered by name
+	CustomFields   map[string]any `json:"customFields"`   // Values of the tenant custom fields, by key
+	Consents       []ConsentState `json:"consents"`       // Current consent per purpose and channel
+}
+
+// CreateInput is the payload used to create a contact.
+type CreateInput struct {
+	DocumentType   string         `json:"documentType"`           // Document type (e.g. "CC", "TI")
+	DocumentNumber string         `json:"documentNumber"`         // Unique document identifier
+	LegalName      string         `json:"legalName,omitempty"`    // Legal name (for legal entities)
+	FirstName      string         `json:"firstName,omitempty"`    // First name (for individuals)
+	LastName       string         `json:"lastName,omitempty"`     // Last name (for individuals)
+	Address        string         `json:"address"`                // Main address (mandatory)
+	AddressExtra   string         `json:"addressExtra,omitempty"` // Additional address details
+	CityCode       string         `json:"cityCode"`               // 5-digit city code from catalog
+	Phone          string         `json:"phone"`                  // Minimum 8-digit phone number
+	Email          string         `json:"email"`                  // Valid email address
+	CustomFields   map[string]any `json:"customFields,omitempty"` // Values of the tenant custom fields, by key
+}
+
+// PatchInput is the payload used to partially update a contact. Nil fields are left unchanged.
+type PatchInput struct {
+	LegalName    *string        `json:"legalName,omitempty"`    // Updated legal name
+	FirstName    *string        `json:"firstName,omitempty"`    // Updated first name
+	LastName     *string        `json:"lastName,omitempty"`     // Updated last name
+	Address      *string        `json:"address,omitempty"`      // Updated address
+	AddressExtra *string        `json:"addressExtra,omitempty"` // Updated extra address
+	CityCode     *string        `json:"cityCode,omitempty"`     // Updated city code
+	Phone        *string        `json:"phone,omitempty"`        // Updated phone number
+	Email        *string        `json:"email,omitempty"`        // Updated email address
+	CustomFields map[string]any `json:"customFields,omitempty"` // Merged into existing values; nil values remove a key
+}
+
+// Tag match modes of ListFilter and ContactFilter.
+const (
+	MatchAny = "any" // Contacts carrying at least one of the tags
+	MatchAll = "all" // Contacts carrying every tag
+)
+
+// ListFilter narrows the contacts returned by List. Zero-valued criteria are ignored.
+type ListFilter struct {
+	Tags         []string          // Only contacts carrying these tag names
+	Match        string            // MatchAny (default) or MatchAll
+	Email        string            // Only contacts with this email address, ignoring case
+	Phone        string            // Only contacts with this phone number
+	CustomFields map[string]string // Only contacts holding these exact custom field values, by key
+	Marketing    string            // Only contacts who may receive marketing through this channel (Channel*)
+}
+
+// values encodes the filter as query parameters.
+func (f ListFilter) values() url.Values {
+	q := url.Values{}
+	if len(f.Tags) > 0 {
+		q.Set("tags", strings.Join(f.Tags, ","))
+	}
+	if f.Match != "" {
+		q.Set("match", f.Match)
+	}
+	if f.Email != "" {
+		q.Set("email", f.Email)
+	}
+	if f.Phone != "" {
+		q.Set("phone", f.Phone)
+	}
+	if f.Marketing != "" {
+		q.Set("marketing", f.Marketing)
+	}
+	for key, value := range f.CustomFields {
+		q.Set("cf["+key+"]", value)
+	}
+	return q
+}
+
+// Tag is a catalog tag.
+type Tag struct {
+	ID          string `json:"id"`          // Unique tag identifier (UUID)
+	Name        string `json:"name"`        // Tag name
+	Color       string `json:"color"`       // Hex color
+	Description string `json:"description"` // What the tag is used for
+}
+
+// TagInput is the payload used to create a catalog tag.
+type TagInput struct {
+	Name        string `json:"name"`                  // Unique tag name (e.g. "VIP")
+	Color       string `json:"color,omitempty"`       // Hex color (e.g. "#ff8800")
+	Description string `json:"description,omitempty"` // What the tag is used for
+}
+
+// contactTagsInput lists the tag names to link to or unlink from a contact.
+type contactTagsInput struct {
+	Tags []string `json:"tags"`
+}
+
+// ContactFilter selects the contacts affected by a bulk operation. It must not be empty.
+type ContactFilter struct {
+	IDs          []string       `json:"ids,omitempty"`          // Contact IDs
+	Tags         []string       `json:"tags,omitempty"`         // Tag names the contacts carry
+	Match        string         `json:"match,omitempty"`        // MatchAny (default) or MatchAll
+	CustomFields map[string]any `json:"customFields,omitempty"` // Exact custom field values the contacts hold
+}
+
+// BulkTagInput links or unlinks tags on every contact matching a filter.
+type BulkTagInput struct {
+	Action string        `json:"action"` // BulkAdd or BulkRemove
+	Tags   []string      `json:"tags"`   // Tag names
+	Filter ContactFilter `json:"filter"` // Contacts to change
+}
+
+// Actions of BulkTagInput.
+const (
+	BulkAdd    = "add"    // Link the tags
+	BulkRemove = "remove" // Unlink the tags
+)
+
+// Custom field types of FieldDefinition.
+const (
+	FieldString = "string" // Text, constrained by MinLength, MaxLength and Pattern
+	FieldNumber = "number" // Number, constrained by Min and Max
+	FieldDate   = "date"   // Day as YYYY-MM-DD
+	FieldEnum   = "enum"   // One of Options
+	FieldBool   = "bool"   // true or false
+)
+
+// FieldRules constrains the values of a custom field. Only the rules of the field type are accepted.
+type FieldRules struct {
+	MinLength *int     `json:"minLength,omitempty"` // Minimum characters (string)
+	MaxLength *int     `json:"maxLength,omitempty"` // Maximum characters (string)
+	Pattern   string   `json:"pattern,omitempty"`   // Regular expression values must match (string)
+	Min       *float64 `json:"min,omitempty"`       // Lowest accepted value (number)
+	Max       *float64 `json:"max,omitempty"`       // Highest accepted value (number)
+	Options   []string `json:"options,omitempty"`   // Accepted values (enum)
+}
+
+// FieldDefinition is a custom field the tenant stores on its contacts.
+type FieldDefinition struct {
+	ID        string     `json:"id"`        // Unique field identifier (UUID)
+	Key       string     `json:"key"`       // Snake case key
+	Type      string     `json:"type"`      // Value type
+	Required  bool       `json:"required"`  // Whether every contact needs a value
+	Rules     FieldRules `json:"rules"`     // Validation rules
+	CreatedAt string     `json:"createdAt"` // ISO 8601 creation timestamp
+}
+
+// FieldDefinitionInput is the payload used to define a custom field.
+type FieldDefinitionInput struct {
+	Key      string     `json:"key"`      // Snake case key (e.g. "shoe_size")
+	Type     string     `json:"type"`     // FieldString, FieldNumber, FieldDate, FieldEnum or FieldBool
+	Required bool       `json:"required"` // Whether every contact needs a value
+	Rules    FieldRules `json:"rules"`    // Validation rules
+}
+
+// Consent purposes of ConsentInput.
+const (
+	PurposeMarketing      = "marketing"       // Commercial communications through a channel
+	PurposeDataProcessing = "data_processing" // Processing of personal data
+	PurposeProfiling      = "profiling"       // Profiling and segmentation
+)
+
+// Consent channels of marketing decisions and of ListFilter.Marketing.
+const (
+	ChannelEmail    = "email"    // Email messages
+	ChannelSMS      = "sms"      // SMS messages
+	ChannelWhatsApp = "whatsapp" // WhatsApp messages
+	ChannelPhone    = "phone"    // Phone calls
+)
+
+// Actions of ConsentRecord.
+const (
+	ConsentGrant  = "grant"  // The contact gave consent
+	ConsentRevoke = "revoke" // The contact withdrew consent
+)
+
+// ConsentInput is a consent decision collected from a contact.
+type ConsentInput struct {
+	Purpose       string `json:"purpose"`           // One of the Purpose* values
+	Channel       string `json:"channel,omitempty"` // One of the Channel* values, for marketing only
+	Source        string `json:"source"`            // Where it was collected (e.g. "web_form")
+	PolicyVersion string `json:"policyVersion"`     // Privacy policy version shown
+	IP            string `json:"ip,omitempty"`      // Address it was made from; defaults to the caller
+}
+
+// ConsentRecord is an entry of the consent history of a contact.
+type ConsentRecord struct {
+	ID            string `json:"id"`            // Unique record identifier (UUID)
+	Purpose       string `json:"purpose"`       // What the decision applies to
+	Channel       string `json:"channel"`       // Medium, for marketing only
+	Action        string `json:"action"`        // ConsentGrant or ConsentRevoke
+	Source        string `json:"source"`        // Where it was collected
+	PolicyVersion string `json:"policyVersion"` // Privacy policy version shown
+	IP            string `json:"ip"`            // Address it was made from
+	RecordedAt    string `json:"recordedAt"`    // ISO 8601 timestamp
+}
+
+// ConsentState is the current consent of a contact for a purpose and channel.
+type ConsentState struct {
+	Purpose       string `json:"purpose"`       // What the decision applies to
+	Channel       string `json:"channel"`       // Medium, for marketing only
+	Granted       bool   `json:"granted"`       // Whether consent currently holds
+	PolicyVersion string `json:"policyVersion"` // Policy version of the latest decision
+	UpdatedAt     string `json:"updatedAt"`     // ISO 8601 timestamp of the latest decision
+}
+
+// Relationship types of RelationshipInput.
+const (
+	RelationEmployeeOf            = "employee_of"             // Person employed by a company
+	RelationLegalRepresentativeOf = "legal_representative_of" // Person legally representing a company
+	RelationBillingContactFor     = "billing_contact_for"     // Person receiving the invoices of a company
+	RelationParentCompanyOf       = "parent_company_of"       // Company owning a subsidiary
+)
+
+// Relationship links a person to a company, or a parent company to a subsidiary.
+type Relationship struct {
+	ID         string `json:"id"`                   // Unique relationship identifier (UUID)
+	Type       string `json:"type"`                 // Kind of link
+	FromID     string `json:"fromId"`               // Subject contact: the person, or the parent company
+	ToID       string `json:"toId"`                 // Object contact: the company, or the subsidiary
+	Role       string `json:"role"`                 // Position of the subject
+	ValidFrom  string `json:"validFrom,omitempty"`  // First day, YYYY-MM-DD
+	ValidUntil string `json:"validUntil,omitempty"` // Last day, YYYY-MM-DD
+	CreatedAt  string `json:"createdAt"`            // ISO 8601 creation timestamp
+}
+
+// RelationshipInput is the payload used to relate a contact to another contact.
+type RelationshipInput struct {
+	Type       string `json:"type"`                 // One of the Relation* types
+	ContactID  string `json:"contactId"`            // Related company (or subsidiary)
+	Role       string `json:"role,omitempty"`       // Position (e.g. "purchasing manager")
+	ValidFrom  string `json:"validFrom,omitempty"`  // First day, YYYY-MM-DD
+	ValidUntil string `json:"validUntil,omitempty"` // Last day, YYYY-MM-DD
+}
+
+// RelatedContact is a contact on the other side of a relationship.
+type RelatedContact struct {
+	Relationship Relationship `json:"relationship"` // Link between both contacts
+	Contact      Contact      `json:"contact"`      // Related contact
+}
+
+// RelatedFilter narrows the contacts returned by ListPeople and ListCompanies.
+type RelatedFilter struct {
+	Active bool // Only relationships valid today
+}
+
+// values encodes the filter as query parameters.
+func (f RelatedFilter) values() url.Values {
+	q := url.Values{}
+	if f.Active {
+		q.Set("active", "true")
+	}
+	return q
+}
+
+// FieldError describes a single field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`           // JSON path of the offending field (e.g. "cityCode")
+	Rule    string `json:"rule"`            // Validation rule that failed (e.g. "len")
+	Param   string `json:"param,omitempty"` // Rule parameter, if any (e.g. "5")
+	Message string `json:"message"`         // Human-readable description
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// AnyTenant is the tenant of keys allowed to act on behalf of any tenant,
+// chosen per request.
+const AnyTenant = "*"
+
+// Identity is a caller whose credentials were verified by the transport layer.
+type Identity struct {
+	// Principal names the caller, e.g. the service or integration owning the key.
+	Principal string
+
+	// Tenant is the tenant the key is bound to: empty for the default tenant,
+	// or AnyTenant for keys that choose the tenant per request.
+	Tenant string
+
+	// KeyID identifies the API key used without revealing it, so a principal
+	// holding several keys (e.g. during rotation) can be told apart.
+	KeyID string
+}
+
+// APIKeys verifies API keys against the configured credentials.
+// Keys are held as SHA-256 digests so lookups do not compare secrets directly.
+type APIKeys struct {
+	identities map[[sha256.Size]byte]Identity
+}
+
+// ParseAPIKeys builds APIKeys from entries of the form "principal=key", or
+// "principal@tenant=key" for keys bound to a tenant.
+func ParseAPIKeys(entries []string) (*APIKeys, error) {
+	keys := &APIKeys{identities: make(map[[sha256.Size]byte]Identity, len(entries))}
+
+	for i, entry := range entries {
+		owner, key, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		principal, tenant, scoped := strings.Cut(owner, "@")
+		principal, tenant, key = strings.TrimSpace(principal), strings.TrimSpace(tenant), strings.TrimSpace(key)
+		if !ok || principal == "" || key == "" || (scoped && tenant == "") {
+			return nil, fmt.Errorf("api key %d: expected principal=key or principal@tenant=key", i)
+		}
+
+		digest := sha256.Sum256([]byte(key))
+		if _, dup := keys.identities[digest]; dup {
+			return nil, fmt.Errorf("api key %d: key of %q is already assigned", i, principal)
+		}
+		keys.identities[digest] = Identity{Principal: principal, Tenant: tenant, KeyID: hex.EncodeToString(digest[:6])}
+	}
+
+	return keys, nil
+}
+
+// Enabled reports whether any key is configured. Without keys, callers are
+// not authenticated at all.
+func (k *APIKeys) Enabled() bool {
+	return k != nil && len(k.identities) > 0
+}
+
+// Verify returns the identity owning key.
+func (k *APIKeys) Verify(key string) (Identity, bool) {
+	if !k.Enabled() || key == "" {
+		return Identity{}, false
+	}
+	id, ok := k.identities[sha256.Sum256([]byte(key))]
+	return id, ok
+}
+
+// TenantFor returns the tenant a call of id acts for, given the tenant it
+// requested (empty when none). Keys bound to a tenant may only request their
+// own; AnyTenant keys may request any, and default to the default tenant.
+func (id Identity) TenantFor(requested string) (string, bool) {
+	requested = strings.TrimSpace(requested)
+	switch {
+	case id.Tenant == AnyTenant:
+		return requested, true
+	case requested == "" || requested == id.Tenant:
+		return id.Tenant, true
+	}
+	return "", false
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseAPIKeys verifies keys resolve to the principal owning them.
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys([]string{"storefront=s3cr3t-key", " orders = k=with=equals "})
+	require.NoError(t, err)
+	assert.True(t, keys.Enabled())
+
+	id, ok := keys.Verify("s3cr3t-key")
+	require.True(t, ok)
+	assert.Equal(t, "storefront", id.Principal)
+	assert.Len(t, id.KeyID, 12)
+	assert.NotContains(t, id.KeyID, "s3cr3t")
+
+	id, ok = keys.Verify("k=with=equals")
+	require.True(t, ok)
+	assert.Equal(t, "orders", id.Principal)
+
+	_, ok = keys.Verify("unknown")
+	assert.False(t, ok)
+	_, ok = keys.Verify("")
+	assert.False(t, ok)
+}
+
+// TestParseAPIKeys_Invalid ensures malformed and duplicated entries are rejected.
+func TestParseAPIKeys_Invalid(t *testing.T) {
+	for _, entries := range [][]string{{"no-separator"}, {"=key"}, {"orders="}, {"orders@=key"}, {"a=same", "b=same"}} {
+		_, err := ParseAPIKeys(entries)
+		assert.Error(t, err, entries)
+	}
+}
+
+// TestAPIKeys_Disabled ensures an empty set verifies nothing.
+func TestAPIKeys_Disabled(t *testing.T) {
+	var keys *APIKeys
+	assert.False(t, keys.Enabled())
+
+	keys, err := ParseAPIKeys(nil)
+	require.NoError(t, err)
+	assert.False(t, keys.Enabled())
+	_, ok := keys.Verify("anything")
+	assert.False(t, ok)
+}
+
+// TestIdentity_TenantFor verifies keys bound to a tenant cannot act for
+// another one, while AnyTenant keys choose the tenant per request.
+func TestIdentity_TenantFor(t *testing.T) {
+	keys, err := ParseAPIKeys([]string{"storefront@acme=key-1", "backoffice@*=key-2", "orders=key-3"})
+	require.NoError(t, err)
+
+	cases := []struct {
+		key, requested, want string
+		ok                   bool
+	}{
+		{"key-1", "", "acme", true},
+		{"key-1", "acme", "acme", true},
+		{"key-1", "globex", "", false},
+		{"key-2", " globex ", "globex", true},
+		{"key-2", "", "", true},
+		{"key-3", "", "", true},
+		{"key-3", "acme", "", false},
+	}
+	for _, tc := range cases {
+		id, ok := keys.Verify(tc.key)
+		require.True(t, ok)
+
+		tenant, ok := id.TenantFor(tc.requested)
+		assert.Equal(t, tc.ok, ok, tc)
+		assert.Equal(t, tc.want, tenant, tc)
+	}
+}
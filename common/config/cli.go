@@ -0,0 +1,147 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Exit codes returned by RunCommand.
+const (
+	// ExitOK means the command succeeded and the configuration is valid.
+	ExitOK = 0
+
+	// ExitInvalid means the configuration could not be loaded or is invalid.
+	ExitInvalid = 1
+
+	// ExitUsage means the command line was malformed.
+	ExitUsage = 2
+)
+
+// commandUsage describes the config subcommands.
+const commandUsage = `usage: config <command> [flags]
+
+commands:
+  print     show every key with its effective value, source and env var
+  validate  check the configuration and report invalid keys
+  schema    write the JSON Schema of the configuration file
+  env       write the Markdown reference of environment variables
+`
+
+// RunCommand handles the "config print", "config validate", "config schema"
+// and "config env" subcommands for a configuration of type T. It reports false when args do not start
+// with "config", so services can fall through to their normal startup:
+//
+//	if code, ok := config.RunCommand[appconfig.Config](os.Args[1:], "config.yaml", os.Stdout, os.Stderr); ok {
+//		os.Exit(code)
+//	}
+func RunCommand[T any](args []string, configPath string, stdout, stderr io.Writer) (int, bool) {
+	if len(args) == 0 || args[0] != "config" {
+		return ExitOK, false
+	}
+	if len(args) < 2 {
+		fmt.Fprint(stderr, commandUsage)
+		return ExitUsage, true
+	}
+
+	fs := flag.NewFlagSet("config "+args[1], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&configPath, "config", configPath, "path of the YAML configuration file")
+	format := fs.String("format", "text", "output format of print: text or json")
+
+	switch args[1] {
+	case "print", "validate":
+	case "schema":
+		schema, err := JSONSchema[T]()
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to generate schema: %v\n", err)
+			return ExitInvalid, true
+		}
+		_, _ = stdout.Write(schema)
+		return ExitOK, true
+	case "env":
+		fmt.Fprint(stdout, EnvReference[T]())
+		return ExitOK, true
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n%s", args[1], commandUsage)
+		return ExitUsage, true
+	}
+	if err := fs.Parse(args[2:]); err != nil {
+		return ExitUsage, true
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(stderr, "unknown format %q\n", *format)
+		return ExitUsage, true
+	}
+
+	report, err := Inspect[T](configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to load configuration: %v\n", err)
+		return ExitInvalid, true
+	}
+
+	if args[1] == "print" {
+		if err := printReport(stdout, report, *format); err != nil {
+			fmt.Fprintf(stderr, "failed to print configuration: %v\n", err)
+			return ExitInvalid, true
+		}
+	}
+
+	if !report.Valid() {
+		fmt.Fprintln(stderr, "configuration is invalid:")
+		for _, issue := range report.Issues {
+			fmt.Fprintf(stderr, "  %s\n", issue)
+		}
+		return ExitInvalid, true
+	}
+
+	if args[1] == "validate" {
+		fmt.Fprintln(stdout, "configuration is valid")
+	}
+	return ExitOK, true
+}
+
+// reportJSON is the JSON rendering of a Report.
+type reportJSON struct {
+	File   string      `json:"file"`
+	Files  []string    `json:"files"`
+	Fields []fieldJSON `json:"fields"`
+	Valid  bool        `json:"valid"`
+}
+
+// fieldJSON is the JSON rendering of a Field.
+type fieldJSON struct {
+	Key    string `json:"key"`
+	EnvVar string `json:"env"`
+	Value  any    `json:"value"`
+	Source Source `json:"source"`
+}
+
+// printReport writes every field of report to w in the given format.
+func printReport(w io.Writer, report *Report, format string) error {
+	if format == "json" {
+		out := reportJSON{File: report.Path, Files: report.Files, Valid: report.Valid()}
+		for _, f := range report.Fields {
+			out.Fields = append(out.Fields, fieldJSON{Key: f.Key, EnvVar: f.EnvVar, Value: f.Value, Source: f.Source})
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	files := "none found"
+	if len(report.Files) > 0 {
+		files = strings.Join(report.Files, ", ")
+	}
+	fmt.Fprintf(w, "file: %s\nlayers: %s\n\n", report.Path, files)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE\tENV")
+	for _, f := range report.Fields {
+		fmt.Fprintf(tw, "%s\t%v\t%s\t%s\n", f.Key, f.Value, f.Source, f.EnvVar)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCommand runs RunCommand for inspectStruct and captures its output.
+func runCommand(args ...string) (int, bool, string, string) {
+	var stdout, stderr bytes.Buffer
+	code, ok := RunCommand[inspectStruct](args, "testdata/nested.yaml", &stdout, &stderr)
+	return code, ok, stdout.String(), stderr.String()
+}
+
+// TestRunCommandIgnoresOtherArgs verifies services keep starting without the subcommand.
+func TestRunCommandIgnoresOtherArgs(t *testing.T) {
+	_, ok, _, _ := runCommand()
+	assert.False(t, ok)
+
+	_, ok, _, _ = runCommand("serve")
+	assert.False(t, ok)
+}
+
+// TestRunCommandPrint verifies the text table lists values, sources and env vars.
+func TestRunCommandPrint(t *testing.T) {
+	t.Setenv("API_KEY", "top-secret")
+
+	code, ok, out, _ := runCommand("config", "print")
+	require.True(t, ok)
+	assert.Equal(t, ExitOK, code)
+	assert.Contains(t, out, "layers: testdata/nested.yaml")
+	assert.Regexp(t, `server\.port\s+8081\s+file\s+SERVER_PORT`, out)
+	assert.Regexp(t, `api_key\s+\*\*\*\s+env\s+API_KEY`, out)
+	assert.NotContains(t, out, "top-secret")
+}
+
+// TestRunCommandPrintJSON verifies the JSON output.
+func TestRunCommandPrintJSON(t *testing.T) {
+	code, _, out, _ := runCommand("config", "print", "-format", "json")
+	require.Equal(t, ExitOK, code)
+
+	var body reportJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &body))
+	assert.True(t, body.Valid)
+	assert.Equal(t, "server.service_name", body.Fields[0].Key)
+	assert.Equal(t, "contacts", body.Fields[0].Value)
+}
+
+// TestRunCommandValidate verifies validate reports readable issues and exit codes.
+func TestRunCommandValidate(t *testing.T) {
+	code, _, out, _ := runCommand("config", "validate")
+	assert.Equal(t, ExitOK, code)
+	assert.Contains(t, out, "configuration is valid")
+
+	code, _, _, errOut := runCommand("config", "validate", "-config", "testdata/nested_invalid.yaml")
+	assert.Equal(t, ExitInvalid, code)
+	assert.Contains(t, errOut, "server.port (env SERVER_PORT): must be greater than 0")
+}
+
+// TestRunCommandUsage verifies malformed command lines.
+func TestRunCommandUsage(t *testing.T) {
+	code, ok, _, errOut := runCommand("config")
+	assert.True(t, ok)
+	assert.Equal(t, ExitUsage, code)
+	assert.Contains(t, errOut, "usage")
+
+	code, _, _, _ = runCommand("config", "dump")
+	assert.Equal(t, ExitUsage, code)
+
+	code, _, _, _ = runCommand("config", "print", "-format", "xml")
+	assert.Equal(t, ExitUsage, code)
+}
+
+// TestRunCommandGenerators verifies the schema and env var reference are written to stdout.
+func TestRunCommandGenerators(t *testing.T) {
+	code, _, out, _ := runCommand("config", "schema")
+	require.Equal(t, ExitOK, code)
+	assert.True(t, json.Valid([]byte(out)))
+	assert.Contains(t, out, schemaDraft)
+
+	code, _, out, _ = runCommand("config", "env")
+	require.Equal(t, ExitOK, code)
+	assert.Contains(t, out, "| `SERVER_PORT` | `server.port` |")
+}
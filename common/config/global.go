@@ -21,14 +21,82 @@ const (
 type GlobalConfig struct {
 
 	// ServiceName is the unique identifier of the running microservice.
-	ServiceName string `mapstructure:"service_name" default:"mannaiah-unknown" validate:"required"`
+	ServiceName string `mapstructure:"service_name" default:"mannaiah-unknown" validate:"required" reload:"restart"`
 
 	// Port is the port number the service will listen on.
-	Port int `mapstructure:"port" default:"8080" validate:"required,gt=0"`
+	Port int `mapstructure:"port" default:"8080" validate:"required,gt=0" reload:"restart"`
+
+	// GRPCPort is the port number the gRPC server will listen on.
+	GRPCPort int `mapstructure:"grpc_port" default:"9090" validate:"required,gt=0" reload:"restart"`
 
 	// LogLevel defines the verbosity of log output.
-	LogLevel string `mapstructure:"log_level" default:"info" validate:"required"`
+	LogLevel string `mapstructure:"log_level" default:"info" validate:"required,oneof=debug info warn error"`
+
+	// LogFormat selects the log line encoding.
+	LogFormat string `mapstructure:"log_format" default:"json" validate:"oneof=json console logfmt" reload:"restart"`
+
+	// LogOutput selects where logs are written. The file output is rotated by
+	// size and age.
+	LogOutput string `mapstructure:"log_output" default:"stdout" validate:"oneof=stdout stderr file" reload:"restart"`
+
+	// LogFile is the path logs are written to when LogOutput is file.
+	LogFile string `mapstructure:"log_file" validate:"required_if=LogOutput file" reload:"restart"`
+
+	// LogMaxSize is the size at which the log file is rotated. Represented in megabytes.
+	LogMaxSize int `mapstructure:"log_max_size" default:"100" validate:"gte=1" reload:"restart"`
+
+	// LogMaxAge is how long rotated log files are kept. Represented in days.
+	LogMaxAge int `mapstructure:"log_max_age" default:"7" validate:"gte=1" reload:"restart"`
+
+	// LogMaxBackups is how many rotated log files are kept.
+	LogMaxBackups int `mapstructure:"log_max_backups" default:"5" validate:"gte=1" reload:"restart"`
+
+	// LogSamplingInitial is how many identical entries are logged each second
+	// before sampling starts. Use 0 to disable sampling.
+	LogSamplingInitial int `mapstructure:"log_sampling_initial" validate:"gte=0" reload:"restart"`
+
+	// LogSamplingThereafter logs one out of every N identical entries once sampling started.
+	LogSamplingThereafter int `mapstructure:"log_sampling_thereafter" default:"100" validate:"gte=1" reload:"restart"`
+
+	// LogCaller annotates log entries with the file and line that produced them.
+	LogCaller bool `mapstructure:"log_caller" default:"false" reload:"restart"`
+
+	// LogStacktrace is the level from which stack traces are attached to log entries.
+	LogStacktrace string `mapstructure:"log_stacktrace" default:"error" validate:"oneof=debug info warn error off" reload:"restart"`
+
+	// PIIMasking selects how personal data is masked in logs: mask keeps hints
+	// such as the email domain, redact hides values entirely and off logs them
+	// as they are. Staging and production refuse off.
+	PIIMasking string `mapstructure:"pii_masking" default:"mask" validate:"oneof=mask redact off" validate_staging:"ne=off" validate_production:"ne=off"`
+
+	// AdminToken authenticates calls to administrative endpoints such as
+	// /internal/loglevel, sent as a bearer token. Empty disables those endpoints.
+	AdminToken string `mapstructure:"admin_token" secret:"true" validate:"omitempty,min=16" reload:"restart"`
+
+	// APIKeys authenticates callers of the public HTTP and gRPC APIs. Entries
+	// have the form principal=key, or principal@tenant=key to bind the key to a
+	// tenant (comma-separated in environment variables); a tenant of * lets the
+	// caller choose one per request in X-Tenant-ID. Requests send the key in the
+	// X-API-Key header, or the x-api-key metadata over gRPC. Empty disables
+	// authentication, so production refuses it.
+	APIKeys []string `mapstructure:"api_keys" secret:"true" validate_production:"required" reload:"restart"`
+
+	// TrustedProxies lists the addresses or CIDR ranges of reverse proxies, such
+	// as the storefront, allowed to report the client address in ProxyHeader.
+	// Requests from any other peer are attributed to the peer itself.
+	TrustedProxies []string `mapstructure:"trusted_proxies" validate:"dive,ip|cidr" reload:"restart"`
+
+	// ProxyHeader is the header trusted proxies forward the client address in.
+	ProxyHeader string `mapstructure:"proxy_header" default:"X-Forwarded-For" validate:"required" reload:"restart"`
+
+	// AccessLogSampling logs one out of every N successful requests in the access log.
+	AccessLogSampling int `mapstructure:"access_log_sampling" default:"1" validate:"gte=1" reload:"restart"`
 
 	// Env specifies the environment mode used to control behavior like logging, metrics, etc.
-	Env AppEnv `mapstructure:"app_env" default:"dev" validate:"required,oneof=local dev staging production"`
+	Env AppEnv `mapstructure:"app_env" default:"dev" validate:"required,oneof=local dev staging production" reload:"restart"`
+}
+
+// String renders the configuration with secrets redacted, safe for logging.
+func (c GlobalConfig) String() string {
+	return String(c)
 }
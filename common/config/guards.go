@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// envRulesTag prefixes tags holding validation rules enforced only when the
+// configuration runs in one environment, e.g. validate_production:"ne=memory".
+const envRulesTag = "validate_"
+
+// kvSSLModePattern matches the sslmode of a key=value connection string.
+var kvSSLModePattern = regexp.MustCompile(`(?i)(?:^|\s)sslmode\s*=\s*'?([a-z-]+)`)
+
+// enforcedSSLModes are the sslmodes that refuse unencrypted connections. The
+// libpq default "prefer", like "allow", silently falls back to plaintext.
+var enforcedSSLModes = []string{"require", "verify-ca", "verify-full"}
+
+// newValidator returns the validator used for configuration, with the rules
+// environment guards rely on registered.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	_ = v.RegisterValidation("sslmode_enabled", func(fl validator.FieldLevel) bool {
+		return slices.Contains(enforcedSSLModes, sslMode(fl.Field().String()))
+	})
+	return v
+}
+
+// sslMode returns the sslmode of a URL or key=value connection string, or an
+// empty string when it is not set.
+func sslMode(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		return strings.ToLower(u.Query().Get("sslmode"))
+	}
+	if m := kvSSLModePattern.FindStringSubmatch(dsn); m != nil {
+		return strings.ToLower(m[1])
+	}
+	return ""
+}
+
+// environments lists every AppEnv, in promotion order.
+var environments = []AppEnv{EnvLocal, EnvDev, EnvStaging, EnvProduction}
+
+// envRules describes the environment guards of field, e.g. "in production: ne=memory".
+func envRules(field reflect.StructField) []string {
+	var out []string
+	for _, env := range environments {
+		if rules := field.Tag.Get(envRulesTag + string(env)); rules != "" {
+			out = append(out, "in "+string(env)+": "+rules)
+		}
+	}
+	return out
+}
+
+// envIssues enforces the validate_<app_env> rules of struct v for the
+// environment it configures. Structs without an AppEnv field have no guards.
+func envIssues(v reflect.Value) []Issue {
+	all := leaves(v, "", "")
+
+	env := ""
+	for _, l := range all {
+		if l.value.Type() == reflect.TypeOf(AppEnv("")) {
+			env = l.value.String()
+			break
+		}
+	}
+	if env == "" {
+		return nil
+	}
+
+	var issues []Issue
+	for _, l := range all {
+		rules := l.field.Tag.Get(envRulesTag + env)
+		if rules == "" {
+			continue
+		}
+
+		var errs validator.ValidationErrors
+		if !errors.As(validate.Var(l.value.Interface(), rules), &errs) {
+			continue
+		}
+		for _, e := range errs {
+			issues = append(issues, Issue{
+				Key:     l.key + e.Namespace(),
+				EnvVar:  envVar(l.key),
+				Message: issueMessage(e.Tag(), e.Param()) + " in " + env,
+			})
+		}
+	}
+	return issues
+}
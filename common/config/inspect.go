@@ -0,0 +1,310 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/viper"
+)
+
+// Source tells where the effective value of a configuration key came from.
+type Source string
+
+const (
+	// SourceEnvFile means the value was read from the file named by <ENV>_FILE.
+	SourceEnvFile Source = "env_file"
+
+	// SourceEnv means the value was read from the environment variable.
+	SourceEnv Source = "env"
+
+	// SourceFile means the value was read from the YAML file.
+	SourceFile Source = "file"
+
+	// SourceDefault means the value comes from the field's default tag.
+	SourceDefault Source = "default"
+
+	// SourceUnset means no source provided a value and the zero value is used.
+	SourceUnset Source = "unset"
+)
+
+// Field describes the effective value of a single configuration key.
+type Field struct {
+	// Key is the dotted YAML key, e.g. "server.port".
+	Key string
+
+	// EnvVar is the environment variable bound to the key, e.g. "SERVER_PORT".
+	EnvVar string
+
+	// Value is the effective value with secrets and DSN passwords masked.
+	Value any
+
+	// Source tells where Value came from.
+	Source Source
+
+	// Secret reports whether the field is tagged secret:"true".
+	Secret bool
+}
+
+// Issue is a validation failure translated to the key and variable to fix.
+type Issue struct {
+	// Key is the dotted YAML key of the invalid field. Slice elements carry
+	// their index, e.g. "database.db_replica_urls[1]".
+	Key string
+
+	// EnvVar is the environment variable bound to the key.
+	EnvVar string
+
+	// Message is a human-readable explanation of the failure.
+	Message string
+}
+
+// String renders the issue as a single line naming both the key and variable.
+func (i Issue) String() string {
+	return fmt.Sprintf("%s (env %s): %s", i.Key, i.EnvVar, i.Message)
+}
+
+// ValidationError reports every invalid configuration key.
+type ValidationError struct {
+	// Issues lists every failure in field order.
+	Issues []Issue
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		msgs[i] = issue.String()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Report is the result of inspecting a configuration.
+type Report struct {
+	// Path is the base YAML file that was looked up.
+	Path string
+
+	// Files lists the configuration layers that were read, in merge order.
+	Files []string
+
+	// Fields lists every key in declaration order.
+	Fields []Field
+
+	// Issues lists validation failures; empty when the configuration is valid.
+	Issues []Issue
+}
+
+// Valid reports whether the configuration passed validation.
+func (r *Report) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// Inspect loads configuration like Load and reports every key with its
+// effective value, source and environment variable. Validation failures are
+// returned in the report rather than as an error, so an invalid
+// configuration can still be printed.
+func Inspect[T any](configPath string) (*Report, error) {
+	v, _, files, err := newViper[T](configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := build[T](v)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Path: configPath, Files: files}
+	for _, l := range leaves(reflect.ValueOf(cfg).Elem(), "", "") {
+		report.Fields = append(report.Fields, Field{
+			Key:    l.key,
+			EnvVar: envVar(l.key),
+			Value:  maskValue(l.field, l.value),
+			Source: sourceOf(v, l.key, l.field),
+			Secret: l.field.Tag.Get("secret") == "true",
+		})
+	}
+
+	var verr *ValidationError
+	if err := check(cfg); errors.As(err, &verr) {
+		report.Issues = verr.Issues
+	} else if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// leaf is a non-struct configuration field reached through nested structs.
+type leaf struct {
+	// key is the dotted mapstructure path.
+	key string
+
+	// namespace is the dotted Go field path, as reported by the validator.
+	namespace string
+
+	// field is the struct field declaration.
+	field reflect.StructField
+
+	// value is the field value.
+	value reflect.Value
+}
+
+// leaves flattens struct v into its non-struct fields, in declaration order.
+func leaves(v reflect.Value, prefix, namespace string) []leaf {
+	var out []leaf
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if field.PkgPath != "" || tag == "" || tag == "-" {
+			continue
+		}
+
+		key, ns := tag, field.Name
+		if prefix != "" {
+			key = prefix + "." + tag
+			ns = namespace + "." + field.Name
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			out = append(out, leaves(v.Field(i), key, ns)...)
+			continue
+		}
+		out = append(out, leaf{key: key, namespace: ns, field: field, value: v.Field(i)})
+	}
+
+	return out
+}
+
+// envVar returns the environment variable bindEnvs binds to key.
+func envVar(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// maskValue returns the printable form of a field value.
+func maskValue(field reflect.StructField, v reflect.Value) any {
+	switch {
+	case field.Tag.Get("secret") == "true":
+		return Masked
+	case v.Kind() == reflect.String:
+		return MaskDSN(v.String())
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		masked := make([]string, v.Len())
+		for i := range masked {
+			masked[i] = MaskDSN(v.Index(i).String())
+		}
+		return masked
+	default:
+		return v.Interface()
+	}
+}
+
+// sourceOf mirrors viper's precedence to tell which source supplied key.
+func sourceOf(v *viper.Viper, key string, field reflect.StructField) Source {
+	env := envVar(key)
+	switch {
+	case os.Getenv(env+fileEnvSuffix) != "":
+		return SourceEnvFile
+	case os.Getenv(env) != "":
+		return SourceEnv
+	case v.InConfig(key):
+		return SourceFile
+	case field.Tag.Get("default") != "":
+		return SourceDefault
+	default:
+		return SourceUnset
+	}
+}
+
+// check validates cfg, including the guards of its environment, and
+// translates failures into a ValidationError.
+func check(cfg any) error {
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	var issues []Issue
+	var errs validator.ValidationErrors
+	if err := validate.Struct(cfg); errors.As(err, &errs) {
+		keys := make(map[string]string)
+		for _, l := range leaves(v, "", "") {
+			keys[l.namespace] = l.key
+		}
+
+		for _, e := range errs {
+			key := issueKey(keys, e.StructNamespace())
+			issues = append(issues, Issue{
+				Key:     key,
+				EnvVar:  envVar(strings.Split(key, "[")[0]),
+				Message: issueMessage(e.Tag(), e.Param()),
+			})
+		}
+	} else if err != nil {
+		return err
+	}
+
+	issues = append(issues, envIssues(v)...)
+	if len(issues) == 0 {
+		return nil
+	}
+	return &ValidationError{Issues: issues}
+}
+
+// issueKey maps a validator namespace such as "Config.DatabaseConfig.ReplicaURLs[1]"
+// to the YAML key "database.db_replica_urls[1]".
+func issueKey(keys map[string]string, namespace string) string {
+	_, ns, _ := strings.Cut(namespace, ".")
+	if ns == "" {
+		ns = namespace
+	}
+
+	path, index, _ := strings.Cut(ns, "[")
+	key, ok := keys[path]
+	if !ok {
+		return ns
+	}
+	if index != "" {
+		key += "[" + index
+	}
+	return key
+}
+
+// issueMessage renders a readable message for the rules configuration uses.
+func issueMessage(rule, param string) string {
+	switch rule {
+	case "required":
+		return "is required"
+	case "required_if":
+		return fmt.Sprintf("is required when %s", strings.Replace(param, " ", " is ", 1))
+	case "url", "uri":
+		return "must be a valid URL"
+	case "min":
+		return fmt.Sprintf("must be at least %s characters long", param)
+	case "max":
+		return fmt.Sprintf("must be at most %s characters long", param)
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", param)
+	case "ne":
+		return fmt.Sprintf("must not be %s", param)
+	case "ip|cidr":
+		return "must be an IP address or CIDR range"
+	case "sslmode_enabled":
+		return "must set sslmode to require, verify-ca or verify-full"
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", param)
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", param)
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", param)
+	case "lt":
+		return fmt.Sprintf("must be less than %s", param)
+	default:
+		return fmt.Sprintf("failed on '%s'", rule)
+	}
+}
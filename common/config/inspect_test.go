@@ -0,0 +1,85 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inspectStruct nests the shared configs the way services embed them.
+type inspectStruct struct {
+	GlobalConfig   `mapstructure:"server"`
+	DatabaseConfig `mapstructure:"database"`
+
+	APIKey string `mapstructure:"api_key" secret:"true"`
+}
+
+// field returns the report entry for key.
+func field(t *testing.T, r *Report, key string) Field {
+	t.Helper()
+	for _, f := range r.Fields {
+		if f.Key == key {
+			return f
+		}
+	}
+	t.Fatalf("key %s not reported", key)
+	return Field{}
+}
+
+// TestInspectSources verifies each key reports where its value came from.
+func TestInspectSources(t *testing.T) {
+	t.Setenv("DATABASE_DB_MIN_IDLE", "7")
+	t.Setenv("DATABASE_DATABASE_URL_FILE", writeSecret(t, "postgres://app:s3cret@db:5432/mannaiah"))
+	t.Setenv("API_KEY", "top-secret")
+
+	r, err := Inspect[inspectStruct]("testdata/nested.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"testdata/nested.yaml"}, r.Files)
+	assert.True(t, r.Valid())
+
+	port := field(t, r, "server.port")
+	assert.Equal(t, 8081, port.Value)
+	assert.Equal(t, SourceFile, port.Source)
+	assert.Equal(t, "SERVER_PORT", port.EnvVar)
+
+	grpcPort := field(t, r, "server.grpc_port")
+	assert.Equal(t, 9090, grpcPort.Value)
+	assert.Equal(t, SourceDefault, grpcPort.Source)
+
+	minIdle := field(t, r, "database.db_min_idle")
+	assert.Equal(t, 7, minIdle.Value)
+	assert.Equal(t, SourceEnv, minIdle.Source)
+
+	dsn := field(t, r, "database.database_url")
+	assert.Equal(t, "postgres://app:***@db:5432/mannaiah", dsn.Value)
+	assert.Equal(t, SourceEnvFile, dsn.Source)
+
+	key := field(t, r, "api_key")
+	assert.Equal(t, Masked, key.Value)
+	assert.True(t, key.Secret)
+
+	replicas := field(t, r, "database.db_replica_urls")
+	assert.Equal(t, SourceUnset, replicas.Source)
+}
+
+// TestInspectIssues verifies validation failures name the YAML key and env var.
+func TestInspectIssues(t *testing.T) {
+	t.Setenv("DATABASE_DB_REPLICA_URLS", "postgres://ok:5432/db,not a url")
+
+	r, err := Inspect[inspectStruct]("testdata/nested_invalid.yaml")
+	require.NoError(t, err)
+	require.False(t, r.Valid())
+
+	assert.Contains(t, r.Issues, Issue{Key: "server.port", EnvVar: "SERVER_PORT", Message: "must be greater than 0"})
+	assert.Contains(t, r.Issues, Issue{Key: "server.app_env", EnvVar: "SERVER_APP_ENV", Message: "must be one of: local dev staging production"})
+	assert.Contains(t, r.Issues, Issue{Key: "database.db_replica_urls[1]", EnvVar: "DATABASE_DB_REPLICA_URLS", Message: "must be a valid URL"})
+
+	// The same issues surface from Load.
+	_, _, err = Load[inspectStruct]("testdata/nested_invalid.yaml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server.port (env SERVER_PORT): must be greater than 0")
+
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+}
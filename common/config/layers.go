@@ -0,0 +1,146 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// confDir is the directory, next to the base file, holding configuration
+	// fragments such as mounted Kubernetes ConfigMap keys.
+	confDir = "conf.d"
+
+	// localOverlay names the developer override merged last, e.g. config.local.yaml.
+	localOverlay = "local"
+)
+
+// layers locates the files merged into a configuration. Given a base file
+// config.yaml, values are merged in this order, later files winning:
+//
+//  1. config.yaml
+//  2. config.<app_env>.yaml
+//  3. conf.d/*.yaml, in lexical order
+//  4. config.local.yaml
+//
+// Environment variables override every file. Missing files are skipped.
+type layers struct {
+	// base is the path of the base file.
+	base string
+
+	// env selects the environment overlay; empty means none.
+	env string
+}
+
+// files returns the candidate files in merge order.
+func (l layers) files() []string {
+	dir := filepath.Dir(l.base)
+	ext := filepath.Ext(l.base)
+	stem := strings.TrimSuffix(filepath.Base(l.base), ext)
+
+	files := []string{l.base}
+	if l.env != "" && l.env != localOverlay {
+		files = append(files, filepath.Join(dir, stem+"."+l.env+ext))
+	}
+
+	fragments, _ := filepath.Glob(filepath.Join(dir, confDir, "*.yaml"))
+	yml, _ := filepath.Glob(filepath.Join(dir, confDir, "*.yml"))
+	fragments = append(fragments, yml...)
+	sort.Strings(fragments)
+	files = append(files, fragments...)
+
+	return append(files, filepath.Join(dir, stem+"."+localOverlay+ext))
+}
+
+// dirs returns the directories whose changes may affect the configuration.
+func (l layers) dirs() []string {
+	dir := filepath.Dir(l.base)
+	dirs := []string{dir}
+	if info, err := os.Stat(filepath.Join(dir, confDir)); err == nil && info.IsDir() {
+		dirs = append(dirs, filepath.Join(dir, confDir))
+	}
+	return dirs
+}
+
+// read replaces the configuration held by v with the merged content of every
+// existing layer and returns the files that were read.
+func (l layers) read(v *viper.Viper) ([]string, error) {
+	var read []string
+	if err := v.ReadConfig(bytes.NewReader(nil)); err != nil {
+		return nil, err
+	}
+
+	for _, file := range l.files() {
+		content, err := os.ReadFile(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return read, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if err := v.MergeConfig(bytes.NewReader(content)); err != nil {
+			return read, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		read = append(read, file)
+	}
+
+	return read, nil
+}
+
+// resolveEnv picks the environment overlay of T from the base file and the
+// environment. T without an AppEnv field has no overlay.
+func resolveEnv[T any](v *viper.Viper, base string) (string, error) {
+	var raw T
+	key, def, ok := envKey(reflect.TypeOf(raw), "")
+	if !ok {
+		return "", nil
+	}
+
+	if _, err := (layers{base: base}).read(v); err != nil {
+		return "", err
+	}
+	if env := v.GetString(key); env != "" {
+		return env, nil
+	}
+	return def, nil
+}
+
+// envKey returns the key and default of the first AppEnv field of t.
+func envKey(t reflect.Type, prefix string) (string, string, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return "", "", false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if field.PkgPath != "" || tag == "" || tag == "-" {
+			continue
+		}
+
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+
+		if field.Type == reflect.TypeOf(AppEnv("")) {
+			return key, field.Tag.Get("default"), true
+		}
+		if key, def, ok := envKey(field.Type, key); ok {
+			return key, def, true
+		}
+	}
+
+	return "", "", false
+}
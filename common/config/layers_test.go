@@ -0,0 +1,145 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// layeredStruct nests the shared configs the way services embed them.
+type layeredStruct struct {
+	GlobalConfig   `mapstructure:"server"`
+	DatabaseConfig `mapstructure:"database"`
+}
+
+// writeLayers writes each file, relative to a new directory, and returns the base path.
+func writeLayers(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		writeConfig(t, path, content)
+	}
+	return filepath.Join(dir, "config.yaml")
+}
+
+// TestLoadLayers verifies overlays are merged in order and env vars win over every file.
+func TestLoadLayers(t *testing.T) {
+	base := writeLayers(t, map[string]string{
+		"config.yaml":         "server:\n  service_name: contacts\n  app_env: staging\n  port: 8081\n  log_level: info\n",
+		"config.staging.yaml": "server:\n  port: 8082\n  log_level: warn\n",
+		"config.dev.yaml":     "server:\n  port: 9999\n",
+		"conf.d/10-db.yaml":   "database:\n  db_max_pool: 40\n",
+		"conf.d/20-db.yml":    "database:\n  db_max_pool: 50\n  db_min_idle: 2\n",
+		"config.local.yaml":   "server:\n  log_level: debug\n",
+	})
+	t.Setenv("DATABASE_DB_MIN_IDLE", "3")
+
+	cfg, found, err := Load[layeredStruct](base)
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	assert.Equal(t, EnvStaging, cfg.Env)
+	assert.Equal(t, "contacts", cfg.ServiceName)
+	assert.Equal(t, 8082, cfg.Port)
+	assert.Equal(t, "debug", cfg.LogLevel)
+	assert.Equal(t, 50, cfg.MaxPool)
+	assert.Equal(t, 3, cfg.MinIdle)
+}
+
+// TestLoadLayersEnvFromVariable verifies the environment variable selects the overlay.
+func TestLoadLayersEnvFromVariable(t *testing.T) {
+	base := writeLayers(t, map[string]string{
+		"config.yaml":            "server:\n  service_name: contacts\n",
+		"config.production.yaml": "server:\n  port: 80\n  api_keys:\n    - storefront=0123456789abcdef\ndatabase:\n  database_url: postgres://app:pw@db:5432/mannaiah?sslmode=require\n",
+	})
+	t.Setenv("SERVER_APP_ENV", "production")
+
+	cfg, _, err := Load[layeredStruct](base)
+	require.NoError(t, err)
+	assert.Equal(t, 80, cfg.Port)
+}
+
+// TestLoadLayersMissingBase verifies overlays apply even without a base file.
+func TestLoadLayersMissingBase(t *testing.T) {
+	base := writeLayers(t, map[string]string{
+		"config.local.yaml": "server:\n  port: 7000\n",
+	})
+
+	cfg, found, err := Load[layeredStruct](base)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 7000, cfg.Port)
+}
+
+// TestLoadLayersMalformed verifies a broken layer is reported with its file name.
+func TestLoadLayersMalformed(t *testing.T) {
+	base := writeLayers(t, map[string]string{
+		"config.yaml":     "server:\n  service_name: contacts\n",
+		"conf.d/bad.yaml": "server: [\n",
+	})
+
+	_, _, err := Load[layeredStruct](base)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad.yaml")
+}
+
+// TestLoadProductionGuards verifies production refuses database connections that
+// may fall back to plaintext, including an unset sslmode.
+func TestLoadProductionGuards(t *testing.T) {
+	base := writeLayers(t, map[string]string{
+		"config.yaml": "server:\n  service_name: contacts\n  app_env: production\n  api_keys:\n    - storefront=0123456789abcdef\n" +
+			"database:\n  database_url: postgres://app:pw@db:5432/mannaiah?sslmode=disable\n" +
+			"  db_replica_urls:\n    - postgres://ro@replica:5432/mannaiah?sslmode=require\n    - postgres://ro@replica-2:5432/mannaiah?sslmode=disable\n    - postgres://ro@replica-3:5432/mannaiah?sslmode=prefer\n    - postgres://ro@replica-4:5432/mannaiah\n    - postgres://ro@replica-5:5432/mannaiah?sslmode=verify-full\n",
+	})
+
+	_, _, err := Load[layeredStruct](base)
+	require.Error(t, err)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, []Issue{
+		{Key: "database.database_url", EnvVar: "DATABASE_DATABASE_URL", Message: "must set sslmode to require, verify-ca or verify-full in production"},
+		{Key: "database.db_replica_urls[1]", EnvVar: "DATABASE_DB_REPLICA_URLS", Message: "must set sslmode to require, verify-ca or verify-full in production"},
+		{Key: "database.db_replica_urls[2]", EnvVar: "DATABASE_DB_REPLICA_URLS", Message: "must set sslmode to require, verify-ca or verify-full in production"},
+		{Key: "database.db_replica_urls[3]", EnvVar: "DATABASE_DB_REPLICA_URLS", Message: "must set sslmode to require, verify-ca or verify-full in production"},
+	}, verr.Issues)
+	assert.NotContains(t, err.Error(), "pw@")
+
+	// Other environments accept it.
+	t.Setenv("SERVER_APP_ENV", "staging")
+	_, _, err = Load[layeredStruct](base)
+	require.NoError(t, err)
+}
+
+// TestSSLMode verifies sslmode is read from URL and key=value connection strings.
+func TestSSLMode(t *testing.T) {
+	assert.Equal(t, "disable", sslMode("postgres://u@h/db?sslmode=disable"))
+	assert.Equal(t, "", sslMode("postgres://u@h/db"))
+	assert.Equal(t, "require", sslMode("host=h sslmode=require dbname=x"))
+	assert.Equal(t, "disable", sslMode("sslmode='disable' host=h"))
+}
+
+// TestWatch_ConfDirChange verifies fragments added to conf.d are picked up automatically.
+func TestWatch_ConfDirChange(t *testing.T) {
+	base := writeLayers(t, map[string]string{
+		"config.yaml":         "service_name: contacts\nlog_level: info\n",
+		"conf.d/00-keep.yaml": "port: 8081\n",
+	})
+
+	w, _, err := Watch[GlobalConfig](base)
+	require.NoError(t, err)
+
+	var reloaded atomic.Bool
+	w.Subscribe(func(_, _ *GlobalConfig) { reloaded.Store(true) })
+
+	writeConfig(t, filepath.Join(filepath.Dir(base), "conf.d", "10-log.yaml"), "log_level: error\n")
+	assert.Eventually(t, reloaded.Load, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, "error", w.Get().LogLevel)
+}
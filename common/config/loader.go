@@ -2,7 +2,6 @@ package config
 
 import (
 	"fmt"
-	"github.com/go-playground/validator/v10"
 	"github.com/mcuadros/go-defaults"
 	"github.com/spf13/viper"
 	"reflect"
@@ -10,7 +9,7 @@ import (
 )
 
 // validator instance for struct-level validation
-var validate = validator.New()
+var validate = newValidator()
 
 // Default sets default values defined via struct tags.
 func Default(cfg any) {
@@ -54,10 +53,29 @@ func bindEnvs(v *viper.Viper, prefix string, t reflect.Type) {
 	}
 }
 
-// Load reads configuration from YAML file and environment variables.
-// It unmarshalls the config into type T, applies environment overrides,
-// sets defaults, and validates it using validator.v10.
+// Load reads configuration from the layered YAML files next to configPath
+// and environment variables. It unmarshalls the config into type T, applies
+// environment overrides, sets defaults, and validates it using validator.v10.
+// The returned bool reports whether any configuration file was found.
 func Load[T any](configPath string) (*T, bool, error) {
+	v, _, files, err := newViper[T](configPath)
+	if err != nil {
+		return nil, len(files) > 0, err
+	}
+
+	cfg, err := decode[T](v)
+	if err != nil {
+		return nil, len(files) > 0, err
+	}
+
+	return cfg, len(files) > 0, nil
+}
+
+// newViper prepares a viper instance holding the merged configuration layers
+// of configPath and bound to environment variables for every field of T,
+// including <ENV>_FILE variables pointing at mounted secrets. It returns the
+// layers in use and the files that were read.
+func newViper[T any](configPath string) (*viper.Viper, layers, []string, error) {
 	v := viper.New()
 
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
@@ -69,20 +87,52 @@ func Load[T any](configPath string) (*T, bool, error) {
 	var raw T
 	bindEnvs(v, "", reflect.TypeOf(raw))
 
-	fileFound := false
-	if err := v.ReadInConfig(); err == nil {
-		fileFound = true
+	env, err := resolveEnv[T](v, configPath)
+	if err != nil {
+		return nil, layers{}, nil, err
 	}
 
-	if err := v.Unmarshal(&raw); err != nil {
-		return nil, fileFound, fmt.Errorf("failed to unmarshal configuration: %w", err)
+	l := layers{base: configPath, env: env}
+	files, err := l.read(v)
+	if err != nil {
+		return nil, l, files, err
 	}
 
-	Default(&raw)
+	if err := bindFileEnvs(v, "", reflect.TypeOf(raw)); err != nil {
+		return nil, l, files, err
+	}
 
-	if err := validate.Struct(&raw); err != nil {
-		return nil, fileFound, fmt.Errorf("validation failed: %w", err)
+	return v, l, files, nil
+}
+
+// decode builds a T from the current viper state and validates it. Secret
+// values are masked in returned errors.
+func decode[T any](v *viper.Viper) (*T, error) {
+	cfg, err := build[T](v)
+	if err != nil {
+		return nil, err
 	}
 
-	return &raw, fileFound, nil
+	if err := check(cfg); err != nil {
+		return nil, redactError(fmt.Errorf("validation failed: %w", err), secretValues(reflect.ValueOf(cfg).Elem()))
+	}
+
+	return cfg, nil
+}
+
+// build unmarshalls the current viper state into a new T, resolves file://
+// values and applies defaults, without validating.
+func build[T any](v *viper.Viper) (*T, error) {
+	var raw T
+	if err := v.Unmarshal(&raw); err != nil {
+		return nil, redactError(fmt.Errorf("failed to unmarshal configuration: %w", err), secretValues(reflect.ValueOf(&raw).Elem()))
+	}
+
+	if err := resolveFileRefs(reflect.ValueOf(&raw).Elem(), ""); err != nil {
+		return nil, err
+	}
+
+	Default(&raw)
+
+	return &raw, nil
 }
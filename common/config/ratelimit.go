@@ -0,0 +1,24 @@
+package config
+
+// RateLimitConfig defines request rate limiting for a route group.
+type RateLimitConfig struct {
+	// Enabled turns rate limiting on or off.
+	Enabled bool `mapstructure:"rate_limit_enabled" default:"false" reload:"restart"`
+
+	// Requests is the number of requests allowed per window.
+	Requests int `mapstructure:"rate_limit_requests" default:"60" validate:"gte=1"`
+
+	// Window is the length of the limiting window, in seconds.
+	Window int `mapstructure:"rate_limit_window" default:"60" validate:"gte=1"`
+
+	// Algorithm selects the counting strategy.
+	Algorithm string `mapstructure:"rate_limit_algorithm" default:"token_bucket" validate:"oneof=token_bucket sliding_window"`
+
+	// KeyBy selects the identity requests are counted against. API keys,
+	// principals and tenants are only used once verified through server.api_keys;
+	// other requests are counted per client IP.
+	KeyBy string `mapstructure:"rate_limit_key" default:"ip" validate:"oneof=ip api_key principal tenant"`
+
+	// Store selects where counters live; use postgres to share limits across replicas.
+	Store string `mapstructure:"rate_limit_store" default:"memory" validate:"oneof=memory postgres" reload:"restart"`
+}
@@ -0,0 +1,303 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// schemaDraft is the JSON Schema dialect emitted by JSONSchema.
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is the subset of JSON Schema needed to describe configuration.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Minimum              any                    `json:"minimum,omitempty"`
+	ExclusiveMinimum     any                    `json:"exclusiveMinimum,omitempty"`
+	Maximum              any                    `json:"maximum,omitempty"`
+	ExclusiveMaximum     any                    `json:"exclusiveMaximum,omitempty"`
+	MinLength            any                    `json:"minLength,omitempty"`
+	MaxLength            any                    `json:"maxLength,omitempty"`
+	MinItems             any                    `json:"minItems,omitempty"`
+	MaxItems             any                    `json:"maxItems,omitempty"`
+	WriteOnly            bool                   `json:"writeOnly,omitempty"`
+}
+
+// JSONSchema describes the YAML layout of T as an indented JSON Schema, for
+// editor autocompletion and validation of configuration files. It walks the
+// mapstructure, default and validate tags the same way bindEnvs walks T.
+func JSONSchema[T any]() ([]byte, error) {
+	var raw T
+	t := reflect.TypeOf(raw)
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config type %s is not a struct", t)
+	}
+
+	root := objectSchema(t, "")
+	root.Schema = schemaDraft
+	root.Title = t.Name()
+
+	out, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// objectSchema describes struct t, whose keys live under prefix.
+func objectSchema(t reflect.Type, prefix string) *jsonSchema {
+	closed := false
+	s := &jsonSchema{Type: "object", Properties: make(map[string]*jsonSchema), AdditionalProperties: &closed}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if field.PkgPath != "" || tag == "" || tag == "-" {
+			continue
+		}
+
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			s.Properties[tag] = objectSchema(field.Type, key)
+			continue
+		}
+
+		prop := fieldSchema(field, key)
+		s.Properties[tag] = prop
+		if prop.Default == nil && hasRule(field.Tag.Get("validate"), "required") {
+			s.Required = append(s.Required, tag)
+		}
+	}
+
+	return s
+}
+
+// fieldSchema describes a non-struct field.
+func fieldSchema(field reflect.StructField, key string) *jsonSchema {
+	s := typeSchema(field.Type)
+	s.Description = fieldNotes(field, key)
+	s.Default = typedValue(field.Type, field.Tag.Get("default"))
+	s.WriteOnly = field.Tag.Get("secret") == "true"
+
+	target := s
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		if name == "dive" && s.Items != nil {
+			target = s.Items
+			continue
+		}
+		applyRule(target, name, param)
+	}
+
+	return s
+}
+
+// typeSchema returns the JSON type of a Go type.
+func typeSchema(t reflect.Type) *jsonSchema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: typeSchema(t.Elem())}
+	default:
+		return &jsonSchema{Type: "string"}
+	}
+}
+
+// applyRule translates a validator rule into schema keywords. Rules without
+// a JSON Schema equivalent are left to the env var reference.
+func applyRule(s *jsonSchema, name, param string) {
+	switch name {
+	case "url", "uri":
+		s.Format = "uri"
+	case "email":
+		s.Format = "email"
+	case "oneof":
+		for _, v := range strings.Fields(param) {
+			if s.Type == "string" {
+				s.Enum = append(s.Enum, v)
+			} else {
+				s.Enum = append(s.Enum, number(v))
+			}
+		}
+	case "gte":
+		s.Minimum = number(param)
+	case "gt":
+		s.ExclusiveMinimum = number(param)
+	case "lte":
+		s.Maximum = number(param)
+	case "lt":
+		s.ExclusiveMaximum = number(param)
+	case "min", "max", "len":
+		switch s.Type {
+		case "string":
+			if name != "max" {
+				s.MinLength = number(param)
+			}
+			if name != "min" {
+				s.MaxLength = number(param)
+			}
+		case "array":
+			if name != "max" {
+				s.MinItems = number(param)
+			}
+			if name != "min" {
+				s.MaxItems = number(param)
+			}
+		default:
+			if name != "max" {
+				s.Minimum = number(param)
+			}
+			if name != "min" {
+				s.Maximum = number(param)
+			}
+		}
+	}
+}
+
+// fieldNotes describes where a key can be set and how it behaves.
+func fieldNotes(field reflect.StructField, key string) string {
+	notes := []string{"Environment variable " + envVar(key) + "."}
+	if field.Tag.Get("secret") == "true" {
+		notes = append(notes, "Secret; masked in output.")
+	}
+	if field.Tag.Get(reloadTag) == reloadRestart {
+		notes = append(notes, "Requires a restart.")
+	}
+	for _, rules := range envRules(field) {
+		notes = append(notes, "Enforced "+rules+".")
+	}
+	return strings.Join(notes, " ")
+}
+
+// hasRule reports whether the validate tag contains rule before any dive.
+func hasRule(tag, rule string) bool {
+	for _, r := range strings.Split(tag, ",") {
+		if r == "dive" {
+			return false
+		}
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// typedValue parses a tag value according to t, falling back to the string.
+func typedValue(t reflect.Type, value string) any {
+	if value == "" {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return number(value)
+	}
+	return value
+}
+
+// number returns param as a JSON number, or the string when it is not numeric.
+func number(param string) any {
+	if _, err := strconv.ParseFloat(param, 64); err == nil {
+		return json.Number(param)
+	}
+	return param
+}
+
+// EnvReference renders a Markdown table of every environment variable of T
+// with its YAML key, type, default and constraints.
+func EnvReference[T any]() string {
+	var raw T
+	var b strings.Builder
+
+	b.WriteString("| Variable | Key | Type | Default | Constraints | Notes |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	writeEnvRows(&b, reflect.TypeOf(raw), "")
+
+	b.WriteString("\nEvery variable can also be read from a file by appending `" + fileEnvSuffix + "` to its name, ")
+	b.WriteString("and every value may be given as `" + fileScheme + "/path`.\n")
+	return b.String()
+}
+
+// writeEnvRows writes one table row per non-struct field of t.
+func writeEnvRows(b *strings.Builder, t reflect.Type, prefix string) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if field.PkgPath != "" || tag == "" || tag == "-" {
+			continue
+		}
+
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			writeEnvRows(b, field.Type, key)
+			continue
+		}
+
+		var notes []string
+		if field.Tag.Get("secret") == "true" {
+			notes = append(notes, "secret")
+		}
+		if field.Tag.Get(reloadTag) == reloadRestart {
+			notes = append(notes, "restart")
+		}
+		for _, rules := range envRules(field) {
+			notes = append(notes, code(rules))
+		}
+
+		typ := typeSchema(field.Type)
+		typeName := typ.Type
+		if typ.Items != nil {
+			typeName = "array of " + typ.Items.Type
+		}
+
+		fmt.Fprintf(b, "| `%s` | `%s` | %s | %s | %s | %s |\n",
+			envVar(key), key, typeName,
+			code(field.Tag.Get("default")), code(field.Tag.Get("validate")), strings.Join(notes, ", "))
+	}
+}
+
+// code wraps a non-empty table cell value in backticks.
+func code(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJSONSchema verifies tags are translated into schema keywords.
+func TestJSONSchema(t *testing.T) {
+	out, err := JSONSchema[secretStruct]()
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(out, &schema))
+	assert.Equal(t, schemaDraft, schema["$schema"])
+	assert.Equal(t, "secretStruct", schema["title"])
+	assert.Equal(t, false, schema["additionalProperties"])
+	assert.ElementsMatch(t, []any{"name", "api_key"}, schema["required"])
+
+	props := schema["properties"].(map[string]any)
+	apiKey := props["api_key"].(map[string]any)
+	assert.Equal(t, true, apiKey["writeOnly"])
+	assert.Equal(t, float64(40), apiKey["minLength"])
+	assert.Contains(t, apiKey["description"], "API_KEY")
+
+	db := props["database"].(map[string]any)["properties"].(map[string]any)
+	pool := db["db_max_pool"].(map[string]any)
+	assert.Equal(t, "integer", pool["type"])
+	assert.Equal(t, float64(20), pool["default"])
+	assert.Equal(t, float64(1), pool["minimum"])
+	assert.Equal(t, float64(100), pool["maximum"])
+	assert.Equal(t, "Environment variable DATABASE_DB_MAX_POOL. Requires a restart.", pool["description"])
+
+	replicas := db["db_replica_urls"].(map[string]any)
+	assert.Equal(t, "array", replicas["type"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "uri"}, replicas["items"])
+	assert.Contains(t, replicas["description"], "Enforced in production: dive,sslmode_enabled.")
+
+	debug := db["db_debug"].(map[string]any)
+	assert.Equal(t, false, debug["default"])
+}
+
+// TestJSONSchema_Enum verifies oneof rules become enums.
+func TestJSONSchema_Enum(t *testing.T) {
+	out, err := JSONSchema[GlobalConfig]()
+	require.NoError(t, err)
+
+	var schema struct {
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			Enum []string `json:"enum"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(out, &schema))
+	assert.Equal(t, []string{"local", "dev", "staging", "production"}, schema.Properties["app_env"].Enum)
+	assert.Empty(t, schema.Required, "every required field has a default")
+}
+
+// TestEnvReference verifies every variable is listed with its default and constraints.
+func TestEnvReference(t *testing.T) {
+	out := EnvReference[secretStruct]()
+
+	assert.Contains(t, out, "| `NAME` | `name` | string |  | `required` |  |\n")
+	assert.Contains(t, out, "| `API_KEY` | `api_key` | string |  | `required,min=40` | secret |\n")
+	assert.Contains(t, out, "| `DATABASE_DB_MAX_POOL` | `database.db_max_pool` | integer | `20` | `gte=1,lte=100` | restart |\n")
+	assert.Contains(t, out, "| `DATABASE_DB_REPLICA_URLS` | `database.db_replica_urls` | array of string |  | `dive,url` | restart, `in production: dive,sslmode_enabled` |\n")
+	assert.Contains(t, out, "`_FILE`")
+}
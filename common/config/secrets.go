@@ -0,0 +1,286 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// Masked replaces secret values wherever configuration is rendered.
+	Masked = "***"
+
+	// fileScheme marks a value to be read from the named file.
+	fileScheme = "file://"
+
+	// fileEnvSuffix marks an environment variable holding the path of a file
+	// whose content is the value, e.g. DATABASE_URL_FILE.
+	fileEnvSuffix = "_FILE"
+)
+
+// kvPasswordPattern matches the password of a key=value connection string.
+var kvPasswordPattern = regexp.MustCompile(`(?i)(password\s*=\s*)('[^']*'|\S+)`)
+
+// readSecretFile returns the content of path without trailing newlines,
+// as mounted secrets usually end with one.
+func readSecretFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// bindFileEnvs sets every key whose <ENV>_FILE variable is present to the
+// content of that file. It walks t like bindEnvs.
+func bindFileEnvs(v *viper.Viper, prefix string, t reflect.Type) error {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if field.PkgPath != "" || tag == "" || tag == "-" {
+			continue
+		}
+
+		fullKey := tag
+		if prefix != "" {
+			fullKey = prefix + "." + tag
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := bindFileEnvs(v, fullKey, field.Type); err != nil {
+				return err
+			}
+			continue
+		}
+
+		envKey := strings.ToUpper(strings.ReplaceAll(fullKey, ".", "_")) + fileEnvSuffix
+		path, ok := os.LookupEnv(envKey)
+		if !ok {
+			continue
+		}
+		value, err := readSecretFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", envKey, err)
+		}
+		v.Set(fullKey, value)
+	}
+
+	return nil
+}
+
+// resolveFileRefs replaces string values of the form file:///path with the
+// content of that file, recursing into nested structs and string slices.
+func resolveFileRefs(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if field.PkgPath != "" || tag == "" || tag == "-" {
+			continue
+		}
+
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.Struct:
+			if err := resolveFileRefs(fv, key); err != nil {
+				return err
+			}
+		case reflect.String:
+			if err := resolveFileRef(fv, key); err != nil {
+				return err
+			}
+		case reflect.Slice:
+			if fv.Type().Elem().Kind() != reflect.String {
+				continue
+			}
+			for j := 0; j < fv.Len(); j++ {
+				if err := resolveFileRef(fv.Index(j), key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveFileRef loads a single file:// string value in place.
+func resolveFileRef(v reflect.Value, key string) error {
+	path, ok := strings.CutPrefix(v.String(), fileScheme)
+	if !ok {
+		return nil
+	}
+	value, err := readSecretFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	v.SetString(value)
+	return nil
+}
+
+// MaskDSN hides the password of a URL or key=value connection string.
+// Other strings are returned unchanged.
+func MaskDSN(s string) string {
+	if u, err := url.Parse(s); err == nil && u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			user := url.User(u.User.Username()).String()
+			u.User = url.User(u.User.Username())
+			return strings.Replace(u.String(), "//"+user+"@", "//"+user+":"+Masked+"@", 1)
+		}
+		return s
+	}
+	return kvPasswordPattern.ReplaceAllString(s, "${1}"+Masked)
+}
+
+// Redact returns cfg as a map keyed by mapstructure names, safe to print or
+// log: fields tagged secret:"true" become Masked and connection string
+// passwords are hidden.
+func Redact(cfg any) map[string]any {
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	out := make(map[string]any)
+	redactInto(out, v)
+	return out
+}
+
+// redactInto copies the fields of struct v into out. Embedded structs with a
+// mapstructure tag become nested maps, like their YAML representation.
+func redactInto(out map[string]any, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if field.PkgPath != "" || tag == "" || tag == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		switch {
+		case field.Tag.Get("secret") == "true":
+			out[tag] = Masked
+		case fv.Kind() == reflect.Struct:
+			nested := make(map[string]any)
+			redactInto(nested, fv)
+			out[tag] = nested
+		case fv.Kind() == reflect.String:
+			out[tag] = MaskDSN(fv.String())
+		case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+			masked := make([]string, fv.Len())
+			for j := range masked {
+				masked[j] = MaskDSN(fv.Index(j).String())
+			}
+			out[tag] = masked
+		default:
+			out[tag] = fv.Interface()
+		}
+	}
+}
+
+// String renders cfg as sorted key=value pairs with secrets redacted.
+func String(cfg any) string {
+	var b strings.Builder
+	writeRedacted(&b, "", Redact(cfg))
+	return strings.TrimSuffix(b.String(), " ")
+}
+
+// writeRedacted flattens m into dotted key=value pairs.
+func writeRedacted(b *strings.Builder, prefix string, m map[string]any) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := m[k].(map[string]any); ok {
+			writeRedacted(b, key, nested)
+			continue
+		}
+		fmt.Fprintf(b, "%s=%v ", key, m[k])
+	}
+}
+
+// secretValues collects the raw values of v that must never appear in
+// errors: secret fields and connection strings carrying a password.
+func secretValues(v reflect.Value) []string {
+	var secrets []string
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		if field.PkgPath != "" || tag == "" || tag == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		var raw []string
+		switch {
+		case fv.Kind() == reflect.Struct:
+			secrets = append(secrets, secretValues(fv)...)
+		case fv.Kind() == reflect.String:
+			raw = []string{fv.String()}
+		case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String:
+			for j := 0; j < fv.Len(); j++ {
+				raw = append(raw, fv.Index(j).String())
+			}
+		}
+
+		for _, r := range raw {
+			if r != "" && (field.Tag.Get("secret") == "true" || MaskDSN(r) != r) {
+				secrets = append(secrets, r)
+			}
+		}
+	}
+	return secrets
+}
+
+// redactError rewrites err so none of the secret values appear in its message.
+func redactError(err error, secrets []string) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	for _, s := range secrets {
+		msg = strings.ReplaceAll(msg, s, Masked)
+	}
+	if msg == err.Error() {
+		return err
+	}
+	return &redactedError{msg: msg, err: err}
+}
+
+// redactedError carries a sanitized message while keeping the original error
+// reachable through errors.Is and errors.As.
+type redactedError struct {
+	msg string
+	err error
+}
+
+// Error implements error.
+func (e *redactedError) Error() string { return e.msg }
+
+// Unwrap returns the original error.
+func (e *redactedError) Unwrap() error { return e.err }
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Fields tagged reload:"restart" only take effect at startup. On reload their
+// previous value is kept and the change is reported as requiring a restart.
+const (
+	reloadTag     = "reload"
+	reloadRestart = "restart"
+)
+
+// Watcher holds a configuration of type T that is reloaded whenever its
+// YAML file changes. Reloads that fail validation are rejected and the
+// previous configuration stays in effect.
+type Watcher[T any] struct {
+	// v reads the files and environment.
+	v *viper.Viper
+
+	// layers locates the files merged into the configuration. The environment
+	// overlay is fixed at startup like the app_env it is derived from.
+	layers layers
+
+	// current is the configuration in effect.
+	current atomic.Pointer[T]
+
+	// mu serializes reloads and subscriber registration.
+	mu sync.Mutex
+
+	// subscribers are notified, in registration order, after each applied reload.
+	subscribers []func(old, new *T)
+}
+
+// Watch loads configuration like Load and starts watching the directories
+// of its layers, so edits to any layer, including files added to conf.d, are
+// picked up. When no file exists only the initial configuration is served and
+// nothing is watched.
+func Watch[T any](configPath string) (*Watcher[T], bool, error) {
+	w, fileFound, err := newWatcher[T](configPath)
+	if err != nil {
+		return nil, fileFound, err
+	}
+
+	if fileFound {
+		if err := w.watch(); err != nil {
+			return nil, fileFound, err
+		}
+	}
+
+	return w, fileFound, nil
+}
+
+// newWatcher loads the initial configuration without watching the files.
+func newWatcher[T any](configPath string) (*Watcher[T], bool, error) {
+	v, l, files, err := newViper[T](configPath)
+	if err != nil {
+		return nil, len(files) > 0, err
+	}
+
+	cfg, err := decode[T](v)
+	if err != nil {
+		return nil, len(files) > 0, err
+	}
+
+	w := &Watcher[T]{v: v, layers: l}
+	w.current.Store(cfg)
+	return w, len(files) > 0, nil
+}
+
+// watch reloads the configuration whenever a file changes in the layer
+// directories. Directories are watched rather than files so atomic renames
+// and Kubernetes ConfigMap symlink swaps are noticed.
+func (w *Watcher[T]) watch() error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range w.layers.dirs() {
+		if err := fsw.Add(dir); err != nil {
+			_ = fsw.Close()
+			return err
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-fsw.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Write) || event.Has(fsnotify.Create) || event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+					_ = w.Reload()
+				}
+			case err, ok := <-fsw.Errors:
+				if !ok {
+					return
+				}
+				zap.S().Warnw("configuration watch error", "error", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Get returns the configuration in effect. The returned value must not be modified.
+func (w *Watcher[T]) Get() *T {
+	return w.current.Load()
+}
+
+// Subscribe registers fn to be called with the previous and new configuration
+// after every applied reload.
+func (w *Watcher[T]) Subscribe(fn func(old, new *T)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload re-reads and validates the configuration, swaps it in and notifies
+// subscribers. Invalid configurations are rejected and returned as an error.
+// Changes to fields that require a restart are reported and not applied.
+func (w *Watcher[T]) Reload() error {
+	prev, next, subscribers, err := w.swap()
+	if err != nil || next == nil {
+		return err
+	}
+
+	// Subscribers run outside the lock so they may call Subscribe or Get.
+	for _, fn := range subscribers {
+		fn(prev, next)
+	}
+	return nil
+}
+
+// swap applies a reload under the lock and returns the previous and new
+// configuration with the subscribers to notify. next is nil when nothing changed.
+func (w *Watcher[T]) swap() (prev, next *T, subscribers []func(old, new *T), err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	log := zap.S()
+
+	if _, err := w.layers.read(w.v); err != nil {
+		log.Errorw("configuration reload rejected", "file", w.v.ConfigFileUsed(), "error", err)
+		return nil, nil, nil, err
+	}
+
+	next, err = decode[T](w.v)
+	if err != nil {
+		log.Errorw("configuration reload rejected", "file", w.v.ConfigFileUsed(), "error", err)
+		return nil, nil, nil, err
+	}
+
+	prev = w.current.Load()
+	if restart := keepRestartFields(reflect.ValueOf(prev).Elem(), reflect.ValueOf(next).Elem(), ""); len(restart) > 0 {
+		log.Warnw("configuration changes require a restart and were not applied", "fields", restart)
+	}
+
+	if reflect.DeepEqual(prev, next) {
+		return nil, nil, nil, nil
+	}
+
+	w.current.Store(next)
+	log.Infow("configuration reloaded", "file", w.v.ConfigFileUsed())
+
+	return prev, next, slices.Clone(w.subscribers), nil
+}
+
+// keepRestartFields copies every restart-only field of prev into next and
+// returns the keys of those whose value had changed.
+func keepRestartFields(prev, next reflect.Value, prefix string) []string {
+	var changed []string
+
+	t := prev.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := field.Tag.Get("mapstructure")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+
+		pv, nv := prev.Field(i), next.Field(i)
+		switch {
+		case field.Tag.Get(reloadTag) == reloadRestart:
+			if !reflect.DeepEqual(pv.Interface(), nv.Interface()) {
+				changed = append(changed, key)
+				nv.Set(pv)
+			}
+		case field.Type.Kind() == reflect.Struct:
+			changed = append(changed, keepRestartFields(pv, nv, key)...)
+		}
+	}
+
+	return changed
+}
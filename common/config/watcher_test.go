@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfig writes YAML content to path.
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+// TestWatcher_ReloadNotifiesSubscribers verifies a valid change is swapped in and announced.
+func TestWatcher_ReloadNotifiesSubscribers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "service_name: contacts\nlog_level: info\n")
+
+	w, found, err := newWatcher[GlobalConfig](path)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "info", w.Get().LogLevel)
+
+	var oldLevel, newLevel string
+	w.Subscribe(func(old, new *GlobalConfig) {
+		oldLevel, newLevel = old.LogLevel, new.LogLevel
+	})
+
+	writeConfig(t, path, "service_name: contacts\nlog_level: debug\n")
+	require.NoError(t, w.Reload())
+
+	assert.Equal(t, "debug", w.Get().LogLevel)
+	assert.Equal(t, "info", oldLevel)
+	assert.Equal(t, "debug", newLevel)
+}
+
+// TestWatcher_SubscriberMaySubscribe verifies subscribers are notified outside
+// the watcher lock, so they can register further subscribers without deadlocking.
+func TestWatcher_SubscriberMaySubscribe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "service_name: contacts\nlog_level: info\n")
+
+	w, _, err := newWatcher[GlobalConfig](path)
+	require.NoError(t, err)
+
+	var nested atomic.Int32
+	w.Subscribe(func(_, _ *GlobalConfig) {
+		w.Subscribe(func(_, _ *GlobalConfig) { nested.Add(1) })
+	})
+
+	writeConfig(t, path, "service_name: contacts\nlog_level: debug\n")
+	done := make(chan error, 1)
+	go func() { done <- w.Reload() }()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload deadlocked")
+	}
+	assert.Zero(t, nested.Load(), "subscribers added during a reload wait for the next one")
+}
+
+// TestWatcher_RejectsInvalidReload verifies invalid configurations keep the previous one in effect.
+func TestWatcher_RejectsInvalidReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "service_name: contacts\nlog_level: info\n")
+
+	w, _, err := newWatcher[GlobalConfig](path)
+	require.NoError(t, err)
+
+	var calls int
+	w.Subscribe(func(_, _ *GlobalConfig) { calls++ })
+
+	writeConfig(t, path, "service_name: contacts\napp_env: nowhere\nlog_level: debug\n")
+	require.Error(t, w.Reload())
+
+	assert.Equal(t, "info", w.Get().LogLevel)
+	assert.Zero(t, calls)
+}
+
+// TestWatcher_KeepsRestartFields verifies restart-only fields keep their startup value.
+func TestWatcher_KeepsRestartFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "service_name: contacts\nport: 8081\nlog_level: info\n")
+
+	w, _, err := newWatcher[GlobalConfig](path)
+	require.NoError(t, err)
+
+	var calls int
+	w.Subscribe(func(_, _ *GlobalConfig) { calls++ })
+
+	writeConfig(t, path, "service_name: contacts\nport: 9999\nlog_level: info\n")
+	require.NoError(t, w.Reload())
+	assert.Equal(t, 8081, w.Get().Port)
+	assert.Zero(t, calls, "nothing applicable changed")
+
+	writeConfig(t, path, "service_name: contacts\nport: 9999\nlog_level: warn\n")
+	require.NoError(t, w.Reload())
+	assert.Equal(t, 8081, w.Get().Port)
+	assert.Equal(t, "warn", w.Get().LogLevel)
+	assert.Equal(t, 1, calls)
+}
+
+// TestKeepRestartFields_Nested verifies restart fields inside nested structs are reported by key.
+func TestKeepRestartFields_Nested(t *testing.T) {
+	type appConfig struct {
+		GlobalConfig `mapstructure:"server"`
+		RateLimit    RateLimitConfig `mapstructure:"contacts"`
+	}
+
+	prev := appConfig{GlobalConfig: GlobalConfig{Port: 1}, RateLimit: RateLimitConfig{Store: "memory", Requests: 1}}
+	next := appConfig{GlobalConfig: GlobalConfig{Port: 2}, RateLimit: RateLimitConfig{Store: "postgres", Requests: 5}}
+
+	changed := keepRestartFields(reflectValue(&prev), reflectValue(&next), "")
+	assert.ElementsMatch(t, []string{"server.port", "contacts.rate_limit_store"}, changed)
+	assert.Equal(t, 1, next.Port)
+	assert.Equal(t, "memory", next.RateLimit.Store)
+	assert.Equal(t, 5, next.RateLimit.Requests)
+}
+
+// TestWatch_FileChange verifies edits on disk are picked up automatically.
+func TestWatch_FileChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "service_name: contacts\nlog_level: info\n")
+
+	w, _, err := Watch[GlobalConfig](path)
+	require.NoError(t, err)
+
+	var reloaded atomic.Bool
+	w.Subscribe(func(_, _ *GlobalConfig) { reloaded.Store(true) })
+
+	writeConfig(t, path, "service_name: contacts\nlog_level: error\n")
+	assert.Eventually(t, reloaded.Load, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, "error", w.Get().LogLevel)
+}
+
+// reflectValue returns the addressable struct behind ptr.
+func reflectValue(ptr any) reflect.Value {
+	return reflect.ValueOf(ptr).Elem()
+}
@@ -9,6 +9,8 @@ import (
 // DB defines a contract for PostgreSQL operations.
 // It can be implemented by real or mock database clients.
 type DB interface {
+	Transactor
+
 	// Exec executes a query without returning rows.
 	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
 
@@ -17,4 +19,29 @@ type DB interface {
 
 	// QueryRow executes a query and returns a single row.
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+
+	// BeginTx starts a transaction. When ctx already carries a transaction a
+	// savepoint nested in it is returned instead.
+	BeginTx(ctx context.Context, opts TxOptions) (Tx, error)
+}
+
+// Transactor runs a unit of work inside a transaction.
+// Services depend on this contract rather than on DB so they stay storage agnostic.
+type Transactor interface {
+	// WithTx runs fn inside a transaction carried by the context passed to it.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	WithTx(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error
+}
+
+// Tx is an open transaction or savepoint.
+// Beginning a transaction on a Tx creates a savepoint.
+type Tx interface {
+	DB
+
+	// Commit commits the transaction or releases the savepoint.
+	Commit(ctx context.Context) error
+
+	// Rollback aborts the transaction or rolls back to the savepoint.
+	// Calling it after Commit is a no-op.
+	Rollback(ctx context.Context) error
 }
@@ -2,55 +2,320 @@ package database
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/flockstore/mannaiah-backend/common/config"
+	"github.com/flockstore/mannaiah-backend/common/logger"
 	"github.com/jackc/pgx/v5/pgconn"
+	"math"
+	"sync/atomic"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Backoff bounds for startup connection attempts and statement retries.
+const (
+	connectBaseDelay = 250 * time.Millisecond
+	connectMaxDelay  = 5 * time.Second
+	queryBaseDelay   = 50 * time.Millisecond
+	queryMaxDelay    = time.Second
+)
+
+// Postgres SQLSTATE codes returned while a server cannot accept connections yet.
+const (
+	sqlStateCannotConnectNow   = "57P03"
+	sqlStateTooManyConnections = "53300"
 )
 
 // PgxClient is a wrapper around pgxpool.Pool implementing DB interface.
+// When replicas are configured, plain reads are spread across them while
+// writes and transactions stay on the primary pool.
 type PgxClient struct {
-	// Pool is the internal pgx connection pool.
+	// Pool is the internal pgx connection pool for the primary.
 	Pool *pgxpool.Pool
+
+	// replicas routes reads to read replicas; nil when none are configured.
+	replicas *replicaSet
+
+	// retry reruns statements that failed with a transient error.
+	retry RetryPolicy
+
+	// connectTimeout bounds how long Wait retries an unreachable primary.
+	connectTimeout time.Duration
+
+	// ready is set once the primary has answered a ping.
+	ready atomic.Bool
 }
 
-// Connect creates a new PostgreSQL connection pool using pgx.
-func Connect(ctx context.Context, cfg config.DatabaseConfig) (*PgxClient, error) {
-	pgxCfg, err := pgxpool.ParseConfig(cfg.DatabaseURL)
+// Connect opens the connection pools and waits until the primary answers,
+// retrying with backoff for up to DatabaseConfig.ConnectTimeout.
+func Connect(ctx context.Context, cfg config.DatabaseConfig, reg prometheus.Registerer) (*PgxClient, error) {
+	client, err := Open(ctx, cfg, reg)
 	if err != nil {
 		return nil, err
 	}
 
-	pgxCfg.MaxConns = int32(cfg.MaxPool)
-	pgxCfg.MinConns = int32(cfg.MinIdle)
-	pgxCfg.MaxConnLifetime = time.Duration(cfg.MaxConnLifetime) * time.Second
+	if err := client.Wait(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// Open creates a new PostgreSQL connection pool using pgx, plus one pool per
+// configured read replica, without waiting for the servers to be reachable.
+// Call Wait before relying on the client, or use Ready to report readiness.
+// Query metrics are registered with reg; nil disables them.
+func Open(ctx context.Context, cfg config.DatabaseConfig, reg prometheus.Registerer) (*PgxClient, error) {
+	var metrics *QueryMetrics
+	if reg != nil {
+		metrics = NewQueryMetrics(reg)
+	}
+
+	pool, err := newPool(ctx, cfg.DatabaseURL, cfg, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &PgxClient{
+		Pool:           pool,
+		retry:          RetryPolicy{MaxRetries: cfg.QueryRetries, BaseDelay: queryBaseDelay, MaxDelay: queryMaxDelay},
+		connectTimeout: time.Duration(cfg.ConnectTimeout) * time.Second,
+	}
+	if len(cfg.ReplicaURLs) == 0 {
+		return client, nil
+	}
+
+	replicas := make([]*replica, 0, len(cfg.ReplicaURLs))
+	for i, dsn := range cfg.ReplicaURLs {
+		rp, err := newPool(ctx, dsn, cfg, metrics)
+		if err != nil {
+			for _, r := range replicas {
+				r.q.Close()
+			}
+			pool.Close()
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+		host := rp.Config().ConnConfig
+		replicas = append(replicas, &replica{name: fmt.Sprintf("%s:%d", host.Host, host.Port), q: rp})
+	}
+
+	client.replicas = newReplicaSet(replicas,
+		time.Duration(cfg.ReplicaMaxLag)*time.Second,
+		time.Duration(cfg.ReplicaCheckInterval)*time.Second,
+		cfg.ReadYourWrites,
+	)
+	go client.replicas.run()
+
+	return client, nil
+}
+
+// Wait pings the primary until it answers, backing off with jitter between
+// attempts. It gives up when the connect timeout elapses, ctx is cancelled
+// or the server rejects the connection for a permanent reason such as bad
+// credentials.
+func (c *PgxClient) Wait(ctx context.Context) error {
+	if c.connectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.connectTimeout)
+		defer cancel()
+	}
+
+	policy := RetryPolicy{MaxRetries: math.MaxInt32, BaseDelay: connectBaseDelay, MaxDelay: connectMaxDelay}
+	attempt := 0
+	err := policy.Do(ctx, isStartupRetryable, func() error {
+		attempt++
+		err := c.Pool.Ping(ctx)
+		if err != nil && isStartupRetryable(err) {
+			logger.FromContext(ctx).Warnw("database not reachable, retrying", "attempt", attempt, "error", err)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("database not reachable after %d attempts: %w", attempt, err)
+	}
+
+	if c.replicas != nil {
+		c.replicas.check(ctx)
+	}
+	c.ready.Store(true)
+	return nil
+}
+
+// Ready reports whether the primary has answered since the client was opened.
+func (c *PgxClient) Ready() bool {
+	return c.ready.Load()
+}
+
+// isStartupRetryable reports whether a failed connection attempt may succeed
+// later: the server is unreachable, still starting or out of connections.
+func isStartupRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == sqlStateCannotConnectNow || pgErr.Code == sqlStateTooManyConnections
+	}
+	return isConnectionError(err)
+}
 
-	pool, err := pgxpool.NewWithConfig(ctx, pgxCfg)
+// newPool opens a pool for dsn using the shared pool settings from cfg,
+// recording query metrics into metrics when set.
+func newPool(ctx context.Context, dsn string, cfg config.DatabaseConfig, metrics *QueryMetrics) (*pgxpool.Pool, error) {
+	pgxCfg, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	return &PgxClient{Pool: pool}, nil
+	pgxCfg.MaxConns = int32(cfg.MaxPool)
+	pgxCfg.MinConns = int32(cfg.MinIdle)
+	pgxCfg.MaxConnLifetime = time.Duration(cfg.MaxConnLifetime) * time.Second
+	pgxCfg.ConnConfig.Tracer = &Tracer{
+		Debug:         cfg.Debug,
+		SlowThreshold: time.Duration(cfg.SlowQueryThreshold) * time.Millisecond,
+		Metrics:       metrics,
+	}
+
+	return pgxpool.NewWithConfig(ctx, pgxCfg)
 }
 
-// Close shuts down the connection pool gracefully.
+// Close shuts down the connection pools gracefully.
 func (c *PgxClient) Close() {
+	if c.replicas != nil {
+		c.replicas.close()
+	}
 	c.Pool.Close()
 }
 
+// reader returns the replica that should serve sql, or nil for the primary.
+// Queries sent to the primary pin the request there for read-your-writes.
+func (c *PgxClient) reader(ctx context.Context, sql string) *replica {
+	if c.replicas == nil {
+		return nil
+	}
+	if r := c.replicas.route(ctx, sql); r != nil {
+		return r
+	}
+	if !isReadOnlyStatement(sql) {
+		c.replicas.pin(ctx)
+	}
+	return nil
+}
+
 // Exec executes a query without returning rows.
+// The query joins the transaction carried by ctx, if any. Outside a
+// transaction it is retried when the server did not apply it.
 func (c *PgxClient) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
-	return c.Pool.Exec(ctx, sql, args...)
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.Exec(ctx, sql, args...)
+	}
+	if c.replicas != nil {
+		c.replicas.pin(ctx)
+	}
+
+	var tag pgconn.CommandTag
+	err := c.retry.Do(ctx, isTransientWrite, func() (err error) {
+		tag, err = c.Pool.Exec(ctx, sql, args...)
+		return err
+	})
+	return tag, err
 }
 
 // Query executes a query that returns multiple rows.
+// The query joins the transaction carried by ctx, if any. Otherwise it may be
+// served by a replica, falling back to the primary if it is unreachable, and
+// is retried on transient errors.
 func (c *PgxClient) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.Query(ctx, sql, args...)
+	}
+
+	var rows pgx.Rows
+	err := c.retry.Do(ctx, retryableFor(sql), func() (err error) {
+		rows, err = c.query(ctx, sql, args...)
+		return err
+	})
+	return rows, err
+}
+
+// query runs a single attempt of Query outside a transaction.
+func (c *PgxClient) query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if r := c.reader(ctx, sql); r != nil {
+		rows, err := r.q.Query(ctx, sql, args...)
+		if !isConnectionError(err) {
+			return rows, err
+		}
+		c.replicas.markDown(ctx, r, err)
+	}
 	return c.Pool.Query(ctx, sql, args...)
 }
 
 // QueryRow executes a query that returns a single row.
+// The query joins the transaction carried by ctx, if any. Otherwise it may be
+// served by a replica, falling back to the primary if it is unreachable, and
+// is retried on transient errors when scanned.
 func (c *PgxClient) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.QueryRow(ctx, sql, args...)
+	}
+	return &retryRow{
+		ctx:       ctx,
+		policy:    c.retry,
+		retryable: retryableFor(sql),
+		query:     func() pgx.Row { return c.queryRow(ctx, sql, args...) },
+	}
+}
+
+// queryRow runs a single attempt of QueryRow outside a transaction.
+func (c *PgxClient) queryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if r := c.reader(ctx, sql); r != nil {
+		return &failoverRow{
+			row: r.q.QueryRow(ctx, sql, args...),
+			fallback: func(err error) pgx.Row {
+				c.replicas.markDown(ctx, r, err)
+				return c.Pool.QueryRow(ctx, sql, args...)
+			},
+		}
+	}
 	return c.Pool.QueryRow(ctx, sql, args...)
 }
+
+// retryableFor returns the transient error check matching the statement:
+// reads may be rerun after any connection failure, writes only when the
+// server did not apply them.
+func retryableFor(sql string) func(error) bool {
+	if isReadOnlyStatement(sql) {
+		return isTransientRead
+	}
+	return isTransientWrite
+}
+
+// BeginTx starts a transaction on the primary pool, or a savepoint when ctx
+// already carries a transaction.
+func (c *PgxClient) BeginTx(ctx context.Context, opts TxOptions) (Tx, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.BeginTx(ctx, opts)
+	}
+	if c.replicas != nil && !opts.ReadOnly {
+		c.replicas.pin(ctx)
+	}
+
+	tx, err := c.Pool.BeginTx(ctx, opts.pgx())
+	if err != nil {
+		return nil, err
+	}
+	return &pgxTx{tx: tx}, nil
+}
+
+// WithTx runs fn inside a transaction, retrying it on serialization failures
+// and deadlocks. When ctx already carries a transaction fn runs in a savepoint.
+func (c *PgxClient) WithTx(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithTx(ctx, opts, fn)
+	}
+
+	return withRetry(ctx, opts.retries(), func() error {
+		return runTx(ctx, c.BeginTx, opts, fn)
+	})
+}
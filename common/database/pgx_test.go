@@ -15,7 +15,7 @@ func TestConnect_InvalidDSN(t *testing.T) {
 		DatabaseURL: "invalid-dsn",
 	}
 
-	client, err := Connect(ctx, cfg)
+	client, err := Connect(ctx, cfg, nil)
 	require.Nil(t, client)
 	require.Error(t, err)
 }
@@ -32,7 +32,7 @@ func TestConnect_ValidMockConfig(t *testing.T) {
 		MaxConnLifetime: 60,
 	}
 
-	client, err := Connect(ctx, cfg)
+	client, err := Connect(ctx, cfg, nil)
 	require.NoError(t, err)
 	require.NotNil(t, client)
 
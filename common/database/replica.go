@@ -0,0 +1,280 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// replicaLagQuery reports whether the server is a standby and how far behind
+// the primary it is. A standby that has replayed everything it received counts
+// as caught up only while its WAL receiver is streaming, so an idle primary
+// does not look like lag but a standby cut off from its primary does. The lag
+// is NULL when it cannot be determined, e.g. before anything was replayed.
+//
+// pg_stat_wal_receiver only shows its status to roles with pg_read_all_stats;
+// without it an idle standby is measured from its last replayed transaction.
+const replicaLagQuery = `
+SELECT pg_is_in_recovery(),
+       CASE
+           WHEN NOT pg_is_in_recovery() THEN 0
+           WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn()
+                AND EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE status = 'streaming') THEN 0
+           ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+       END::float8`
+
+// errUnknownLag reports a standby whose replication lag cannot be measured.
+var errUnknownLag = errors.New("replication lag unknown")
+
+// querier is the subset of a connection pool used to serve reads.
+type querier interface {
+	// Exec executes a query without returning rows.
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+
+	// Query executes a query and returns multiple rows.
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+
+	// QueryRow executes a query and returns a single row.
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+
+	// Close releases the pool's connections.
+	Close()
+}
+
+// routeKey is the context key holding an explicit routing decision.
+type routeKey struct{}
+
+// route is an explicit routing decision attached to a context.
+type route int
+
+const (
+	// routeReplica sends queries to a replica even when they are not plain SELECTs.
+	routeReplica route = iota + 1
+	// routePrimary keeps queries on the primary.
+	routePrimary
+)
+
+// WithReadOnly marks queries made with ctx as read-only so they may be served
+// by a replica. Writes issued through Exec still go to the primary.
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routeKey{}, routeReplica)
+}
+
+// WithPrimary forces queries made with ctx onto the primary, for reads that
+// cannot tolerate replication lag.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routeKey{}, routePrimary)
+}
+
+// routeFrom returns the routing decision attached to ctx, if any.
+func routeFrom(ctx context.Context) route {
+	r, _ := ctx.Value(routeKey{}).(route)
+	return r
+}
+
+// isReadOnlyStatement reports whether sql is a plain SELECT that neither
+// writes nor takes row locks, and may therefore run on a replica.
+func isReadOnlyStatement(sql string) bool {
+	s := strings.ToLower(strings.TrimLeft(sql, " \t\r\n("))
+	if !strings.HasPrefix(s, "select") {
+		return false
+	}
+	for _, lock := range []string{" for update", " for no key update", " for share", " for key share"} {
+		if strings.Contains(s, lock) {
+			return false
+		}
+	}
+	return true
+}
+
+// replica is a read replica with its last observed health.
+type replica struct {
+	// name identifies the replica in logs without exposing credentials.
+	name string
+	// q serves queries routed to the replica.
+	q querier
+	// healthy is false while the replica is unreachable or lagging.
+	healthy atomic.Bool
+}
+
+// replicaSet routes reads across replicas and tracks their health.
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+	maxLag   time.Duration
+	interval time.Duration
+
+	// readYourWrites enables pinning requests to the primary after a write.
+	readYourWrites bool
+	// pins maps request IDs to the time their primary pin expires.
+	pins sync.Map
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newReplicaSet creates a set whose replicas start out healthy.
+func newReplicaSet(replicas []*replica, maxLag, interval time.Duration, readYourWrites bool) *replicaSet {
+	for _, r := range replicas {
+		r.healthy.Store(true)
+	}
+	return &replicaSet{
+		replicas:       replicas,
+		maxLag:         maxLag,
+		interval:       interval,
+		readYourWrites: readYourWrites,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// pick returns the next healthy replica in round-robin order, or nil when
+// none is available and reads must fall back to the primary.
+func (s *replicaSet) pick() *replica {
+	n := uint64(len(s.replicas))
+	start := s.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		r := s.replicas[(start+i)%n]
+		if r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// route returns the replica that should serve sql, or nil for the primary.
+func (s *replicaSet) route(ctx context.Context, sql string) *replica {
+	switch routeFrom(ctx) {
+	case routePrimary:
+		return nil
+	case routeReplica:
+	default:
+		if !isReadOnlyStatement(sql) {
+			return nil
+		}
+	}
+	if s.pinned(ctx) {
+		return nil
+	}
+	return s.pick()
+}
+
+// pin keeps the request carried by ctx on the primary for the lag budget,
+// after which any replica serving it has caught up with the write.
+func (s *replicaSet) pin(ctx context.Context) {
+	if !s.readYourWrites {
+		return
+	}
+	if id := reqctx.RequestID(ctx); id != "" {
+		s.pins.Store(id, time.Now().Add(s.maxLag))
+	}
+}
+
+// pinned reports whether the request carried by ctx has recently written.
+func (s *replicaSet) pinned(ctx context.Context) bool {
+	id := reqctx.RequestID(ctx)
+	if id == "" {
+		return false
+	}
+	until, ok := s.pins.Load(id)
+	return ok && time.Now().Before(until.(time.Time))
+}
+
+// prunePins drops expired pins.
+func (s *replicaSet) prunePins(now time.Time) {
+	s.pins.Range(func(key, until any) bool {
+		if !now.Before(until.(time.Time)) {
+			s.pins.Delete(key)
+		}
+		return true
+	})
+}
+
+// check probes every replica and updates its health.
+func (s *replicaSet) check(ctx context.Context) {
+	for _, r := range s.replicas {
+		var standby bool
+		var lagSeconds *float64
+
+		err := r.q.QueryRow(ctx, replicaLagQuery).Scan(&standby, &lagSeconds)
+		if err == nil && lagSeconds == nil {
+			err = errUnknownLag
+		}
+
+		var lag time.Duration
+		if err == nil {
+			lag = time.Duration(*lagSeconds * float64(time.Second))
+		}
+		healthy := err == nil && lag <= s.maxLag
+
+		if was := r.healthy.Swap(healthy); was != healthy {
+			log := logger.FromContext(ctx).With("replica", r.name, "lag", lag)
+			if healthy {
+				log.Infow("database replica back in rotation")
+			} else {
+				log.Warnw("database replica removed from rotation", "error", err)
+			}
+		}
+	}
+}
+
+// markDown removes a replica from rotation after a failed query until the
+// next successful health check.
+func (s *replicaSet) markDown(ctx context.Context, r *replica, err error) {
+	if r.healthy.Swap(false) {
+		logger.FromContext(ctx).Warnw("database replica removed from rotation", "replica", r.name, "error", err)
+	}
+}
+
+// run probes replicas on every interval until close is called.
+func (s *replicaSet) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+			s.check(ctx)
+			cancel()
+			s.prunePins(now)
+		}
+	}
+}
+
+// close stops health checks and closes every replica pool.
+func (s *replicaSet) close() {
+	close(s.stop)
+	<-s.done
+	for _, r := range s.replicas {
+		r.q.Close()
+	}
+}
+
+// failoverRow retries a single-row read on the primary when the replica
+// that served it turns out to be unreachable.
+type failoverRow struct {
+	row      pgx.Row
+	fallback func(err error) pgx.Row
+}
+
+// Scan implements pgx.Row.
+func (f *failoverRow) Scan(dest ...any) error {
+	err := f.row.Scan(dest...)
+	if isConnectionError(err) {
+		return f.fallback(err).Scan(dest...)
+	}
+	return err
+}
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRow is a pgx.Row driven by a scan function.
+type fakeRow func(dest ...any) error
+
+// Scan implements pgx.Row.
+func (f fakeRow) Scan(dest ...any) error { return f(dest...) }
+
+// fakeQuerier answers health probes with a fixed lag or error.
+type fakeQuerier struct {
+	lag     float64
+	unknown bool
+	err     error
+	closed  bool
+}
+
+// Exec is unused by these tests.
+func (f *fakeQuerier) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+// Query is unused by these tests.
+func (f *fakeQuerier) Query(context.Context, string, ...any) (pgx.Rows, error) { return nil, nil }
+
+// QueryRow returns the configured lag probe result.
+func (f *fakeQuerier) QueryRow(context.Context, string, ...any) pgx.Row {
+	return fakeRow(func(dest ...any) error {
+		if f.err != nil {
+			return f.err
+		}
+		*dest[0].(*bool) = true
+		if f.unknown {
+			*dest[1].(**float64) = nil
+		} else {
+			lag := f.lag
+			*dest[1].(**float64) = &lag
+		}
+		return nil
+	})
+}
+
+// Close records that the pool was closed.
+func (f *fakeQuerier) Close() { f.closed = true }
+
+// newTestReplicaSet returns a set of n healthy fake replicas.
+func newTestReplicaSet(n int, readYourWrites bool) (*replicaSet, []*fakeQuerier) {
+	fakes := make([]*fakeQuerier, n)
+	replicas := make([]*replica, n)
+	for i := range replicas {
+		fakes[i] = &fakeQuerier{}
+		replicas[i] = &replica{name: "replica", q: fakes[i]}
+	}
+	return newReplicaSet(replicas, time.Second, time.Hour, readYourWrites), fakes
+}
+
+// TestIsReadOnlyStatement verifies which statements may be sent to a replica.
+func TestIsReadOnlyStatement(t *testing.T) {
+	cases := map[string]bool{
+		"SELECT * FROM contacts":                          true,
+		"  \n select id from contacts where id = $1":      true,
+		"(SELECT 1) UNION (SELECT 2)":                     true,
+		"SELECT * FROM contacts WHERE id = $1 FOR UPDATE": false,
+		"select * from contacts for share":                false,
+		"INSERT INTO contacts VALUES ($1)":                false,
+		"WITH x AS (DELETE FROM t RETURNING *) SELECT 1":  false,
+	}
+	for sql, want := range cases {
+		assert.Equal(t, want, isReadOnlyStatement(sql), sql)
+	}
+}
+
+// TestReplicaSet_RoundRobin verifies reads alternate across healthy replicas and skip unhealthy ones.
+func TestReplicaSet_RoundRobin(t *testing.T) {
+	set, _ := newTestReplicaSet(2, false)
+	ctx := context.Background()
+
+	first := set.route(ctx, "SELECT 1")
+	second := set.route(ctx, "SELECT 1")
+	require.NotNil(t, first)
+	require.NotNil(t, second)
+	assert.NotSame(t, first, second)
+
+	set.replicas[0].healthy.Store(false)
+	for range 4 {
+		assert.Same(t, set.replicas[1], set.route(ctx, "SELECT 1"))
+	}
+
+	set.replicas[1].healthy.Store(false)
+	assert.Nil(t, set.route(ctx, "SELECT 1"))
+}
+
+// TestReplicaSet_ContextRouting verifies explicit read-only and primary markers.
+func TestReplicaSet_ContextRouting(t *testing.T) {
+	set, _ := newTestReplicaSet(1, false)
+	ctx := context.Background()
+
+	assert.Nil(t, set.route(ctx, "UPDATE contacts SET x = 1"))
+	assert.NotNil(t, set.route(WithReadOnly(ctx), "WITH r AS (SELECT 1) SELECT * FROM r"))
+	assert.Nil(t, set.route(WithPrimary(ctx), "SELECT 1"))
+}
+
+// TestReplicaSet_ReadYourWrites verifies a request that wrote is pinned to the primary until the pin expires.
+func TestReplicaSet_ReadYourWrites(t *testing.T) {
+	set, _ := newTestReplicaSet(1, true)
+	ctx := reqctx.WithRequestID(context.Background(), "req-1")
+	other := reqctx.WithRequestID(context.Background(), "req-2")
+
+	set.pin(ctx)
+	assert.Nil(t, set.route(ctx, "SELECT 1"))
+	assert.NotNil(t, set.route(other, "SELECT 1"))
+
+	set.prunePins(time.Now().Add(2 * time.Second))
+	assert.NotNil(t, set.route(ctx, "SELECT 1"))
+
+	disabled, _ := newTestReplicaSet(1, false)
+	disabled.pin(ctx)
+	assert.NotNil(t, disabled.route(ctx, "SELECT 1"))
+}
+
+// TestReplicaSet_Check verifies lagging or unreachable replicas leave rotation and come back once healthy.
+func TestReplicaSet_Check(t *testing.T) {
+	set, fakes := newTestReplicaSet(2, false)
+	fakes[0].lag = 5
+	fakes[1].err = errors.New("connection refused")
+
+	set.check(context.Background())
+	assert.False(t, set.replicas[0].healthy.Load())
+	assert.False(t, set.replicas[1].healthy.Load())
+
+	fakes[0].lag = 0.5
+	fakes[1].err = nil
+	set.check(context.Background())
+	assert.True(t, set.replicas[0].healthy.Load())
+	assert.True(t, set.replicas[1].healthy.Load())
+
+	fakes[0].unknown = true
+	set.check(context.Background())
+	assert.False(t, set.replicas[0].healthy.Load(), "a replica with unknown lag leaves rotation")
+}
+
+// TestReplicaSet_Close verifies health checks stop and replica pools are closed.
+func TestReplicaSet_Close(t *testing.T) {
+	set, fakes := newTestReplicaSet(2, false)
+	go set.run()
+	set.close()
+
+	for _, f := range fakes {
+		assert.True(t, f.closed)
+	}
+}
+
+// TestFailoverRow verifies only connection failures fall back to the primary.
+func TestFailoverRow(t *testing.T) {
+	var fellBack bool
+	fallback := func(error) pgx.Row {
+		fellBack = true
+		return fakeRow(func(...any) error { return nil })
+	}
+
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	row := &failoverRow{row: fakeRow(func(...any) error { return refused }), fallback: fallback}
+	require.NoError(t, row.Scan())
+	assert.True(t, fellBack)
+
+	fellBack = false
+	row = &failoverRow{row: fakeRow(func(...any) error { return pgx.ErrNoRows }), fallback: fallback}
+	require.ErrorIs(t, row.Scan(), pgx.ErrNoRows)
+	assert.False(t, fellBack)
+
+	row = &failoverRow{row: fakeRow(func(...any) error { return &pgconn.PgError{Code: "42P01"} }), fallback: fallback}
+	require.Error(t, row.Scan())
+	assert.False(t, fellBack)
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres SQLSTATE codes that signal a transaction may succeed when retried.
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// RetryPolicy describes how many times and how patiently a failed operation
+// is retried. Delays grow exponentially from BaseDelay up to MaxDelay with
+// full jitter. The zero value never retries.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+
+	// BaseDelay is the upper bound of the first backoff.
+	BaseDelay time.Duration
+
+	// MaxDelay caps every backoff.
+	MaxDelay time.Duration
+}
+
+// backoff returns the jittered delay before retry number attempt (0-based).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << min(attempt, 30)
+	if p.MaxDelay > 0 && (delay > p.MaxDelay || delay <= 0) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay + 1)
+}
+
+// Do runs fn until it succeeds, returns an error retryable rejects, the
+// retry budget is exhausted or ctx is done. The last error is returned.
+func (p RetryPolicy) Do(ctx context.Context, retryable func(error) bool, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxRetries || !retryable(err) {
+			return err
+		}
+		if !sleep(ctx, p.backoff(attempt)) {
+			return err
+		}
+	}
+}
+
+// sleep waits for d and reports false if ctx finished first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// IsRetryable reports whether err is a serialization failure or deadlock,
+// meaning the whole transaction can be retried.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == sqlStateSerializationFailure || pgErr.Code == sqlStateDeadlockDetected
+}
+
+// isConnectionError reports whether err means the server could not be
+// reached or dropped the connection, as opposed to the query itself failing.
+func isConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var connErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connErr) ||
+		errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE)
+}
+
+// isTransientRead reports whether a failed read-only statement may be rerun:
+// the connection failed or the server aborted it to resolve a conflict.
+func isTransientRead(err error) bool {
+	return !errors.Is(err, pgx.ErrNoRows) && (isConnectionError(err) || IsRetryable(err))
+}
+
+// isTransientWrite reports whether a failed write may be rerun without
+// risking a double apply: it never reached the server, or the server
+// rolled it back because of a serialization failure or deadlock.
+func isTransientWrite(err error) bool {
+	return pgconn.SafeToRetry(err) || IsRetryable(err)
+}
+
+// retryRow reruns a single-row statement when scanning reports a transient error.
+type retryRow struct {
+	ctx       context.Context
+	policy    RetryPolicy
+	retryable func(error) bool
+	query     func() pgx.Row
+}
+
+// Scan implements pgx.Row.
+func (r *retryRow) Scan(dest ...any) error {
+	return r.policy.Do(r.ctx, r.retryable, func() error {
+		return r.query().Scan(dest...)
+	})
+}
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// redacted replaces query arguments that may carry personal data in logs.
+const redacted = "[REDACTED]"
+
+// QueryMetrics holds the Prometheus collectors updated by Tracer.
+type QueryMetrics struct {
+	// Queries counts executed statements by outcome ("ok" or "error").
+	Queries *prometheus.CounterVec
+
+	// Duration observes statement latency in seconds.
+	Duration prometheus.Histogram
+
+	// SlowQueries counts statements slower than the configured threshold.
+	SlowQueries prometheus.Counter
+}
+
+// NewQueryMetrics creates the query collectors and registers them with reg.
+func NewQueryMetrics(reg prometheus.Registerer) *QueryMetrics {
+	m := &QueryMetrics{
+		Queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "mannaiah",
+			Subsystem: "db",
+			Name:      "queries_total",
+			Help:      "Number of SQL statements executed, by outcome.",
+		}, []string{"outcome"}),
+		Duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "mannaiah",
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "SQL statement latency.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		SlowQueries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "mannaiah",
+			Subsystem: "db",
+			Name:      "slow_queries_total",
+			Help:      "Number of SQL statements slower than the slow query threshold.",
+		}),
+	}
+	reg.MustRegister(m.Queries, m.Duration, m.SlowQueries)
+	return m
+}
+
+// Tracer is a pgx.QueryTracer that logs statements when Debug is set, warns
+// about slow statements and records query metrics.
+type Tracer struct {
+	// Debug logs every statement with its redacted arguments, row count and
+	// duration at debug level, so the log level must allow it too.
+	Debug bool
+
+	// SlowThreshold is the duration above which a statement is logged as slow.
+	// Zero disables slow query detection.
+	SlowThreshold time.Duration
+
+	// Metrics receives query counts and latencies. Nil disables metrics.
+	Metrics *QueryMetrics
+}
+
+// traceKey is the context key holding the in-flight query.
+type traceKey struct{}
+
+// traceData is the query state carried from TraceQueryStart to TraceQueryEnd.
+type traceData struct {
+	sql   string
+	args  []any
+	start time.Time
+}
+
+// TraceQueryStart records the statement and start time.
+func (t *Tracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, traceKey{}, &traceData{sql: data.SQL, args: data.Args, start: time.Now()})
+}
+
+// TraceQueryEnd logs and measures the statement started in TraceQueryStart.
+func (t *Tracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	td, ok := ctx.Value(traceKey{}).(*traceData)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(td.start)
+	slow := t.SlowThreshold > 0 && elapsed >= t.SlowThreshold
+
+	if t.Metrics != nil {
+		outcome := "ok"
+		if data.Err != nil {
+			outcome = "error"
+		}
+		t.Metrics.Queries.WithLabelValues(outcome).Inc()
+		t.Metrics.Duration.Observe(elapsed.Seconds())
+		if slow {
+			t.Metrics.SlowQueries.Inc()
+		}
+	}
+
+	if !t.Debug && !slow {
+		return
+	}
+
+	log := logger.FromContext(ctx).With(
+		"sql", td.sql,
+		"args", RedactArgs(td.args),
+		"rows", data.CommandTag.RowsAffected(),
+		"duration", elapsed,
+	)
+	if data.Err != nil {
+		log = log.With("error", data.Err)
+	}
+
+	if slow {
+		log.Warnw("slow sql query", "threshold", t.SlowThreshold)
+	} else {
+		log.Debugw("sql query")
+	}
+}
+
+// RedactArgs returns a copy of args safe to log. Numbers, booleans, times and
+// nulls are kept; strings, bytes and composite values are replaced because
+// they may contain personal data.
+func RedactArgs(args []any) []any {
+	out := make([]any, len(args))
+	for i, arg := range args {
+		out[i] = redactArg(arg)
+	}
+	return out
+}
+
+// redactArg returns arg unchanged when it cannot hold personal data.
+func redactArg(arg any) any {
+	switch arg.(type) {
+	case nil, time.Time, time.Duration:
+		return arg
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return v.Interface()
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t
+	}
+	return redacted
+}
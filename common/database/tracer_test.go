@@ -0,0 +1,93 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// traceQuery runs a statement through the tracer, pretending it took elapsed.
+func traceQuery(t *testing.T, tracer *Tracer, ctx context.Context, elapsed time.Duration, err error) {
+	t.Helper()
+	ctx = tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{
+		SQL:  "SELECT * FROM contacts WHERE email = $1 AND id = $2",
+		Args: []any{"ana@flock.com", 42},
+	})
+	ctx.Value(traceKey{}).(*traceData).start = time.Now().Add(-elapsed)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 3"), Err: err})
+}
+
+// newTraceContext returns a context whose logger writes into buf.
+func newTraceContext(buf *bytes.Buffer) context.Context {
+	ctx := logger.WithContext(context.Background(), logger.New("debug", buf))
+	return reqctx.WithRequestID(ctx, "req-42")
+}
+
+// TestTracer_DebugLogsRedactedStatements verifies debug logging hides string arguments.
+func TestTracer_DebugLogsRedactedStatements(t *testing.T) {
+	var buf bytes.Buffer
+	traceQuery(t, &Tracer{Debug: true}, newTraceContext(&buf), time.Millisecond, nil)
+
+	out := buf.String()
+	assert.Contains(t, out, "sql query")
+	assert.Contains(t, out, "SELECT * FROM contacts")
+	assert.Contains(t, out, redacted)
+	assert.Contains(t, out, `"rows": 3`)
+	assert.NotContains(t, out, "ana@flock.com")
+}
+
+// TestTracer_QuietWithoutDebug verifies fast statements are not logged when debug is off.
+func TestTracer_QuietWithoutDebug(t *testing.T) {
+	var buf bytes.Buffer
+	traceQuery(t, &Tracer{SlowThreshold: time.Second}, newTraceContext(&buf), time.Millisecond, nil)
+	assert.Empty(t, buf.String())
+}
+
+// TestTracer_SlowQuery verifies slow statements warn with the request ID and are counted.
+func TestTracer_SlowQuery(t *testing.T) {
+	var buf bytes.Buffer
+	metrics := NewQueryMetrics(prometheus.NewRegistry())
+	tracer := &Tracer{SlowThreshold: 100 * time.Millisecond, Metrics: metrics}
+
+	traceQuery(t, tracer, newTraceContext(&buf), 200*time.Millisecond, nil)
+	traceQuery(t, tracer, newTraceContext(&buf), time.Millisecond, errors.New("boom"))
+
+	out := buf.String()
+	assert.Contains(t, out, "slow sql query")
+	assert.Contains(t, out, "req-42")
+	assert.NotContains(t, out, "ana@flock.com")
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.SlowQueries))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.Queries.WithLabelValues("ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.Queries.WithLabelValues("error")))
+	require.Equal(t, 1, testutil.CollectAndCount(metrics.Duration))
+}
+
+// TestRedactArgs verifies only values that cannot carry personal data are kept.
+func TestRedactArgs(t *testing.T) {
+	now := time.Now()
+	name := "Ana"
+	var missing *string
+
+	got := RedactArgs([]any{"secret", []byte("x"), &name, missing, nil, 7, int64(8), true, 1.5, now, &now, map[string]any{"a": 1}})
+	assert.Equal(t, []any{redacted, redacted, redacted, nil, nil, 7, int64(8), true, 1.5, now, now, redacted}, got)
+}
+
+// TestTracer_DebugLogsAtDebugLevel verifies statements are hidden above debug level.
+func TestTracer_DebugLogsAtDebugLevel(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := logger.WithContext(context.Background(), logger.New("info", &buf))
+	traceQuery(t, &Tracer{Debug: true}, ctx, time.Millisecond, nil)
+	assert.Empty(t, buf.String())
+}
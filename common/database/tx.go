@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// IsolationLevel is the SQL transaction isolation level.
+type IsolationLevel string
+
+const (
+	// IsolationDefault uses the server's default isolation level.
+	IsolationDefault IsolationLevel = ""
+	// IsolationReadCommitted sees only data committed before each statement.
+	IsolationReadCommitted IsolationLevel = "read committed"
+	// IsolationRepeatableRead sees a snapshot taken at the first statement.
+	IsolationRepeatableRead IsolationLevel = "repeatable read"
+	// IsolationSerializable behaves as if transactions ran one after another.
+	IsolationSerializable IsolationLevel = "serializable"
+)
+
+const (
+	// DefaultTxRetries is how many times WithTx retries a serialization failure
+	// when TxOptions.MaxRetries is zero.
+	DefaultTxRetries = 3
+
+	// txRetryBaseDelay is the initial backoff between transaction retries.
+	txRetryBaseDelay = 10 * time.Millisecond
+
+	// txRetryMaxDelay caps the backoff between transaction retries.
+	txRetryMaxDelay = 500 * time.Millisecond
+)
+
+// TxOptions configures a transaction started by BeginTx or WithTx.
+// Isolation and ReadOnly are ignored for savepoints.
+type TxOptions struct {
+	// Isolation is the isolation level. Empty uses the server default.
+	Isolation IsolationLevel
+
+	// ReadOnly starts a read-only transaction.
+	ReadOnly bool
+
+	// MaxRetries bounds how many times WithTx reruns fn after a serialization
+	// failure or deadlock. Zero uses DefaultTxRetries; negative disables retries.
+	MaxRetries int
+}
+
+// retries returns the effective retry budget.
+func (o TxOptions) retries() int {
+	switch {
+	case o.MaxRetries < 0:
+		return 0
+	case o.MaxRetries == 0:
+		return DefaultTxRetries
+	default:
+		return o.MaxRetries
+	}
+}
+
+// pgx converts the options into their pgx representation.
+func (o TxOptions) pgx() pgx.TxOptions {
+	opts := pgx.TxOptions{IsoLevel: pgx.TxIsoLevel(o.Isolation)}
+	if o.ReadOnly {
+		opts.AccessMode = pgx.ReadOnly
+	}
+	return opts
+}
+
+// txKey is the context key holding the active transaction.
+type txKey struct{}
+
+// ContextWithTx returns a copy of ctx carrying tx.
+// Clients route queries made with the returned context through tx.
+func ContextWithTx(ctx context.Context, tx Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any.
+func TxFromContext(ctx context.Context) (Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(Tx)
+	return tx, ok
+}
+
+// withRetry runs attempt until it succeeds, fails with an error other than a
+// serialization failure or deadlock, or the retry budget is exhausted.
+func withRetry(ctx context.Context, retries int, attempt func() error) error {
+	policy := RetryPolicy{MaxRetries: retries, BaseDelay: txRetryBaseDelay, MaxDelay: txRetryMaxDelay}
+	return policy.Do(ctx, IsRetryable, attempt)
+}
+
+// runTx begins a transaction with begin, runs fn with it in context and
+// commits or rolls back depending on the outcome. Panics roll back and propagate.
+func runTx(ctx context.Context, begin func(context.Context, TxOptions) (Tx, error), opts TxOptions, fn func(ctx context.Context) error) (err error) {
+	tx, err := begin(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(ContextWithTx(ctx, tx)); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return errors.Join(err, fmt.Errorf("rollback transaction: %w", rbErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// pgxTx adapts pgx.Tx to the Tx interface.
+type pgxTx struct {
+	tx pgx.Tx
+}
+
+// Exec executes a query without returning rows.
+func (t *pgxTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return t.tx.Exec(ctx, sql, args...)
+}
+
+// Query executes a query that returns multiple rows.
+func (t *pgxTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return t.tx.Query(ctx, sql, args...)
+}
+
+// QueryRow executes a query that returns a single row.
+func (t *pgxTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return t.tx.QueryRow(ctx, sql, args...)
+}
+
+// BeginTx creates a savepoint within the transaction.
+func (t *pgxTx) BeginTx(ctx context.Context, _ TxOptions) (Tx, error) {
+	sp, err := t.tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pgxTx{tx: sp}, nil
+}
+
+// WithTx runs fn inside a savepoint. Serialization failures are not retried
+// here because they abort the enclosing transaction; the outermost WithTx retries.
+func (t *pgxTx) WithTx(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error {
+	return runTx(ctx, t.BeginTx, opts, fn)
+}
+
+// Commit commits the transaction or releases the savepoint.
+func (t *pgxTx) Commit(ctx context.Context) error {
+	return t.tx.Commit(ctx)
+}
+
+// Rollback aborts the transaction or rolls back to the savepoint.
+func (t *pgxTx) Rollback(ctx context.Context) error {
+	err := t.tx.Rollback(ctx)
+	if errors.Is(err, pgx.ErrTxClosed) {
+		return nil
+	}
+	return err
+}
+
+// NopTransactor runs units of work directly, without a transaction.
+// It backs storage that has no transactions, such as in-memory repositories.
+type NopTransactor struct{}
+
+// WithTx calls fn with ctx unchanged.
+func (NopTransactor) WithTx(ctx context.Context, _ TxOptions, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx records how a transaction was finished.
+type fakeTx struct {
+	committed  bool
+	rolledBack bool
+	commitErr  error
+}
+
+// Exec is unused by these tests.
+func (f *fakeTx) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+// Query is unused by these tests.
+func (f *fakeTx) Query(context.Context, string, ...any) (pgx.Rows, error) { return nil, nil }
+
+// QueryRow is unused by these tests.
+func (f *fakeTx) QueryRow(context.Context, string, ...any) pgx.Row { return nil }
+
+// BeginTx returns a nested fake.
+func (f *fakeTx) BeginTx(context.Context, TxOptions) (Tx, error) { return &fakeTx{}, nil }
+
+// WithTx runs fn in a nested fake.
+func (f *fakeTx) WithTx(ctx context.Context, opts TxOptions, fn func(context.Context) error) error {
+	return runTx(ctx, f.BeginTx, opts, fn)
+}
+
+// Commit marks the transaction committed.
+func (f *fakeTx) Commit(context.Context) error {
+	f.committed = true
+	return f.commitErr
+}
+
+// Rollback marks the transaction rolled back.
+func (f *fakeTx) Rollback(context.Context) error {
+	f.rolledBack = true
+	return nil
+}
+
+// beginFake returns a begin func that hands out tx and counts calls.
+func beginFake(calls *int, tx func() *fakeTx) func(context.Context, TxOptions) (Tx, error) {
+	return func(context.Context, TxOptions) (Tx, error) {
+		*calls++
+		return tx(), nil
+	}
+}
+
+// TestRunTx_CommitsOnSuccess verifies fn sees the tx in context and it is committed.
+func TestRunTx_CommitsOnSuccess(t *testing.T) {
+	tx := &fakeTx{}
+	var calls int
+
+	err := runTx(context.Background(), beginFake(&calls, func() *fakeTx { return tx }), TxOptions{}, func(ctx context.Context) error {
+		got, ok := TxFromContext(ctx)
+		require.True(t, ok)
+		assert.Same(t, tx, got)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.True(t, tx.committed)
+	assert.False(t, tx.rolledBack)
+}
+
+// TestRunTx_RollsBackOnError verifies errors from fn roll back and propagate.
+func TestRunTx_RollsBackOnError(t *testing.T) {
+	tx := &fakeTx{}
+	var calls int
+	boom := errors.New("boom")
+
+	err := runTx(context.Background(), beginFake(&calls, func() *fakeTx { return tx }), TxOptions{}, func(context.Context) error {
+		return boom
+	})
+
+	require.ErrorIs(t, err, boom)
+	assert.True(t, tx.rolledBack)
+	assert.False(t, tx.committed)
+}
+
+// TestRunTx_RollsBackOnPanic verifies panics roll back before propagating.
+func TestRunTx_RollsBackOnPanic(t *testing.T) {
+	tx := &fakeTx{}
+	var calls int
+
+	assert.Panics(t, func() {
+		_ = runTx(context.Background(), beginFake(&calls, func() *fakeTx { return tx }), TxOptions{}, func(context.Context) error {
+			panic("boom")
+		})
+	})
+	assert.True(t, tx.rolledBack)
+}
+
+// TestWithRetry_RetriesSerializationFailures verifies retryable errors rerun the whole transaction.
+func TestWithRetry_RetriesSerializationFailures(t *testing.T) {
+	var calls, attempts int
+	begin := beginFake(&calls, func() *fakeTx { return &fakeTx{} })
+
+	err := withRetry(context.Background(), TxOptions{}.retries(), func() error {
+		return runTx(context.Background(), begin, TxOptions{}, func(context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return &pgconn.PgError{Code: sqlStateSerializationFailure}
+			}
+			return nil
+		})
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+// TestWithRetry_GivesUp verifies the retry budget is honoured and non-retryable errors stop immediately.
+func TestWithRetry_GivesUp(t *testing.T) {
+	var attempts int
+	err := withRetry(context.Background(), TxOptions{MaxRetries: 2}.retries(), func() error {
+		attempts++
+		return &pgconn.PgError{Code: sqlStateDeadlockDetected}
+	})
+	require.True(t, IsRetryable(err))
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = withRetry(context.Background(), DefaultTxRetries, func() error {
+		attempts++
+		return errors.New("plain")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+
+	assert.Equal(t, 0, TxOptions{MaxRetries: -1}.retries())
+}
+
+// TestTxOptions_Pgx verifies options translate to pgx settings.
+func TestTxOptions_Pgx(t *testing.T) {
+	opts := TxOptions{Isolation: IsolationSerializable, ReadOnly: true}.pgx()
+	assert.Equal(t, pgx.Serializable, opts.IsoLevel)
+	assert.Equal(t, pgx.ReadOnly, opts.AccessMode)
+}
+
+// TestNopTransactor verifies units of work run inline without a transaction in context.
+func TestNopTransactor(t *testing.T) {
+	boom := errors.New("boom")
+	err := NopTransactor{}.WithTx(context.Background(), TxOptions{}, func(ctx context.Context) error {
+		_, ok := TxFromContext(ctx)
+		assert.False(t, ok)
+		return boom
+	})
+	require.ErrorIs(t, err, boom)
+}
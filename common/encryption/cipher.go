@@ -0,0 +1,145 @@
+// Package encryption provides field-level encryption for values stored at
+// rest: AES-256-GCM with versioned keys, and HMAC-SHA256 blind indexes so
+// encrypted columns can still be looked up by equality.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrMalformed is returned when a value is not a ciphertext produced by Cipher.
+var ErrMalformed = errors.New("encryption: malformed ciphertext")
+
+// versionPrefix starts every ciphertext, followed by the key version and a colon: "v2:<base64>".
+const versionPrefix = "v"
+
+// Cipher encrypts and decrypts string fields with keys from a KeyProvider.
+// The field name is bound to each ciphertext as associated data, so a value
+// copied into another column fails to decrypt.
+type Cipher struct {
+	keys KeyProvider
+}
+
+// NewCipher creates a Cipher backed by keys.
+func NewCipher(keys KeyProvider) *Cipher {
+	return &Cipher{keys: keys}
+}
+
+// Encrypt seals plaintext for field with the current key. Empty values stay
+// empty so optional columns remain blank.
+func (c *Cipher) Encrypt(field, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	key, err := c.keys.Current()
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(key.Material)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(field))
+
+	return versionPrefix + strconv.FormatUint(uint64(key.Version), 10) + ":" +
+		base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a ciphertext produced by Encrypt for the same field, using
+// whichever key version it was sealed with.
+func (c *Cipher) Decrypt(field, ciphertext string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+
+	version, payload, err := split(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	key, err := c.keys.Key(version)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(key.Material)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrMalformed
+	}
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, []byte(field))
+	if err != nil {
+		return "", fmt.Errorf("encryption: failed to decrypt %s: %w", field, err)
+	}
+	return string(plaintext), nil
+}
+
+// CurrentVersion returns the version of the key new values are encrypted with.
+func (c *Cipher) CurrentVersion() (uint32, error) {
+	key, err := c.keys.Current()
+	if err != nil {
+		return 0, err
+	}
+	return key.Version, nil
+}
+
+// BlindIndex returns a deterministic keyed hash of value for field, hex
+// encoded. Equal values of the same field produce equal indexes, so the index
+// column can be queried and constrained without revealing the value. Callers
+// normalize value first; empty values have an empty index.
+func (c *Cipher) BlindIndex(field, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	key, err := c.keys.IndexKey()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// split parses "v<version>:<payload>".
+func split(ciphertext string) (uint32, string, error) {
+	head, payload, ok := strings.Cut(ciphertext, ":")
+	if !ok || !strings.HasPrefix(head, versionPrefix) {
+		return 0, "", ErrMalformed
+	}
+	version, err := strconv.ParseUint(strings.TrimPrefix(head, versionPrefix), 10, 32)
+	if err != nil {
+		return 0, "", ErrMalformed
+	}
+	return uint32(version), payload, nil
+}
+
+// newAEAD returns AES-GCM for key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,181 @@
+package encryption
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKeyring returns a keyring holding versions 1..current.
+func testKeyring(t *testing.T, current uint32) *Keyring {
+	t.Helper()
+	keys := make(map[uint32][]byte)
+	for v := uint32(1); v <= current; v++ {
+		keys[v] = bytes.Repeat([]byte{byte(v)}, KeySize)
+	}
+	ring, err := NewKeyring(current, keys, bytes.Repeat([]byte{0xff}, KeySize))
+	require.NoError(t, err)
+	return ring
+}
+
+// TestCipher_RoundTrip verifies values decrypt to the original and carry the key version.
+func TestCipher_RoundTrip(t *testing.T) {
+	c := NewCipher(testKeyring(t, 1))
+
+	ct, err := c.Encrypt("email", "ana@example.com")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ct, "v1:"))
+	assert.NotContains(t, ct, "ana@example.com")
+
+	pt, err := c.Decrypt("email", ct)
+	require.NoError(t, err)
+	assert.Equal(t, "ana@example.com", pt)
+
+	again, err := c.Encrypt("email", "ana@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, ct, again, "nonces must differ")
+}
+
+// TestCipher_Empty verifies empty values are stored and read as empty.
+func TestCipher_Empty(t *testing.T) {
+	c := NewCipher(testKeyring(t, 1))
+
+	ct, err := c.Encrypt("phone", "")
+	require.NoError(t, err)
+	assert.Empty(t, ct)
+
+	pt, err := c.Decrypt("phone", "")
+	require.NoError(t, err)
+	assert.Empty(t, pt)
+
+	idx, err := c.BlindIndex("phone", "")
+	require.NoError(t, err)
+	assert.Empty(t, idx)
+}
+
+// TestCipher_FieldBound verifies a ciphertext cannot be read as another field.
+func TestCipher_FieldBound(t *testing.T) {
+	c := NewCipher(testKeyring(t, 1))
+
+	ct, err := c.Encrypt("email", "ana@example.com")
+	require.NoError(t, err)
+
+	_, err = c.Decrypt("phone", ct)
+	assert.Error(t, err)
+}
+
+// TestCipher_Rotation verifies old ciphertexts stay readable after rotation
+// and new ones use the current key.
+func TestCipher_Rotation(t *testing.T) {
+	old, err := NewCipher(testKeyring(t, 1)).Encrypt("doc_number", "100")
+	require.NoError(t, err)
+
+	c := NewCipher(testKeyring(t, 2))
+	pt, err := c.Decrypt("doc_number", old)
+	require.NoError(t, err)
+	assert.Equal(t, "100", pt)
+
+	ct, err := c.Encrypt("doc_number", "100")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ct, "v2:"))
+
+	version, err := c.CurrentVersion()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), version)
+
+	_, err = NewCipher(testKeyring(t, 1)).Decrypt("doc_number", ct)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+// TestCipher_Malformed verifies values not produced by Encrypt are rejected.
+func TestCipher_Malformed(t *testing.T) {
+	c := NewCipher(testKeyring(t, 1))
+
+	for _, value := range []string{"plain", "v:abc", "vx:abc", "v1:!!", "v1:AA"} {
+		_, err := c.Decrypt("email", value)
+		assert.ErrorIs(t, err, ErrMalformed, value)
+	}
+}
+
+// TestCipher_BlindIndex verifies indexes are deterministic, keyed and field-scoped.
+func TestCipher_BlindIndex(t *testing.T) {
+	c := NewCipher(testKeyring(t, 1))
+
+	a, err := c.BlindIndex("doc_number", "100")
+	require.NoError(t, err)
+	b, err := c.BlindIndex("doc_number", "100")
+	require.NoError(t, err)
+	other, err := c.BlindIndex("phone", "100")
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, other)
+	assert.Len(t, a, 64)
+
+	rotated, err := NewCipher(testKeyring(t, 2)).BlindIndex("doc_number", "100")
+	require.NoError(t, err)
+	assert.Equal(t, a, rotated, "data key rotation must not change indexes")
+}
+
+// TestNewKeyring_Invalid verifies key sizes and the current version are checked.
+func TestNewKeyring_Invalid(t *testing.T) {
+	good := bytes.Repeat([]byte{1}, KeySize)
+
+	_, err := NewKeyring(1, map[uint32][]byte{1: good}, []byte("short"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = NewKeyring(1, map[uint32][]byte{1: []byte("short")}, good)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = NewKeyring(2, map[uint32][]byte{1: good}, good)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	_, err = NewKeyring(0, map[uint32][]byte{0: good}, good)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+// TestRotateKeyFile verifies a key file is created, rotated and loaded.
+func TestRotateKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+
+	version, err := RotateKeyFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), version)
+
+	first, err := LoadKeyFile(path)
+	require.NoError(t, err)
+	ct, err := NewCipher(first).Encrypt("email", "ana@example.com")
+	require.NoError(t, err)
+	idx, err := NewCipher(first).BlindIndex("email", "ana@example.com")
+	require.NoError(t, err)
+
+	version, err = RotateKeyFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), version)
+
+	second, err := LoadKeyFile(path)
+	require.NoError(t, err)
+	c := NewCipher(second)
+
+	current, err := c.CurrentVersion()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), current)
+
+	pt, err := c.Decrypt("email", ct)
+	require.NoError(t, err)
+	assert.Equal(t, "ana@example.com", pt)
+
+	same, err := c.BlindIndex("email", "ana@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, idx, same)
+}
+
+// TestLoadKeyFile_Missing verifies a missing file is reported.
+func TestLoadKeyFile_Missing(t *testing.T) {
+	_, err := LoadKeyFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// KeySize is the length in bytes of data keys and the blind index key (AES-256, HMAC-SHA256).
+const KeySize = 32
+
+var (
+	// ErrUnknownKey is returned when a ciphertext references a key version the provider does not hold.
+	ErrUnknownKey = errors.New("encryption: unknown key version")
+
+	// ErrInvalidKey is returned when key material has the wrong length.
+	ErrInvalidKey = errors.New("encryption: invalid key")
+)
+
+// Key is a versioned data encryption key.
+type Key struct {
+	// Version identifies the key inside ciphertexts. Versions start at 1.
+	Version uint32
+
+	// Material is the raw AES-256 key.
+	Material []byte
+}
+
+// KeyProvider supplies data keys and the blind index key. Implementations may
+// load keys from a local file, a secret manager or a KMS.
+type KeyProvider interface {
+	// Current returns the key new values are encrypted with.
+	Current() (Key, error)
+
+	// Key returns the key with the given version, or ErrUnknownKey.
+	Key(version uint32) (Key, error)
+
+	// IndexKey returns the HMAC key used for blind indexes. It is not rotated
+	// with data keys, since every stored index would have to be recomputed.
+	IndexKey() ([]byte, error)
+}
+
+// Keyring is an in-memory KeyProvider.
+type Keyring struct {
+	current  uint32
+	keys     map[uint32][]byte
+	indexKey []byte
+}
+
+// NewKeyring builds a Keyring from raw keys, validating their sizes and that
+// current is among them.
+func NewKeyring(current uint32, keys map[uint32][]byte, indexKey []byte) (*Keyring, error) {
+	if len(indexKey) != KeySize {
+		return nil, fmt.Errorf("%w: index key must be %d bytes", ErrInvalidKey, KeySize)
+	}
+	for version, material := range keys {
+		if version == 0 {
+			return nil, fmt.Errorf("%w: versions start at 1", ErrInvalidKey)
+		}
+		if len(material) != KeySize {
+			return nil, fmt.Errorf("%w: key %d must be %d bytes", ErrInvalidKey, version, KeySize)
+		}
+	}
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("%w: current key %d", ErrUnknownKey, current)
+	}
+	return &Keyring{current: current, keys: keys, indexKey: indexKey}, nil
+}
+
+// Current returns the key new values are encrypted with.
+func (k *Keyring) Current() (Key, error) {
+	return k.Key(k.current)
+}
+
+// Key returns the key with the given version.
+func (k *Keyring) Key(version uint32) (Key, error) {
+	material, ok := k.keys[version]
+	if !ok {
+		return Key{}, fmt.Errorf("%w: %d", ErrUnknownKey, version)
+	}
+	return Key{Version: version, Material: material}, nil
+}
+
+// IndexKey returns the blind index key.
+func (k *Keyring) IndexKey() ([]byte, error) {
+	return k.indexKey, nil
+}
+
+// keyFile is the JSON layout of a local key file. Keys are base64 encoded.
+type keyFile struct {
+	Current  uint32            `json:"current"`
+	IndexKey string            `json:"index_key"`
+	Keys     map[string]string `json:"keys"`
+}
+
+// LoadKeyFile reads a Keyring from a local JSON key file, meant for
+// development and single-host deployments:
+//
+//	{"current": 2, "index_key": "<base64>", "keys": {"1": "<base64>", "2": "<base64>"}}
+func LoadKeyFile(path string) (*Keyring, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	var f keyFile
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse key file %s: %w", path, err)
+	}
+
+	indexKey, err := base64.StdEncoding.DecodeString(f.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: index key: %v", ErrInvalidKey, err)
+	}
+
+	keys := make(map[uint32][]byte, len(f.Keys))
+	for v, encoded := range f.Keys {
+		version, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: version %q", ErrInvalidKey, v)
+		}
+		material, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %s: %v", ErrInvalidKey, v, err)
+		}
+		keys[uint32(version)] = material
+	}
+
+	return NewKeyring(f.Current, keys, indexKey)
+}
+
+// RotateKeyFile adds a new random data key to the key file at path and makes
+// it current, creating the file with a fresh index key when it does not exist.
+// Older keys are kept so existing values stay readable until they are
+// re-encrypted. It returns the new key version.
+func RotateKeyFile(path string) (uint32, error) {
+	f := keyFile{Keys: make(map[string]string)}
+
+	content, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		indexKey, err := randomKey()
+		if err != nil {
+			return 0, err
+		}
+		f.IndexKey = base64.StdEncoding.EncodeToString(indexKey)
+	case err != nil:
+		return 0, fmt.Errorf("failed to read key file: %w", err)
+	default:
+		if err := json.Unmarshal(content, &f); err != nil {
+			return 0, fmt.Errorf("failed to parse key file %s: %w", path, err)
+		}
+		if f.Keys == nil {
+			f.Keys = make(map[string]string)
+		}
+	}
+
+	next := f.Current + 1
+	for v := range f.Keys {
+		if version, err := strconv.ParseUint(v, 10, 32); err == nil && uint32(version) >= next {
+			next = uint32(version) + 1
+		}
+	}
+
+	material, err := randomKey()
+	if err != nil {
+		return 0, err
+	}
+	f.Keys[strconv.FormatUint(uint64(next), 10)] = base64.StdEncoding.EncodeToString(material)
+	f.Current = next
+
+	out, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(path, append(out, '\n'), 0o600); err != nil {
+		return 0, fmt.Errorf("failed to write key file: %w", err)
+	}
+	return next, nil
+}
+
+// randomKey returns KeySize random bytes.
+func randomKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,121 @@
+package errors
+
+import (
+	stderrors "errors"
+	"net/http"
+)
+
+// CodeInternal is the code used for unexpected failures.
+const CodeInternal = "internal"
+
+// Error is an application error with a stable code, an HTTP status and a
+// message that is safe to show to clients. The optional cause is kept for
+// logging only and is never rendered in responses.
+type Error struct {
+	// Code is a stable, machine-readable identifier (e.g. "contact.duplicate_document").
+	Code string
+
+	// Status is the HTTP status code associated with the error.
+	Status int
+
+	// Message is a human-readable explanation that is safe to expose.
+	Message string
+
+	// Details can carry additional public context, such as field errors.
+	Details any
+
+	// cause is the internal error that triggered this one, if any.
+	cause error
+}
+
+// New creates an Error with the given code, HTTP status and public message.
+func New(code string, status int, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+// Error implements the error interface. The cause is included for logs.
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the internal cause.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is an *Error with the same code, so copies
+// produced by Wrap or WithDetails still match their sentinel.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns a copy of e carrying the given internal cause.
+func (e *Error) Wrap(cause error) *Error {
+	cp := *e
+	cp.cause = cause
+	return &cp
+}
+
+// WithDetails returns a copy of e carrying the given public details.
+func (e *Error) WithDetails(details any) *Error {
+	cp := *e
+	cp.Details = details
+	return &cp
+}
+
+// Cause returns the internal cause, or nil.
+func (e *Error) Cause() error {
+	return e.cause
+}
+
+// From extracts the *Error from err's chain. Any other error is wrapped as an
+// internal error so that its message never reaches clients.
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if stderrors.As(err, &e) {
+		return e
+	}
+	return Internal(err)
+}
+
+// Internal wraps an unexpected error behind a generic public message.
+func Internal(cause error) *Error {
+	return New(CodeInternal, http.StatusInternalServerError, "internal server error").Wrap(cause)
+}
+
+// InvalidArgument creates a 400 error.
+func InvalidArgument(code, message string) *Error {
+	return New(code, http.StatusBadRequest, message)
+}
+
+// Unauthenticated creates a 401 error.
+func Unauthenticated(code, message string) *Error {
+	return New(code, http.StatusUnauthorized, message)
+}
+
+// PermissionDenied creates a 403 error.
+func PermissionDenied(code, message string) *Error {
+	return New(code, http.StatusForbidden, message)
+}
+
+// NotFound creates a 404 error.
+func NotFound(code, message string) *Error {
+	return New(code, http.StatusNotFound, message)
+}
+
+// Conflict creates a 409 error.
+func Conflict(code, message string) *Error {
+	return New(code, http.StatusConflict, message)
+}
+
+// Unavailable creates a 503 error.
+func Unavailable(code, message string) *Error {
+	return New(code, http.StatusServiceUnavailable, message)
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errSample = NotFound("sample.not_found", "sample not found")
+
+// TestIs_MatchesByCode ensures wrapped copies still match their sentinel.
+func TestIs_MatchesByCode(t *testing.T) {
+	wrapped := fmt.Errorf("loading: %w", errSample.Wrap(stderrors.New("no rows")))
+
+	assert.ErrorIs(t, wrapped, errSample)
+	assert.NotErrorIs(t, wrapped, Conflict("other", "other"))
+}
+
+// TestWrap_KeepsCauseOutOfMessage verifies the public message excludes the cause.
+func TestWrap_KeepsCauseOutOfMessage(t *testing.T) {
+	cause := stderrors.New("pq: relation does not exist")
+	err := errSample.Wrap(cause)
+
+	assert.Equal(t, "sample not found", err.Message)
+	assert.Contains(t, err.Error(), cause.Error())
+	assert.ErrorIs(t, err, cause)
+	assert.Nil(t, errSample.Cause(), "sentinel must not be mutated")
+}
+
+// TestFrom_UnknownErrorIsInternal ensures raw errors are hidden behind a generic message.
+func TestFrom_UnknownErrorIsInternal(t *testing.T) {
+	err := From(stderrors.New("SELECT failed"))
+
+	assert.Equal(t, CodeInternal, err.Code)
+	assert.Equal(t, http.StatusInternalServerError, err.Status)
+	assert.Equal(t, "internal server error", err.Message)
+	assert.Nil(t, From(nil))
+}
+
+// TestFrom_ExtractsTypedError ensures typed errors are returned from the chain.
+func TestFrom_ExtractsTypedError(t *testing.T) {
+	err := From(fmt.Errorf("ctx: %w", errSample))
+	assert.Equal(t, "sample.not_found", err.Code)
+	assert.Equal(t, http.StatusNotFound, err.Status)
+}
@@ -0,0 +1,26 @@
+package logger
+
+import (
+	"github.com/flockstore/mannaiah-backend/common/config"
+	"go.uber.org/zap"
+)
+
+// NewOptions translates the logging settings of cfg into Options whose level
+// is controlled by level.
+func NewOptions(cfg config.GlobalConfig, level zap.AtomicLevel) Options {
+	return Options{
+		Level:  level,
+		Format: cfg.LogFormat,
+		Output: cfg.LogOutput,
+		File: FileOptions{
+			Path:       cfg.LogFile,
+			MaxSizeMB:  cfg.LogMaxSize,
+			MaxAgeDays: cfg.LogMaxAge,
+			MaxBackups: cfg.LogMaxBackups,
+		},
+		SamplingInitial:    cfg.LogSamplingInitial,
+		SamplingThereafter: cfg.LogSamplingThereafter,
+		Caller:             cfg.LogCaller,
+		StacktraceLevel:    cfg.LogStacktrace,
+	}
+}
@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"go.uber.org/zap"
+)
+
+// loggerKey is the context key under which the request-scoped logger is stored.
+type loggerKey struct{}
+
+// WithContext returns a copy of ctx carrying the given logger.
+func WithContext(ctx context.Context, l *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns a child logger populated with the request ID, tenant and
+// principal found in ctx. When ctx carries no logger, the global zap logger is used.
+func FromContext(ctx context.Context) *zap.SugaredLogger {
+	if ctx == nil {
+		return zap.S()
+	}
+
+	l, ok := ctx.Value(loggerKey{}).(*zap.SugaredLogger)
+	if !ok || l == nil {
+		l = zap.S()
+	}
+
+	var fields []any
+	if id := reqctx.RequestID(ctx); id != "" {
+		fields = append(fields, "requestId", id)
+	}
+	if tenant := reqctx.Tenant(ctx); tenant != "" {
+		fields = append(fields, "tenant", tenant)
+	}
+	if principal := reqctx.Principal(ctx); principal != "" {
+		fields = append(fields, "principal", principal)
+	}
+
+	if len(fields) == 0 {
+		return l
+	}
+	return l.With(fields...)
+}
@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFromContext_AddsRequestFields verifies that request-scoped values are attached to log lines.
+func TestFromContext_AddsRequestFields(t *testing.T) {
+	var buf bytes.Buffer
+	log := New("debug", &buf)
+
+	ctx := WithContext(context.Background(), log)
+	ctx = reqctx.WithRequestID(ctx, "req-123")
+	ctx = reqctx.WithTenant(ctx, "acme")
+	ctx = reqctx.WithPrincipal(ctx, "user-42")
+
+	FromContext(ctx).Info("hello")
+
+	output := buf.String()
+	assert.Contains(t, output, "hello")
+	assert.Contains(t, output, "req-123")
+	assert.Contains(t, output, "acme")
+	assert.Contains(t, output, "user-42")
+}
+
+// TestFromContext_FallsBackToGlobal ensures a usable logger is returned when ctx has none.
+func TestFromContext_FallsBackToGlobal(t *testing.T) {
+	assert.NotNil(t, FromContext(context.Background()))
+}
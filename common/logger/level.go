@@ -1,19 +1,24 @@
 package logger
 
-import "go.uber.org/zap/zapcore"
+import (
+	"fmt"
 
-// ParseLevel casts strings into zap levels.
-func ParseLevel(level string) zapcore.Level {
+	"go.uber.org/zap/zapcore"
+)
+
+// ParseLevel casts strings into zap levels. Unknown levels are rejected
+// rather than silently mapped to info.
+func ParseLevel(level string) (zapcore.Level, error) {
 	switch level {
 	case "debug":
-		return zapcore.DebugLevel
+		return zapcore.DebugLevel, nil
 	case "info":
-		return zapcore.InfoLevel
+		return zapcore.InfoLevel, nil
 	case "warn":
-		return zapcore.WarnLevel
+		return zapcore.WarnLevel, nil
 	case "error":
-		return zapcore.ErrorLevel
+		return zapcore.ErrorLevel, nil
 	default:
-		return zapcore.InfoLevel
+		return zapcore.InfoLevel, fmt.Errorf("unknown log level %q", level)
 	}
 }
@@ -20,14 +20,14 @@ func TestParseLevel_ValidLevels(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		actual := ParseLevel(tc.input)
+		actual, err := ParseLevel(tc.input)
+		assert.NoError(t, err)
 		assert.Equal(t, tc.expected, actual, "unexpected log level for input %q", tc.input)
 	}
 }
 
-// TestParseLevel_InvalidLevelDefaultsToInfo test invalid level behaviour
-func TestParseLevel_InvalidLevelDefaultsToInfo(t *testing.T) {
-	invalid := "invalid-level"
-	result := ParseLevel(invalid)
-	assert.Equal(t, zapcore.InfoLevel, result)
+// TestParseLevel_InvalidLevelFails test invalid level behaviour
+func TestParseLevel_InvalidLevelFails(t *testing.T) {
+	_, err := ParseLevel("invalid-level")
+	assert.EqualError(t, err, `unknown log level "invalid-level"`)
 }
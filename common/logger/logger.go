@@ -1,40 +1,201 @@
 package logger
 
 import (
+	"fmt"
 	"io"
+	"os"
+	"time"
 
+	zaplogfmt "github.com/jsternberg/zap-logfmt"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+// Supported log line encodings.
+const (
+	// FormatJSON writes one JSON object per line.
+	FormatJSON = "json"
+
+	// FormatConsole writes human-friendly, tab-separated lines.
+	FormatConsole = "console"
+
+	// FormatLogfmt writes key=value pairs.
+	FormatLogfmt = "logfmt"
+)
+
+// Supported log destinations.
+const (
+	// OutputStdout writes to standard output.
+	OutputStdout = "stdout"
+
+	// OutputStderr writes to standard error.
+	OutputStderr = "stderr"
+
+	// OutputFile writes to a file rotated by size and age.
+	OutputFile = "file"
+)
+
+// StacktraceOff disables stack traces.
+const StacktraceOff = "off"
+
+// FileOptions configures the rotated log file used by OutputFile.
+type FileOptions struct {
+	// Path is the file logs are written to. Rotated files are kept next to it.
+	Path string
+
+	// MaxSizeMB is the size, in megabytes, at which the file is rotated.
+	MaxSizeMB int
+
+	// MaxAgeDays is how many days rotated files are kept.
+	MaxAgeDays int
+
+	// MaxBackups is how many rotated files are kept.
+	MaxBackups int
+}
+
+// Options configures a logger built by Build.
+type Options struct {
+	// Level is the minimum enabled level; it can be changed at runtime.
+	// The zero value means info.
+	Level zap.AtomicLevel
+
+	// Format selects the encoding: FormatJSON (default), FormatConsole or FormatLogfmt.
+	Format string
+
+	// Output selects the destination: OutputStdout (default), OutputStderr or OutputFile.
+	Output string
+
+	// File configures the rotated file when Output is OutputFile.
+	File FileOptions
+
+	// SamplingInitial is how many entries with the same level and message are
+	// logged each second before sampling starts. Zero disables sampling.
+	SamplingInitial int
+
+	// SamplingThereafter logs one out of every N entries once sampling started.
+	SamplingThereafter int
+
+	// Caller annotates entries with the file and line that logged them.
+	Caller bool
+
+	// StacktraceLevel is the level from which stack traces are attached,
+	// or StacktraceOff. Empty means off.
+	StacktraceLevel string
+}
+
 // New creates a zap.SugaredLogger with a given level.
 // If writer is nil, logs go to the default destination.
+// It panics on an unknown level; services should use Build.
 func New(level string, writer io.Writer) *zap.SugaredLogger {
-	parsedLevel := ParseLevel(level)
-
-	encoderCfg := zap.NewProductionEncoderConfig()
-	encoderCfg.TimeKey = "timestamp"
-	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		panic("cannot initialize logger: " + err.Error())
+	}
+	return NewWithLevel(zap.NewAtomicLevelAt(lvl), writer)
+}
 
-	var core zapcore.Core
+// NewWithLevel creates a zap.SugaredLogger whose level follows the given
+// AtomicLevel, so it can be changed while the service is running.
+// If writer is nil, logs go to the default destination.
+func NewWithLevel(level zap.AtomicLevel, writer io.Writer) *zap.SugaredLogger {
 	if writer != nil {
 		ws := zapcore.AddSync(writer)
-		core = zapcore.NewCore(
-			zapcore.NewConsoleEncoder(encoderCfg),
+		core := zapcore.NewCore(
+			zapcore.NewConsoleEncoder(encoderConfig()),
 			ws,
-			parsedLevel,
+			level,
 		)
-	} else {
-		cfg := zap.NewProductionConfig()
-		cfg.Level = zap.NewAtomicLevelAt(parsedLevel)
-		cfg.EncoderConfig = encoderCfg
+		return zap.New(core).Sugar()
+	}
+
+	cfg := zap.NewProductionConfig()
+	cfg.Level = level
+	cfg.EncoderConfig = encoderConfig()
+
+	logger, err := cfg.Build()
+	if err != nil {
+		panic("cannot initialize logger: " + err.Error())
+	}
+	return logger.Sugar()
+}
+
+// Build creates a zap.SugaredLogger from opts.
+func Build(opts Options) (*zap.SugaredLogger, error) {
+	if opts.Level == (zap.AtomicLevel{}) {
+		opts.Level = zap.NewAtomicLevel()
+	}
+
+	enc, err := newEncoder(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := newSink(opts.Output, opts.File)
+	if err != nil {
+		return nil, err
+	}
+
+	core := zapcore.NewCore(enc, ws, opts.Level)
+	if opts.SamplingInitial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, opts.SamplingInitial, opts.SamplingThereafter)
+	}
 
-		logger, err := cfg.Build()
+	zapOpts := []zap.Option{zap.ErrorOutput(zapcore.Lock(os.Stderr))}
+	if opts.Caller {
+		zapOpts = append(zapOpts, zap.AddCaller())
+	}
+	if opts.StacktraceLevel != "" && opts.StacktraceLevel != StacktraceOff {
+		lvl, err := ParseLevel(opts.StacktraceLevel)
 		if err != nil {
-			panic("cannot initialize logger: " + err.Error())
+			return nil, fmt.Errorf("invalid stacktrace level: %w", err)
 		}
-		return logger.Sugar()
+		zapOpts = append(zapOpts, zap.AddStacktrace(lvl))
+	}
+
+	return zap.New(core, zapOpts...).Sugar(), nil
+}
+
+// encoderConfig returns the encoder settings shared by every format.
+func encoderConfig() zapcore.EncoderConfig {
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.TimeKey = "timestamp"
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	return encoderCfg
+}
+
+// newEncoder returns the encoder for format.
+func newEncoder(format string) (zapcore.Encoder, error) {
+	switch format {
+	case FormatJSON, "":
+		return zapcore.NewJSONEncoder(encoderConfig()), nil
+	case FormatConsole:
+		return zapcore.NewConsoleEncoder(encoderConfig()), nil
+	case FormatLogfmt:
+		return zaplogfmt.NewEncoder(encoderConfig()), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
 	}
+}
 
-	return zap.New(core).Sugar()
+// newSink returns the destination for output.
+func newSink(output string, file FileOptions) (zapcore.WriteSyncer, error) {
+	switch output {
+	case OutputStdout, "":
+		return zapcore.Lock(os.Stdout), nil
+	case OutputStderr:
+		return zapcore.Lock(os.Stderr), nil
+	case OutputFile:
+		if file.Path == "" {
+			return nil, fmt.Errorf("log output %q requires a file path", output)
+		}
+		return zapcore.AddSync(&lumberjack.Logger{
+			Filename:   file.Path,
+			MaxSize:    file.MaxSizeMB,
+			MaxAge:     file.MaxAgeDays,
+			MaxBackups: file.MaxBackups,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown log output %q", output)
+	}
 }
@@ -2,9 +2,15 @@ package logger
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // TestNew_DebugLevel_PrintsDebug verifies that a logger at "debug" level
@@ -34,3 +40,94 @@ func TestNew_InfoLevel_SkipsDebug(t *testing.T) {
 	assert.NotContains(t, output, "skip this")
 	assert.Contains(t, output, "include this")
 }
+
+// TestNewWithLevel_FollowsAtomicLevel verifies level changes apply to an existing logger.
+func TestNewWithLevel_FollowsAtomicLevel(t *testing.T) {
+	var buf bytes.Buffer
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	log := NewWithLevel(level, &buf)
+
+	log.Debug("hidden")
+	level.SetLevel(zapcore.DebugLevel)
+	log.Debug("shown")
+
+	output := buf.String()
+	assert.NotContains(t, output, "hidden")
+	assert.Contains(t, output, "shown")
+}
+
+// TestBuild_Formats verifies each format renders entries with its own encoding to a rotated file.
+func TestBuild_Formats(t *testing.T) {
+	tests := map[string]string{
+		FormatJSON:    `"msg":"hello"`,
+		FormatConsole: "\tinfo\thello\t",
+		FormatLogfmt:  "msg=hello",
+	}
+
+	for format, want := range tests {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.log")
+			log, err := Build(Options{Format: format, Output: OutputFile, File: FileOptions{Path: path, MaxSizeMB: 1}})
+			require.NoError(t, err)
+
+			log.Infow("hello", "user", "42")
+			require.NoError(t, log.Sync())
+
+			content := readFile(t, path)
+			assert.Contains(t, content, want)
+			assert.Contains(t, content, "42")
+		})
+	}
+}
+
+// TestBuild_Sampling verifies repeated entries are dropped once sampling starts.
+func TestBuild_Sampling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := Build(Options{Output: OutputFile, File: FileOptions{Path: path}, SamplingInitial: 2, SamplingThereafter: 1000})
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		log.Info("repeated")
+	}
+
+	assert.Equal(t, 2, strings.Count(readFile(t, path), "repeated"))
+}
+
+// TestBuild_CallerAndStacktrace verifies caller and stack trace annotations follow the options.
+func TestBuild_CallerAndStacktrace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := Build(Options{Output: OutputFile, File: FileOptions{Path: path}, Caller: true, StacktraceLevel: "warn"})
+	require.NoError(t, err)
+
+	log.Info("plain")
+	log.Warn("traced")
+
+	lines := strings.Split(strings.TrimSpace(readFile(t, path)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"caller":"logger/logger_test.go`)
+	assert.NotContains(t, lines[0], "stacktrace")
+	assert.Contains(t, lines[1], "stacktrace")
+}
+
+// TestBuild_InvalidOptions verifies unknown settings are rejected.
+func TestBuild_InvalidOptions(t *testing.T) {
+	_, err := Build(Options{Format: "xml"})
+	assert.EqualError(t, err, `unknown log format "xml"`)
+
+	_, err = Build(Options{Output: "syslog"})
+	assert.EqualError(t, err, `unknown log output "syslog"`)
+
+	_, err = Build(Options{Output: OutputFile})
+	assert.Error(t, err)
+
+	_, err = Build(Options{StacktraceLevel: "loud"})
+	assert.Error(t, err)
+}
+
+// readFile returns the content of path.
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(content)
+}
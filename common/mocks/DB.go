@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	database "github.com/flockstore/mannaiah-backend/common/database"
 	mock "github.com/stretchr/testify/mock"
 
 	pgconn "github.com/jackc/pgx/v5/pgconn"
@@ -17,6 +18,36 @@ type DB struct {
 	mock.Mock
 }
 
+// BeginTx provides a mock function with given fields: ctx, opts
+func (_m *DB) BeginTx(ctx context.Context, opts database.TxOptions) (database.Tx, error) {
+	ret := _m.Called(ctx, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginTx")
+	}
+
+	var r0 database.Tx
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.TxOptions) (database.Tx, error)); ok {
+		return rf(ctx, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.TxOptions) database.Tx); ok {
+		r0 = rf(ctx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(database.Tx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.TxOptions) error); ok {
+		r1 = rf(ctx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Exec provides a mock function with given fields: ctx, sql, args
 func (_m *DB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
 	var _ca []interface{}
@@ -104,6 +135,24 @@ func (_m *DB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx
 	return r0
 }
 
+// WithTx provides a mock function with given fields: ctx, opts, fn
+func (_m *DB) WithTx(ctx context.Context, opts database.TxOptions, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, opts, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.TxOptions, func(context.Context) error) error); ok {
+		r0 = rf(ctx, opts, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewDB creates a new instance of DB. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDB(t interface {
@@ -0,0 +1,212 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pathParam matches Fiber-style path parameters such as ":id".
+var pathParam = regexp.MustCompile(`:([A-Za-z0-9_]+)\??`)
+
+// Operation describes an HTTP endpoint and the payloads it exchanges.
+type Operation struct {
+	// Method is the HTTP method (e.g. "GET").
+	Method string
+
+	// Path is the route path relative to its group, using Fiber syntax (e.g. "/:id").
+	Path string
+
+	// OperationID is the unique, stable identifier of the operation.
+	OperationID string
+
+	// Summary is a short human-readable description.
+	Summary string
+
+	// Request is a zero value of the request body type, or nil if none.
+	Request any
+
+	// Query is a zero value of a struct whose fields tagged `query:"name"`
+	// are the query parameters, or nil if none.
+	Query any
+
+	// Response is a zero value of the success payload type, or nil for empty responses.
+	Response any
+
+	// Status is the success status code.
+	Status int
+
+	// Errors lists the error status codes the operation may return.
+	Errors []int
+}
+
+// Group is a set of operations mounted under a common prefix.
+type Group struct {
+	// Prefix is the mount path of the group (e.g. "/contacts").
+	Prefix string
+
+	// Tag groups the operations in documentation.
+	Tag string
+
+	// Operations are the endpoints of the group.
+	Operations []Operation
+}
+
+// Options tunes how payloads are rendered by Build.
+type Options struct {
+	// ErrorModel is a zero value of the error payload type shared by all operations.
+	ErrorModel any
+
+	// Envelope optionally wraps success payload schemas (e.g. in a {data, requestId} object).
+	Envelope func(data *Schema) *Schema
+}
+
+// Build generates an OpenAPI document for the given operation groups.
+func Build(info Info, opts Options, groups ...Group) *Document {
+	registry := NewSchemaRegistry()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]*PathItem{},
+	}
+
+	var errorSchema *Schema
+	if opts.ErrorModel != nil {
+		errorSchema = registry.SchemaFor(opts.ErrorModel)
+	}
+
+	for _, g := range groups {
+		for _, op := range g.Operations {
+			path, params := toOpenAPIPath(joinPath(g.Prefix, op.Path))
+
+			item := doc.Paths[path]
+			if item == nil {
+				item = &PathItem{}
+				doc.Paths[path] = item
+			}
+
+			obj := &OperationObject{
+				OperationID: op.OperationID,
+				Summary:     op.Summary,
+				Parameters:  append(params, queryParams(registry, op.Query)...),
+				Responses:   map[string]*Response{},
+			}
+			if g.Tag != "" {
+				obj.Tags = []string{g.Tag}
+			}
+
+			if op.Request != nil {
+				obj.RequestBody = &RequestBody{
+					Required: true,
+					Content:  jsonContent(registry.SchemaFor(op.Request)),
+				}
+			}
+
+			success := &Response{Description: http.StatusText(op.Status)}
+			if op.Response != nil {
+				schema := registry.SchemaFor(op.Response)
+				if opts.Envelope != nil {
+					schema = opts.Envelope(schema)
+				}
+				success.Content = jsonContent(schema)
+			}
+			obj.Responses[strconv.Itoa(op.Status)] = success
+
+			for _, code := range op.Errors {
+				resp := &Response{Description: http.StatusText(code)}
+				if errorSchema != nil {
+					resp.Content = jsonContent(errorSchema)
+				}
+				obj.Responses[strconv.Itoa(code)] = resp
+			}
+
+			setOperation(item, op.Method, obj)
+		}
+	}
+
+	doc.Components.Schemas = registry.Schemas()
+	return doc
+}
+
+// jsonContent wraps a schema as an application/json media type.
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
+
+// joinPath concatenates a prefix and a route path without duplicate or trailing slashes.
+func joinPath(prefix, path string) string {
+	full := strings.TrimRight(prefix, "/") + "/" + strings.TrimLeft(path, "/")
+	if len(full) > 1 {
+		full = strings.TrimRight(full, "/")
+	}
+	return full
+}
+
+// toOpenAPIPath converts Fiber parameters to OpenAPI templates and describes them.
+func toOpenAPIPath(path string) (string, []Parameter) {
+	var params []Parameter
+	for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+		params = append(params, Parameter{
+			Name:     m[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	return pathParam.ReplaceAllString(path, "{$1}"), params
+}
+
+// queryParams describes the `query` tagged fields of v as query parameters.
+func queryParams(registry *SchemaRegistry, v any) []Parameter {
+	if v == nil {
+		return nil
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("query"), ",")
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		schema := registry.schemaForType(field.Type)
+		param := Parameter{
+			Name:     name,
+			In:       "query",
+			Required: applyValidateTag(schema, field.Tag.Get("validate")),
+			Schema:   schema,
+		}
+		if field.Type.Kind() == reflect.Map {
+			// Maps are sent as name[key]=value.
+			param.Style, param.Explode = "deepObject", true
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// setOperation assigns the operation to the slot matching its HTTP method.
+func setOperation(item *PathItem, method string, op *OperationObject) {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		item.Get = op
+	case http.MethodPost:
+		item.Post = op
+	case http.MethodPut:
+		item.Put = op
+	case http.MethodPatch:
+		item.Patch = op
+	case http.MethodDelete:
+		item.Delete = op
+	}
+}
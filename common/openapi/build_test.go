@@ -0,0 +1,102 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sampleInput struct {
+	Name    string    `json:"name" validate:"required,max=50"`
+	Code    string    `json:"code" validate:"required,len=5,numeric"`
+	Email   *string   `json:"email,omitempty" validate:"omitempty,email"`
+	Kind    string    `json:"kind" validate:"oneof=a b"`
+	Count   int       `json:"count" validate:"gte=1,lte=10"`
+	When    time.Time `json:"when"`
+	Tags    []string  `json:"tags"`
+	Labels  []string  `json:"labels" validate:"required,min=1,max=5,dive,required,max=64"`
+	private string
+}
+
+type sampleQuery struct {
+	Search string            `query:"q" validate:"required"`
+	Sort   string            `query:"sort" validate:"omitempty,oneof=asc desc"`
+	Attrs  map[string]string `query:"attr"`
+	Ignore string
+}
+
+type sampleError struct {
+	Message string `json:"message"`
+}
+
+// TestBuild_PathsAndParameters verifies that Fiber paths become OpenAPI templates.
+func TestBuild_PathsAndParameters(t *testing.T) {
+	doc := Build(Info{Title: "test", Version: "1"}, Options{ErrorModel: sampleError{}}, Group{
+		Prefix: "/items",
+		Tag:    "items",
+		Operations: []Operation{
+			{Method: "POST", Path: "/", OperationID: "createItem", Request: sampleInput{}, Response: sampleInput{}, Status: 201, Errors: []int{400}},
+			{Method: "GET", Path: "/", OperationID: "listItems", Query: sampleQuery{}, Response: []sampleInput{}, Status: 200},
+			{Method: "GET", Path: "/:id", OperationID: "getItem", Response: sampleInput{}, Status: 200, Errors: []int{404}},
+			{Method: "DELETE", Path: "/:id", OperationID: "deleteItem", Status: 204},
+		},
+	})
+
+	assert.Equal(t, Version, doc.OpenAPI)
+	require.Contains(t, doc.Paths, "/items")
+	require.Contains(t, doc.Paths, "/items/{id}")
+
+	get := doc.Paths["/items/{id}"].Get
+	require.NotNil(t, get)
+	require.Len(t, get.Parameters, 1)
+	assert.Equal(t, "id", get.Parameters[0].Name)
+	assert.Equal(t, "path", get.Parameters[0].In)
+	assert.Contains(t, get.Responses, "404")
+
+	post := doc.Paths["/items"].Post
+	require.NotNil(t, post)
+	assert.Equal(t, "#/components/schemas/sampleInput", post.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/sampleError", post.Responses["400"].Content["application/json"].Schema.Ref)
+
+	assert.Empty(t, doc.Paths["/items/{id}"].Delete.Responses["204"].Content)
+
+	list := doc.Paths["/items"].Get
+	require.NotNil(t, list)
+	require.Len(t, list.Parameters, 3)
+	assert.Equal(t, "q", list.Parameters[0].Name)
+	assert.Equal(t, "query", list.Parameters[0].In)
+	assert.True(t, list.Parameters[0].Required)
+	assert.Equal(t, "sort", list.Parameters[1].Name)
+	assert.False(t, list.Parameters[1].Required)
+	assert.Equal(t, []string{"asc", "desc"}, list.Parameters[1].Schema.Enum)
+	assert.Equal(t, "attr", list.Parameters[2].Name)
+	assert.Equal(t, "deepObject", list.Parameters[2].Style)
+	assert.True(t, list.Parameters[2].Explode)
+}
+
+// TestSchemaFor_ValidateTags verifies that validator rules become schema constraints.
+func TestSchemaFor_ValidateTags(t *testing.T) {
+	registry := NewSchemaRegistry()
+	registry.SchemaFor(sampleInput{})
+	s := registry.Schemas()["sampleInput"]
+	require.NotNil(t, s)
+
+	assert.Equal(t, []string{"name", "code", "labels"}, s.Required)
+	assert.Equal(t, 50, *s.Properties["name"].MaxLength)
+	assert.Equal(t, 5, *s.Properties["code"].MinLength)
+	assert.Equal(t, 5, *s.Properties["code"].MaxLength)
+	assert.Equal(t, numericPattern, s.Properties["code"].Pattern)
+	assert.Equal(t, "email", s.Properties["email"].Format)
+	assert.Equal(t, []string{"a", "b"}, s.Properties["kind"].Enum)
+	assert.Equal(t, 1.0, *s.Properties["count"].Minimum)
+	assert.Equal(t, 10.0, *s.Properties["count"].Maximum)
+	assert.Equal(t, "date-time", s.Properties["when"].Format)
+	assert.Equal(t, "array", s.Properties["tags"].Type)
+	assert.Equal(t, 1, *s.Properties["labels"].MinItems)
+	assert.Equal(t, 5, *s.Properties["labels"].MaxItems)
+	assert.Equal(t, 1, *s.Properties["labels"].Items.MinLength)
+	assert.Equal(t, 64, *s.Properties["labels"].Items.MaxLength)
+	assert.NotContains(t, s.Properties, "private")
+}
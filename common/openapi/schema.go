@@ -0,0 +1,247 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// numericPattern mirrors the validator "numeric" rule.
+const numericPattern = `^[-+]?[0-9]+(?:\.[0-9]+)?$`
+
+// timeType is used to render time.Time values as RFC 3339 strings.
+var timeType = reflect.TypeOf(time.Time{})
+
+// SchemaRegistry collects named component schemas while reflecting Go types.
+type SchemaRegistry struct {
+	schemas map[string]*Schema
+}
+
+// NewSchemaRegistry creates an empty registry.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: map[string]*Schema{}}
+}
+
+// Schemas returns the collected component schemas keyed by type name.
+func (r *SchemaRegistry) Schemas() map[string]*Schema {
+	return r.schemas
+}
+
+// SchemaFor returns the schema for the given value's type. Named structs are
+// registered as components and referenced through $ref.
+func (r *SchemaRegistry) SchemaFor(v any) *Schema {
+	return r.schemaForType(reflect.TypeOf(v))
+}
+
+// schemaForType maps a Go type onto a JSON Schema.
+func (r *SchemaRegistry) schemaForType(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaForType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaForType(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		if _, ok := r.schemas[t.Name()]; !ok {
+			// Reserve the name first so recursive types terminate.
+			r.schemas[t.Name()] = &Schema{}
+			*r.schemas[t.Name()] = *r.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema builds an object schema from exported fields and their json/validate tags.
+func (r *SchemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// Embedded structs without a json name are flattened, like encoding/json does.
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := r.structSchema(embedded)
+				for k, v := range inner.Properties {
+					s.Properties[k] = v
+				}
+				s.Required = append(s.Required, inner.Required...)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := r.schemaForType(field.Type)
+		if applyValidateTag(prop, field.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+
+	return s
+}
+
+// applyValidateTag translates validator rules into schema constraints.
+// Rules following "dive" apply to the elements of an array, as in the validator.
+// It reports whether the field is required.
+func applyValidateTag(s *Schema, tag string) bool {
+	if tag == "" || tag == "-" || s.Ref != "" {
+		return false
+	}
+
+	rules := strings.Split(tag, ",")
+	optional := false
+	required := false
+	target := s
+
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			if target != s || s.Items == nil || s.Items.Ref != "" {
+				// Nested or referenced elements cannot carry inline constraints.
+				return required && !optional
+			}
+			target = s.Items
+		case "omitempty":
+			if target == s {
+				optional = true
+			}
+		case "required":
+			if target == s {
+				required = true
+			}
+			if target.Type == "string" {
+				target.MinLength = maxInt(target.MinLength, 1)
+			}
+		default:
+			applyRule(target, name, param)
+		}
+	}
+
+	return required && !optional
+}
+
+// applyRule translates a single validator rule other than presence rules.
+func applyRule(s *Schema, name, param string) {
+	switch name {
+	case "len":
+		if n, err := strconv.Atoi(param); err == nil {
+			setLength(s, &n, &n)
+		}
+	case "min":
+		if n, err := strconv.Atoi(param); err == nil {
+			setLength(s, &n, nil)
+		}
+	case "max":
+		if n, err := strconv.Atoi(param); err == nil {
+			setLength(s, nil, &n)
+		}
+	case "gte":
+		s.Minimum = parseFloat(param)
+	case "lte":
+		s.Maximum = parseFloat(param)
+	case "gt":
+		s.ExclusiveMinimum = parseFloat(param)
+	case "lt":
+		s.ExclusiveMaximum = parseFloat(param)
+	case "numeric":
+		s.Pattern = numericPattern
+	case "email":
+		s.Format = "email"
+	case "url", "uri":
+		s.Format = "uri"
+	case "uuid", "uuid4":
+		s.Format = "uuid"
+	case "datetime":
+		s.Format = "date-time"
+	case "oneof":
+		s.Enum = strings.Fields(param)
+	}
+}
+
+// setLength applies min/max to strings (length), arrays (items) or numbers (value).
+func setLength(s *Schema, min, max *int) {
+	switch s.Type {
+	case "string":
+		if min != nil {
+			s.MinLength = min
+		}
+		if max != nil {
+			s.MaxLength = max
+		}
+	case "array":
+		if min != nil {
+			s.MinItems = min
+		}
+		if max != nil {
+			s.MaxItems = max
+		}
+	case "integer", "number":
+		if min != nil {
+			f := float64(*min)
+			s.Minimum = &f
+		}
+		if max != nil {
+			f := float64(*max)
+			s.Maximum = &f
+		}
+	}
+}
+
+// maxInt returns a pointer to the greater of the current value and n.
+func maxInt(current *int, n int) *int {
+	if current != nil && *current > n {
+		return current
+	}
+	return &n
+}
+
+// parseFloat returns a pointer to the parsed value, or nil if invalid.
+func parseFloat(param string) *float64 {
+	f, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
@@ -0,0 +1,250 @@
+package pii
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Tag is the struct tag marking personal data. Its value selects how the
+// field is masked, e.g. `pii:"email"`.
+const Tag = "pii"
+
+// Kind selects how a value is masked.
+type Kind string
+
+const (
+	// KindEmail keeps the first character and the domain: j***@flock.com.
+	KindEmail Kind = "email"
+
+	// KindDocument keeps the last four characters: ******6789.
+	KindDocument Kind = "document"
+
+	// KindPhone keeps the last four digits: ******4567.
+	KindPhone Kind = "phone"
+
+	// KindName keeps the first character: J***.
+	KindName Kind = "name"
+
+	// KindAddress hides the whole value.
+	KindAddress Kind = "address"
+
+	// KindDefault hides the whole value; used for `pii:"true"` and unknown kinds.
+	KindDefault Kind = "true"
+)
+
+// Masked replaces values that are hidden entirely.
+const Masked = "***"
+
+// Policy decides how much of a personal value survives masking.
+type Policy string
+
+const (
+	// PolicyMask keeps the hints described by each Kind, enough to tell
+	// records apart while debugging.
+	PolicyMask Policy = "mask"
+
+	// PolicyRedact hides every value entirely.
+	PolicyRedact Policy = "redact"
+
+	// PolicyOff logs values as they are. Only meant for local development.
+	PolicyOff Policy = "off"
+)
+
+// policy is the process-wide masking policy; unset means PolicyMask.
+var policy atomic.Value
+
+// SetPolicy changes the process-wide masking policy.
+func SetPolicy(p Policy) {
+	policy.Store(p)
+}
+
+// CurrentPolicy returns the process-wide masking policy.
+func CurrentPolicy() Policy {
+	if p, ok := policy.Load().(Policy); ok {
+		return p
+	}
+	return PolicyMask
+}
+
+// ParsePolicy converts a configuration value into a Policy.
+func ParsePolicy(s string) (Policy, error) {
+	switch p := Policy(s); p {
+	case PolicyMask, PolicyRedact, PolicyOff:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown pii policy %q", s)
+	}
+}
+
+// Mask hides value according to kind and the current policy.
+// Empty values are returned unchanged.
+func Mask(kind Kind, value string) string {
+	if value == "" {
+		return ""
+	}
+
+	switch CurrentPolicy() {
+	case PolicyOff:
+		return value
+	case PolicyRedact:
+		return Masked
+	}
+
+	switch kind {
+	case KindEmail:
+		local, domain, ok := strings.Cut(value, "@")
+		if !ok || local == "" {
+			return Masked
+		}
+		return firstRune(local) + Masked + "@" + domain
+	case KindDocument, KindPhone:
+		n := utf8.RuneCountInString(value)
+		if n <= 4 {
+			return strings.Repeat("*", n)
+		}
+		runes := []rune(value)
+		return strings.Repeat("*", n-4) + string(runes[n-4:])
+	case KindName:
+		return firstRune(value) + Masked
+	default:
+		return Masked
+	}
+}
+
+// firstRune returns the first character of s.
+func firstRune(s string) string {
+	r, _ := utf8.DecodeRuneInString(s)
+	return string(r)
+}
+
+var (
+	// emailPattern matches email addresses embedded in free text.
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+	// numberPattern matches runs of six or more digits, optionally separated
+	// by spaces or dashes, as found in document and phone numbers.
+	numberPattern = regexp.MustCompile(`\+?\b\d(?:[ -]?\d){5,}\b`)
+)
+
+// Scrub masks email addresses and long numbers found in free text such as
+// error messages, which may echo request content.
+func Scrub(s string) string {
+	if CurrentPolicy() == PolicyOff {
+		return s
+	}
+	s = emailPattern.ReplaceAllStringFunc(s, func(m string) string { return Mask(KindEmail, m) })
+	return numberPattern.ReplaceAllStringFunc(s, func(m string) string { return Mask(KindDocument, m) })
+}
+
+// Redact returns v as a map keyed by JSON field names, with every field
+// tagged pii masked. Embedded structs are flattened like encoding/json does.
+// Values other than structs and pointers to structs are returned unchanged.
+func Redact(v any) any {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return v
+	}
+
+	out := make(map[string]any)
+	redactInto(out, rv)
+	return out
+}
+
+// redactInto copies the exported fields of struct v into out.
+func redactInto(out map[string]any, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		if field.Anonymous && name == "" && fv.Kind() == reflect.Struct {
+			redactInto(out, fv)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		kind, tagged := field.Tag.Lookup(Tag)
+		switch {
+		case tagged:
+			out[name] = maskValue(Kind(kind), fv)
+		case !fv.CanInterface():
+			continue
+		case fv.Kind() == reflect.Struct || (fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct):
+			out[name] = Redact(fv.Interface())
+		default:
+			out[name] = fv.Interface()
+		}
+	}
+}
+
+// maskValue masks a tagged field, which may be a string or a pointer to one.
+func maskValue(kind Kind, v reflect.Value) any {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.String {
+		return Masked
+	}
+	return Mask(kind, v.String())
+}
+
+// Values returns the raw, non-empty values of every pii tagged field of v,
+// including nested structs. Tests use it to look for leaks.
+func Values(v any) []string {
+	return valuesOf(reflect.ValueOf(v))
+}
+
+// valuesOf collects the tagged values of v, which may be unexported.
+func valuesOf(v reflect.Value) []string {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var values []string
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+		if _, tagged := field.Tag.Lookup(Tag); !tagged {
+			values = append(values, valuesOf(fv)...)
+			continue
+		}
+
+		if fv.Kind() == reflect.Ptr && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.String && fv.String() != "" {
+			values = append(values, fv.String())
+		}
+	}
+	return values
+}
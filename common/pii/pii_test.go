@@ -0,0 +1,149 @@
+package pii
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// person is a test type mixing tagged, untagged, embedded and nested fields.
+type person struct {
+	audit
+
+	ID      string  `json:"id"`
+	Email   string  `json:"email" pii:"email"`
+	Phone   *string `json:"phone,omitempty" pii:"phone"`
+	Name    string  `pii:"name"`
+	Address address `json:"address"`
+	Secret  string  `json:"-" pii:"true"`
+}
+
+// audit is embedded into person like domain.Auditable.
+type audit struct {
+	CreatedBy string `json:"createdBy" pii:"email"`
+	Version   int    `json:"version"`
+}
+
+// address is nested into person.
+type address struct {
+	Line string `json:"line" pii:"address"`
+	City string `json:"city"`
+}
+
+// withPolicy sets p for the duration of the test.
+func withPolicy(t *testing.T, p Policy) {
+	t.Helper()
+	SetPolicy(p)
+	t.Cleanup(func() { SetPolicy(PolicyMask) })
+}
+
+// TestMask verifies each kind keeps only its hint.
+func TestMask(t *testing.T) {
+	tests := []struct {
+		kind  Kind
+		value string
+		want  string
+	}{
+		{KindEmail, "john@flock.com", "j***@flock.com"},
+		{KindEmail, "not-an-email", "***"},
+		{KindDocument, "1023456789", "******6789"},
+		{KindDocument, "123", "***"},
+		{KindPhone, "3001234567", "******4567"},
+		{KindName, "Ángela", "Á***"},
+		{KindAddress, "Calle 1 # 2-3", "***"},
+		{KindDefault, "anything", "***"},
+		{KindEmail, "", ""},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, Mask(tc.kind, tc.value), "%s %q", tc.kind, tc.value)
+	}
+}
+
+// TestMask_Policies verifies the global policy overrides partial masking.
+func TestMask_Policies(t *testing.T) {
+	withPolicy(t, PolicyRedact)
+	assert.Equal(t, "***", Mask(KindEmail, "john@flock.com"))
+
+	SetPolicy(PolicyOff)
+	assert.Equal(t, "john@flock.com", Mask(KindEmail, "john@flock.com"))
+	assert.Equal(t, "call 3001234567", Scrub("call 3001234567"))
+}
+
+// TestParsePolicy verifies configuration values are validated.
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy("redact")
+	assert.NoError(t, err)
+	assert.Equal(t, PolicyRedact, p)
+
+	_, err = ParsePolicy("hide")
+	assert.Error(t, err)
+}
+
+// TestScrub verifies emails and long numbers are masked in free text while IDs survive.
+func TestScrub(t *testing.T) {
+	in := `duplicate key (doc_type, doc_number)=(CC, 1023456789) for john@flock.com, phone +57 300 123 4567, id 9f1c2a33-4b5d-4e6f-8a7b-123456789abc`
+	out := Scrub(in)
+
+	assert.NotContains(t, out, "1023456789")
+	assert.NotContains(t, out, "john@flock.com")
+	assert.NotContains(t, out, "300 123 4567")
+	assert.Contains(t, out, "******6789")
+	assert.Contains(t, out, "j***@flock.com")
+	assert.Contains(t, out, "9f1c2a33-4b5d-4e6f-8a7b-123456789abc")
+}
+
+// TestRedact verifies tagged fields are masked and others kept under their JSON names.
+func TestRedact(t *testing.T) {
+	phone := "3001234567"
+	p := person{
+		audit:   audit{CreatedBy: "admin@flock.com", Version: 2},
+		ID:      "c-1",
+		Email:   "john@flock.com",
+		Phone:   &phone,
+		Name:    "John",
+		Address: address{Line: "Calle 1", City: "11001"},
+		Secret:  "hidden",
+	}
+
+	assert.Equal(t, map[string]any{
+		"createdBy": "a***@flock.com",
+		"version":   2,
+		"id":        "c-1",
+		"email":     "j***@flock.com",
+		"phone":     "******4567",
+		"Name":      "J***",
+		"address":   map[string]any{"line": "***", "city": "11001"},
+	}, Redact(&p))
+
+	assert.Equal(t, "plain", Redact("plain"))
+	assert.Nil(t, Redact((*person)(nil)))
+}
+
+// TestValues verifies raw tagged values are collected from nested structs.
+func TestValues(t *testing.T) {
+	phone := "3001234567"
+	p := person{Email: "john@flock.com", Phone: &phone, Address: address{Line: "Calle 1", City: "11001"}}
+
+	assert.ElementsMatch(t, []string{"john@flock.com", "3001234567", "Calle 1"}, Values(p))
+}
+
+// TestFields verifies the zap helpers never log raw values.
+func TestFields(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(core)
+
+	log.Info("contact",
+		Object("contact", person{Email: "john@flock.com"}),
+		String("email", KindEmail, "john@flock.com"),
+		Error(errors.New("bad value john@flock.com")),
+	)
+
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "j***@flock.com", fields["email"])
+	assert.Equal(t, "bad value j***@flock.com", fields["error"])
+	assert.Equal(t, "j***@flock.com", fields["contact"].(map[string]any)["email"])
+}
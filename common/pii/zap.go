@@ -0,0 +1,23 @@
+package pii
+
+import "go.uber.org/zap"
+
+// Object returns a zap field holding v with its pii tagged fields masked.
+func Object(key string, v any) zap.Field {
+	return zap.Any(key, Redact(v))
+}
+
+// String returns a zap field holding value masked as kind.
+func String(key string, kind Kind, value string) zap.Field {
+	return zap.String(key, Mask(kind, value))
+}
+
+// Error returns a zap field holding the message of err with emails and long
+// numbers masked. Use it for errors that may echo request content, such as
+// body parsing failures.
+func Error(err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+	return zap.String("error", Scrub(err.Error()))
+}
@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// pruneEvery controls how many calls happen between sweeps of expired keys.
+const pruneEvery = 1024
+
+// MemoryStore keeps counters in process memory.
+// It is suitable for a single replica or for tests.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]time.Time
+	windows map[string]windowCounter
+	calls   int
+}
+
+// windowCounter holds hit counts for the current and previous fixed windows.
+type windowCounter struct {
+	start    time.Time
+	current  int
+	previous int
+	window   time.Duration
+}
+
+// NewMemoryStore creates an empty in-memory Store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]time.Time{},
+		windows: map[string]windowCounter{},
+	}
+}
+
+// Allow registers a hit for key and reports whether it fits within rule.
+func (s *MemoryStore) Allow(_ context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.calls%pruneEvery == 0 {
+		s.prune(now)
+	}
+
+	switch rule.Algorithm {
+	case TokenBucket:
+		res, tat := gcra(rule, s.buckets[key], now)
+		if res.Allowed {
+			s.buckets[key] = tat
+		}
+		return res, nil
+
+	case SlidingWindow:
+		start := now.Truncate(rule.Window)
+		wc := s.windows[key]
+		switch {
+		case wc.start.Equal(start):
+		case wc.start.Add(rule.Window).Equal(start):
+			wc = windowCounter{start: start, previous: wc.current}
+		default:
+			wc = windowCounter{start: start}
+		}
+		res := slidingWindow(rule, wc.current, wc.previous, now)
+		if res.Allowed {
+			wc.current++
+		}
+		wc.window = rule.Window
+		s.windows[key] = wc
+		return res, nil
+
+	default:
+		return Result{}, fmt.Errorf("unsupported rate limit algorithm %q", rule.Algorithm)
+	}
+}
+
+// prune drops keys whose state can no longer affect future decisions.
+func (s *MemoryStore) prune(now time.Time) {
+	for key, tat := range s.buckets {
+		if tat.Before(now) {
+			delete(s.buckets, key)
+		}
+	}
+	for key, wc := range s.windows {
+		if wc.start.Add(2 * wc.window).Before(now) {
+			delete(s.windows, key)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMemoryStore_TokenBucket verifies bursts up to the limit and gradual refill.
+func TestMemoryStore_TokenBucket(t *testing.T) {
+	store := NewMemoryStore()
+	rule := Rule{Algorithm: TokenBucket, Limit: 3, Window: 3 * time.Second}
+	now := time.Unix(1_700_000_000, 0)
+
+	for i := 0; i < 3; i++ {
+		res, err := store.Allow(context.Background(), "k", rule, now)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2-i, res.Remaining)
+	}
+
+	res, err := store.Allow(context.Background(), "k", rule, now)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, time.Second, res.RetryAfter)
+
+	res, err = store.Allow(context.Background(), "k", rule, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	res, err = store.Allow(context.Background(), "other", rule, now)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "keys must be isolated")
+}
+
+// TestMemoryStore_SlidingWindow verifies the limit within a window and the weighted carry-over.
+func TestMemoryStore_SlidingWindow(t *testing.T) {
+	store := NewMemoryStore()
+	rule := Rule{Algorithm: SlidingWindow, Limit: 2, Window: time.Minute}
+	start := time.Unix(1_700_000_040, 0).Truncate(time.Minute)
+
+	for i := 0; i < 2; i++ {
+		res, err := store.Allow(context.Background(), "k", rule, start)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+	}
+
+	res, err := store.Allow(context.Background(), "k", rule, start.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 30*time.Second, res.RetryAfter)
+
+	// Halfway through the next window, half of the previous window's two allowed hits still count.
+	res, err = store.Allow(context.Background(), "k", rule, start.Add(90*time.Second))
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	res, err = store.Allow(context.Background(), "k", rule, start.Add(90*time.Second))
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+
+	res, err = store.Allow(context.Background(), "k", rule, start.Add(150*time.Second))
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+// TestMemoryStore_UnknownAlgorithm ensures unsupported algorithms are rejected.
+func TestMemoryStore_UnknownAlgorithm(t *testing.T) {
+	_, err := NewMemoryStore().Allow(context.Background(), "k", Rule{Algorithm: "leaky", Limit: 1, Window: time.Second}, time.Now())
+	assert.Error(t, err)
+}
+
+// TestMemoryStore_SlidingWindowIgnoresRejectedHits ensures a client retrying while
+// limited is not kept blocked by its own rejected attempts.
+func TestMemoryStore_SlidingWindowIgnoresRejectedHits(t *testing.T) {
+	store := NewMemoryStore()
+	rule := Rule{Algorithm: SlidingWindow, Limit: 2, Window: time.Minute}
+	start := time.Unix(1_700_000_040, 0).Truncate(time.Minute)
+
+	for i := 0; i < 60; i++ {
+		res, err := store.Allow(context.Background(), "k", rule, start.Add(time.Duration(i)*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, i < 2, res.Allowed, "second %d", i)
+	}
+
+	res, err := store.Allow(context.Background(), "k", rule, start.Add(90*time.Second))
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
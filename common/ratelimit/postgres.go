@@ -0,0 +1,141 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresStore keeps counters in PostgreSQL so limits are shared across replicas.
+// It expects the rate_limit_buckets and rate_limit_windows tables to exist.
+type PostgresStore struct {
+	db database.DB
+}
+
+// NewPostgresStore creates a Store backed by the given database.
+func NewPostgresStore(db database.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Allow registers a hit for key and reports whether it fits within rule.
+func (s *PostgresStore) Allow(ctx context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	switch rule.Algorithm {
+	case TokenBucket:
+		return s.allowTokenBucket(ctx, key, rule, now)
+	case SlidingWindow:
+		return s.allowSlidingWindow(ctx, key, rule, now)
+	default:
+		return Result{}, fmt.Errorf("unsupported rate limit algorithm %q", rule.Algorithm)
+	}
+}
+
+// Prune removes counters that expired before the given time.
+// Run it periodically, e.g. with PruneEvery, so the tables do not grow without bound.
+func (s *PostgresStore) Prune(ctx context.Context, before time.Time) error {
+	if _, err := s.db.Exec(ctx, `DELETE FROM rate_limit_buckets WHERE tat < $1`, before.UnixMicro()); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(ctx, `DELETE FROM rate_limit_windows WHERE expires_at < $1`, before.UnixMicro())
+	return err
+}
+
+// PruneEvery calls Prune every interval until ctx is done. Failures are
+// reported to onError and retried on the next tick.
+func (s *PostgresStore) PruneEvery(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.Prune(ctx, now); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// allowTokenBucket advances the stored theoretical arrival time only when the hit is allowed.
+func (s *PostgresStore) allowTokenBucket(ctx context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	query := `
+		INSERT INTO rate_limit_buckets AS b (key, tat)
+		VALUES ($1, $2 + $3)
+		ON CONFLICT (key) DO UPDATE SET tat = GREATEST(b.tat, $2) + $3
+		WHERE GREATEST(b.tat, $2) + $3 - $4 <= $2
+		RETURNING tat
+	`
+
+	nowMicros := now.UnixMicro()
+	var tat int64
+	err := s.db.QueryRow(ctx, query,
+		key, nowMicros, rule.interval().Microseconds(), rule.Window.Microseconds(),
+	).Scan(&tat)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The update was rejected: read the current state to compute the retry delay.
+		if err := s.db.QueryRow(ctx, `SELECT tat FROM rate_limit_buckets WHERE key = $1`, key).Scan(&tat); err != nil {
+			return Result{}, err
+		}
+		res, _ := gcra(rule, time.UnixMicro(tat), now)
+		return res, nil
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	previous := time.UnixMicro(tat).Add(-rule.interval())
+	res, _ := gcra(rule, previous, now)
+	return res, nil
+}
+
+// allowSlidingWindow records the hit in the current window only when the
+// weighted count leaves room for it, so rejected hits are never stored.
+func (s *PostgresStore) allowSlidingWindow(ctx context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	start := now.Truncate(rule.Window)
+
+	var previous int
+	err := s.db.QueryRow(ctx,
+		`SELECT COALESCE((SELECT hits FROM rate_limit_windows WHERE key = $1 AND window_start = $2), 0)`,
+		key, start.Add(-rule.Window).UnixMicro(),
+	).Scan(&previous)
+	if err != nil {
+		return Result{}, err
+	}
+
+	query := `
+		INSERT INTO rate_limit_windows AS w (key, window_start, hits, expires_at)
+		SELECT $1::text, $2::bigint, 1, $3::bigint
+		WHERE $4::int + 1 <= $5::int
+		ON CONFLICT (key, window_start) DO UPDATE SET hits = w.hits + 1
+		WHERE w.hits + 1 + $4::int <= $5::int
+		RETURNING hits
+	`
+
+	var current int
+	err = s.db.QueryRow(ctx, query,
+		key, start.UnixMicro(), start.Add(2*rule.Window).UnixMicro(), carriedHits(rule, previous, now), rule.Limit,
+	).Scan(&current)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The hit was rejected: read the current count to report the state.
+		err = s.db.QueryRow(ctx,
+			`SELECT COALESCE((SELECT hits FROM rate_limit_windows WHERE key = $1 AND window_start = $2), 0)`,
+			key, start.UnixMicro(),
+		).Scan(&current)
+		if err != nil {
+			return Result{}, err
+		}
+		return slidingWindow(rule, current, previous, now), nil
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	return slidingWindow(rule, current-1, previous, now), nil
+}
@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Algorithm identifies the counting strategy used to enforce a Rule.
+type Algorithm string
+
+const (
+	// TokenBucket allows bursts up to Limit and refills continuously over Window.
+	// It is implemented with the generic cell rate algorithm, which only needs
+	// to persist a single timestamp per key.
+	TokenBucket Algorithm = "token_bucket"
+
+	// SlidingWindow approximates a rolling window by weighting the previous
+	// fixed window against the current one.
+	SlidingWindow Algorithm = "sliding_window"
+)
+
+// Rule describes how many requests are allowed per key in a given window.
+type Rule struct {
+	// Algorithm is the counting strategy to apply.
+	Algorithm Algorithm
+
+	// Limit is the maximum number of requests allowed per Window.
+	Limit int
+
+	// Window is the period over which Limit applies.
+	Window time.Duration
+}
+
+// Result is the outcome of a single rate-limit check.
+type Result struct {
+	// Allowed reports whether the request may proceed.
+	Allowed bool
+
+	// Limit is the configured request quota.
+	Limit int
+
+	// Remaining is the number of requests still available in the current window.
+	Remaining int
+
+	// Reset is the time until the quota is fully replenished.
+	Reset time.Duration
+
+	// RetryAfter is the time the client should wait before retrying; zero when allowed.
+	RetryAfter time.Duration
+}
+
+// Store records hits and evaluates rules. Implementations must be safe for
+// concurrent use and apply each check atomically.
+type Store interface {
+	// Allow registers a hit for key and reports whether it fits within rule.
+	Allow(ctx context.Context, key string, rule Rule, now time.Time) (Result, error)
+}
+
+// interval returns the time it takes to earn back a single request.
+func (r Rule) interval() time.Duration {
+	if r.Limit <= 0 {
+		return r.Window
+	}
+	return r.Window / time.Duration(r.Limit)
+}
+
+// gcra evaluates a token bucket given the stored theoretical arrival time (tat).
+// It returns the result and the tat to persist when the request is allowed.
+func gcra(rule Rule, tat, now time.Time) (Result, time.Time) {
+	interval := rule.interval()
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(interval)
+	allowAt := newTat.Add(-rule.Window)
+
+	if now.Before(allowAt) {
+		return Result{
+			Allowed:    false,
+			Limit:      rule.Limit,
+			Remaining:  0,
+			Reset:      tat.Sub(now),
+			RetryAfter: allowAt.Sub(now),
+		}, tat
+	}
+
+	return Result{
+		Allowed:   true,
+		Limit:     rule.Limit,
+		Remaining: int(now.Sub(allowAt) / interval),
+		Reset:     newTat.Sub(now),
+	}, newTat
+}
+
+// slidingWindow evaluates a sliding window given the hit counts of the current
+// and previous fixed windows, not including this hit. Rejected hits must not be
+// recorded, so clients retrying while limited regain access once the window slides.
+func slidingWindow(rule Rule, current, previous int, now time.Time) Result {
+	elapsed := now.Sub(now.Truncate(rule.Window))
+	estimated := carriedHits(rule, previous, now) + current + 1
+
+	reset := rule.Window - elapsed
+	if estimated > rule.Limit {
+		return Result{
+			Allowed:    false,
+			Limit:      rule.Limit,
+			Remaining:  0,
+			Reset:      reset,
+			RetryAfter: reset,
+		}
+	}
+
+	return Result{
+		Allowed:   true,
+		Limit:     rule.Limit,
+		Remaining: rule.Limit - estimated,
+		Reset:     reset,
+	}
+}
+
+// carriedHits returns the share of the previous window's hits that still
+// counts at now, weighted by how much of that window overlaps the rolling one.
+func carriedHits(rule Rule, previous int, now time.Time) int {
+	elapsed := now.Sub(now.Truncate(rule.Window))
+	weight := 1 - float64(elapsed)/float64(rule.Window)
+	return int(float64(previous) * weight)
+}
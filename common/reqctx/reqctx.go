@@ -0,0 +1,51 @@
+package reqctx
+
+import "context"
+
+// ctxKey is the private type used for request-scoped context keys.
+type ctxKey int
+
+const (
+	requestIDKey ctxKey = iota
+	tenantKey
+	principalKey
+)
+
+// WithRequestID returns a copy of ctx carrying the given request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID stored in ctx, or an empty string.
+func RequestID(ctx context.Context) string {
+	return value(ctx, requestIDKey)
+}
+
+// WithTenant returns a copy of ctx carrying the given tenant identifier.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenant)
+}
+
+// Tenant returns the tenant stored in ctx, or an empty string.
+func Tenant(ctx context.Context) string {
+	return value(ctx, tenantKey)
+}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated principal.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// Principal returns the principal stored in ctx, or an empty string.
+func Principal(ctx context.Context) string {
+	return value(ctx, principalKey)
+}
+
+// value reads a string value from ctx, tolerating nil contexts.
+func value(ctx context.Context, key ctxKey) string {
+	if ctx == nil {
+		return ""
+	}
+	v, _ := ctx.Value(key).(string)
+	return v
+}
@@ -0,0 +1,27 @@
+package reqctx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRoundTrip verifies that stored values are returned by their accessors.
+func TestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	ctx = WithRequestID(ctx, "req-1")
+	ctx = WithTenant(ctx, "acme")
+	ctx = WithPrincipal(ctx, "user-42")
+
+	assert.Equal(t, "req-1", RequestID(ctx))
+	assert.Equal(t, "acme", Tenant(ctx))
+	assert.Equal(t, "user-42", Principal(ctx))
+}
+
+// TestEmptyContext ensures accessors return empty strings when nothing is stored.
+func TestEmptyContext(t *testing.T) {
+	assert.Empty(t, RequestID(context.Background()))
+	assert.Empty(t, Tenant(context.Background()))
+	assert.Empty(t, Principal(context.Background()))
+}
@@ -0,0 +1,34 @@
+package testutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/common/pii"
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertNoPII checks that output, typically captured log lines or a response
+// body, contains none of the raw personal data of subjects.
+// - structs contribute the values of their pii tagged fields
+// - strings are looked for as they are
+func AssertNoPII(t *testing.T, output string, subjects ...any) bool {
+	t.Helper()
+
+	var raw []string
+	for _, s := range subjects {
+		if str, ok := s.(string); ok {
+			raw = append(raw, str)
+			continue
+		}
+		raw = append(raw, pii.Values(s)...)
+	}
+
+	ok := true
+	for _, value := range raw {
+		if value != "" && strings.Contains(output, value) {
+			ok = assert.Fail(t, "output contains unmasked personal data", "value %q found in:\n%s", value, output)
+		}
+	}
+	return ok
+}
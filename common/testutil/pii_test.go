@@ -0,0 +1,30 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// customer is a test type with personal data.
+type customer struct {
+	Email string `pii:"email"`
+	Plan  string
+}
+
+// TestAssertNoPII_Masked ensures masked output passes.
+func TestAssertNoPII_Masked(t *testing.T) {
+	c := customer{Email: "john@flock.com", Plan: "pro"}
+	assert.True(t, AssertNoPII(t, `msg=saved email=j***@flock.com plan=pro`, c))
+}
+
+// TestAssertNoPII_Leak ensures raw values from structs or strings fail the check.
+func TestAssertNoPII_Leak(t *testing.T) {
+	mockT := &testing.T{}
+	AssertNoPII(mockT, `msg=saved email=john@flock.com`, customer{Email: "john@flock.com"})
+	assert.True(t, mockT.Failed())
+
+	mockT = &testing.T{}
+	AssertNoPII(mockT, `doc=1023456789`, "1023456789")
+	assert.True(t, mockT.Failed())
+}
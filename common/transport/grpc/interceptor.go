@@ -0,0 +1,244 @@
+package grpctransport
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/auth"
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/pii"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MetadataRequestID is the metadata key carrying the request ID, mirroring X-Request-ID.
+	MetadataRequestID = "x-request-id"
+
+	// MetadataAPIKey is the metadata key carrying the caller's API key, mirroring X-API-Key.
+	MetadataAPIKey = "x-api-key"
+
+	// MetadataTenantID is the metadata key carrying the tenant a call asks to act for, mirroring X-Tenant-ID.
+	MetadataTenantID = "x-tenant-id"
+)
+
+var (
+	// ErrUnauthenticated is returned when a call carries no valid API key.
+	ErrUnauthenticated = apperrors.Unauthenticated("request.unauthenticated", "authentication required")
+
+	// ErrTenantForbidden is returned when an API key asks to act for a tenant it is not bound to.
+	ErrTenantForbidden = apperrors.PermissionDenied("request.tenant_forbidden", "tenant not allowed for this API key")
+)
+
+// publicMethods lists the method prefixes served without authentication,
+// so health probes and tooling keep working.
+var publicMethods = []string{"/grpc.health.v1.Health/", "/grpc.reflection."}
+
+// metadataValue returns the first incoming metadata value stored under key.
+func metadataValue(ctx context.Context, key string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// RequestIDUnaryInterceptor reuses the incoming x-request-id or generates one,
+// stores it in the context and echoes it in the response header.
+func RequestIDUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := requestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataRequestID, id))
+		return handler(reqctx.WithRequestID(ctx, id), req)
+	}
+}
+
+// RequestIDStreamInterceptor is the streaming counterpart of RequestIDUnaryInterceptor.
+func RequestIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := requestID(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(MetadataRequestID, id))
+		return handler(srv, &contextStream{ServerStream: ss, ctx: reqctx.WithRequestID(ss.Context(), id)})
+	}
+}
+
+// requestID returns the incoming x-request-id, or a new one when missing.
+func requestID(ctx context.Context) string {
+	if id := metadataValue(ctx, MetadataRequestID); id != "" {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// AuthUnaryInterceptor verifies the x-api-key metadata against keys and stores
+// the caller's principal and tenant in the context. Calls without a valid key
+// fail with ErrUnauthenticated, and those asking in x-tenant-id for a tenant
+// their key is not bound to with ErrTenantForbidden (see
+// auth.Identity.TenantFor). Health checks and reflection are exempt. When keys
+// is empty nothing can be verified, so x-tenant-id is taken as sent.
+func AuthUnaryInterceptor(keys *auth.APIKeys) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, keys, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor is the streaming counterpart of AuthUnaryInterceptor.
+func AuthStreamInterceptor(keys *auth.APIKeys) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), keys, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate returns ctx carrying the principal owning the call's API key
+// and the tenant it acts for.
+func authenticate(ctx context.Context, keys *auth.APIKeys, method string) (context.Context, error) {
+	for _, prefix := range publicMethods {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+	if !keys.Enabled() {
+		if tenant := strings.TrimSpace(metadataValue(ctx, MetadataTenantID)); tenant != "" {
+			ctx = reqctx.WithTenant(ctx, tenant)
+		}
+		return ctx, nil
+	}
+
+	id, ok := keys.Verify(metadataValue(ctx, MetadataAPIKey))
+	if !ok {
+		return ctx, ErrUnauthenticated
+	}
+	tenant, ok := id.TenantFor(metadataValue(ctx, MetadataTenantID))
+	if !ok {
+		return ctx, ErrTenantForbidden
+	}
+
+	ctx = reqctx.WithPrincipal(ctx, id.Principal)
+	if tenant != "" {
+		ctx = reqctx.WithTenant(ctx, tenant)
+	}
+	return ctx, nil
+}
+
+// contextStream overrides the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the overridden context.
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// LoggingUnaryInterceptor stores a request-scoped logger in the context and
+// logs one line per call with method, status code and latency. It runs inside
+// ErrorUnaryInterceptor so the cause of internal errors is logged before
+// being hidden from the caller.
+func LoggingUnaryInterceptor(l *zap.SugaredLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx = logger.WithContext(ctx, l)
+
+		resp, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// LoggingStreamInterceptor is the streaming counterpart of LoggingUnaryInterceptor.
+func LoggingStreamInterceptor(l *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := logger.WithContext(ss.Context(), l)
+
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// logCall logs the outcome of a call, with the error cause for server-side failures.
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(ToStatus(err))
+	log := logger.FromContext(ctx).With(
+		"method", method,
+		"code", code.String(),
+		"latency", time.Since(start),
+	)
+
+	switch code {
+	case codes.OK:
+		log.Info("rpc completed")
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		log.Errorw("rpc completed", pii.Error(err))
+	default:
+		log.Warn("rpc completed")
+	}
+}
+
+// RecoveryUnaryInterceptor converts panics into codes.Internal errors.
+func RecoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.FromContext(ctx).Errorw("panic recovered",
+					"method", info.FullMethod,
+					"panic", r,
+					"stack", string(debug.Stack()),
+				)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor converts panics in streaming handlers into codes.Internal errors.
+func RecoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.FromContext(ss.Context()).Errorw("panic recovered",
+					"method", info.FullMethod,
+					"panic", r,
+					"stack", string(debug.Stack()),
+				)
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// ErrorUnaryInterceptor converts application errors returned by handlers into gRPC statuses.
+func ErrorUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, ToStatus(err)
+	}
+}
+
+// ErrorStreamInterceptor is the streaming counterpart of ErrorUnaryInterceptor.
+func ErrorStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return ToStatus(handler(srv, ss))
+	}
+}
@@ -0,0 +1,186 @@
+package grpctransport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/flockstore/mannaiah-backend/common/auth"
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testInfo = &grpc.UnaryServerInfo{FullMethod: "/test.v1.Test/Call"}
+
+// TestRequestIDUnaryInterceptor verifies the incoming request ID is propagated to the handler.
+func TestRequestIDUnaryInterceptor(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataRequestID, "rid-1"))
+
+	var seen string
+	_, err := RequestIDUnaryInterceptor()(ctx, nil, testInfo, func(ctx context.Context, _ any) (any, error) {
+		seen = reqctx.RequestID(ctx)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "rid-1", seen)
+
+	_, err = RequestIDUnaryInterceptor()(context.Background(), nil, testInfo, func(ctx context.Context, _ any) (any, error) {
+		seen = reqctx.RequestID(ctx)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, seen, "a request ID must be generated when missing")
+}
+
+// TestRecoveryUnaryInterceptor verifies panics become codes.Internal.
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	_, err := RecoveryUnaryInterceptor()(context.Background(), nil, testInfo, func(context.Context, any) (any, error) {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+// TestErrorUnaryInterceptor verifies application errors map to gRPC codes with their stable reason.
+func TestErrorUnaryInterceptor(t *testing.T) {
+	notFound := apperrors.NotFound("thing.not_found", "thing not found")
+
+	_, err := ErrorUnaryInterceptor()(context.Background(), nil, testInfo, func(context.Context, any) (any, error) {
+		return nil, notFound.Wrap(errors.New("no rows"))
+	})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "thing not found", st.Message())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, "thing.not_found", st.Details()[0].(*errdetails.ErrorInfo).Reason)
+}
+
+// TestErrorUnaryInterceptor_HidesInternalErrors verifies raw errors are not exposed.
+func TestErrorUnaryInterceptor_HidesInternalErrors(t *testing.T) {
+	_, err := ErrorUnaryInterceptor()(context.Background(), nil, testInfo, func(context.Context, any) (any, error) {
+		return nil, errors.New("pq: connection refused")
+	})
+
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "internal server error", st.Message())
+}
+
+// TestAuthUnaryInterceptor verifies calls need a valid x-api-key and carry its principal.
+func TestAuthUnaryInterceptor(t *testing.T) {
+	keys, err := auth.ParseAPIKeys([]string{"orders=0123456789abcdef"})
+	require.NoError(t, err)
+	interceptor := AuthUnaryInterceptor(keys)
+
+	var seen string
+	handler := func(ctx context.Context, _ any) (any, error) {
+		seen = reqctx.Principal(ctx)
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAPIKey, "0123456789abcdef"))
+	_, err = interceptor(ctx, nil, testInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "orders", seen)
+
+	_, err = interceptor(context.Background(), nil, testInfo, handler)
+	assert.ErrorIs(t, err, ErrUnauthenticated)
+
+	health := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	_, err = interceptor(context.Background(), nil, health, handler)
+	assert.NoError(t, err, "health checks are public")
+}
+
+// TestAuthUnaryInterceptor_Tenant verifies the tenant comes from the verified
+// API key, and that x-tenant-id cannot select another tenant.
+func TestAuthUnaryInterceptor_Tenant(t *testing.T) {
+	keys, err := auth.ParseAPIKeys([]string{"orders@acme=0123456789abcdef"})
+	require.NoError(t, err)
+
+	var seen string
+	handler := func(ctx context.Context, _ any) (any, error) {
+		seen = reqctx.Tenant(ctx)
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAPIKey, "0123456789abcdef"))
+	_, err = AuthUnaryInterceptor(keys)(ctx, nil, testInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", seen)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataAPIKey, "0123456789abcdef", MetadataTenantID, "globex"))
+	_, err = AuthUnaryInterceptor(keys)(ctx, nil, testInfo, handler)
+	assert.ErrorIs(t, err, ErrTenantForbidden)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataTenantID, "globex"))
+	_, err = AuthUnaryInterceptor(nil)(ctx, nil, testInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "globex", seen, "without keys the tenant is taken as sent")
+}
+
+// TestLoggingUnaryInterceptor_LogsInternalErrorsOnce verifies the cause of an
+// internal error is logged a single time while the caller gets a generic status.
+func TestLoggingUnaryInterceptor_LogsInternalErrorsOnce(t *testing.T) {
+	var buf bytes.Buffer
+	logging := LoggingUnaryInterceptor(logger.New("debug", &buf))
+
+	_, err := ErrorUnaryInterceptor()(context.Background(), nil, testInfo, func(ctx context.Context, req any) (any, error) {
+		return logging(ctx, req, testInfo, func(context.Context, any) (any, error) {
+			return nil, errors.New("pq: connection refused")
+		})
+	})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), "connection refused")
+	assert.Contains(t, buf.String(), `"code": "Internal"`)
+}
+
+// testStream is a server stream carrying only a context.
+type testStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *testStream) Context() context.Context { return s.ctx }
+
+func (s *testStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// TestStreamInterceptors verifies streams get a request ID, a logged outcome
+// and application errors mapped to gRPC statuses.
+func TestStreamInterceptors(t *testing.T) {
+	var buf bytes.Buffer
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/test.v1.Test/Stream"}
+	ss := &testStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataRequestID, "rid-2"))}
+
+	var seen string
+	err := RequestIDStreamInterceptor()(nil, ss, streamInfo, func(_ any, ss grpc.ServerStream) error {
+		return ErrorStreamInterceptor()(nil, ss, streamInfo, func(_ any, ss grpc.ServerStream) error {
+			return LoggingStreamInterceptor(logger.New("debug", &buf))(nil, ss, streamInfo, func(_ any, ss grpc.ServerStream) error {
+				seen = reqctx.RequestID(ss.Context())
+				return apperrors.NotFound("thing.not_found", "thing not found")
+			})
+		})
+	})
+
+	assert.Equal(t, "rid-2", seen)
+	assert.Equal(t, []string{"rid-2"}, ss.header.Get(MetadataRequestID))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Contains(t, buf.String(), "/test.v1.Test/Stream")
+	assert.Contains(t, buf.String(), "rid-2")
+}
@@ -0,0 +1,168 @@
+package grpctransport
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/auth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server holds the gRPC server and configuration for graceful shutdown.
+type Server struct {
+	// server is the underlying gRPC server instance.
+	server *grpc.Server
+
+	// health reports serving status to gRPC health checks.
+	health *health.Server
+
+	// logger is the reusable zap.SugaredLogger instance.
+	logger *zap.SugaredLogger
+
+	// port is the port number on which the server will listen.
+	port int
+}
+
+// Options configures the Server.
+type Options struct {
+	// Port is the port number where the server should listen.
+	Port int
+
+	// Logger is the logger instance to use for logging.
+	Logger *zap.SugaredLogger
+
+	// Register is a function to register application-specific services.
+	Register func(server *grpc.Server)
+
+	// Ready reports whether dependencies such as the database are available.
+	// Health checks answer NOT_SERVING while it returns false. Nil means always ready.
+	Ready func() bool
+
+	// APIKeys authenticates callers of the registered services. Nil or empty
+	// leaves them unauthenticated.
+	APIKeys *auth.APIKeys
+}
+
+// New creates a new Server with the standard interceptors, health checking
+// and server reflection.
+func New(opts Options) *Server {
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop().Sugar()
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			RequestIDUnaryInterceptor(),
+			ErrorUnaryInterceptor(),
+			LoggingUnaryInterceptor(opts.Logger),
+			RecoveryUnaryInterceptor(),
+			AuthUnaryInterceptor(opts.APIKeys),
+		),
+		grpc.ChainStreamInterceptor(
+			RequestIDStreamInterceptor(),
+			ErrorStreamInterceptor(),
+			LoggingStreamInterceptor(opts.Logger),
+			RecoveryStreamInterceptor(),
+			AuthStreamInterceptor(opts.APIKeys),
+		),
+	)
+
+	if opts.Register != nil {
+		opts.Register(server)
+	}
+
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(server, &readinessHealth{Server: healthSrv, ready: opts.Ready})
+	reflection.Register(server)
+
+	return &Server{
+		server: server,
+		health: healthSrv,
+		logger: opts.Logger,
+		port:   opts.Port,
+	}
+}
+
+// readinessHealth reports NOT_SERVING while the service's dependencies are
+// unavailable and otherwise defers to the standard health server.
+type readinessHealth struct {
+	*health.Server
+	ready func() bool
+}
+
+// Check implements healthpb.HealthServer.
+func (h *readinessHealth) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if h.ready != nil && !h.ready() {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return h.Server.Check(ctx, req)
+}
+
+// GRPC returns the internal gRPC server instance (useful for testing).
+func (s *Server) GRPC() *grpc.Server {
+	return s.server
+}
+
+// Serve accepts connections on the given listener until Stop is called.
+func (s *Server) Serve(lis net.Listener) error {
+	s.health.Resume()
+	return s.server.Serve(lis)
+}
+
+// Stop marks the server as not serving and stops it gracefully, forcing
+// termination if pending RPCs do not finish before the timeout.
+func (s *Server) Stop(timeout time.Duration) {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		s.server.Stop()
+	}
+}
+
+// Start runs the gRPC server and handles graceful shutdown on SIGINT/SIGTERM.
+func (s *Server) Start(ctx context.Context) error {
+	addr := fmt.Sprintf(":%d", s.port)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.logger.Infof("Starting gRPC server on %s", addr)
+
+	// Start server asynchronously
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			s.logger.Errorf("gRPC server error: %v", err)
+		}
+	}()
+
+	// Wait for interrupt or context cancellation
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case <-sigChan:
+		s.logger.Info("Received shutdown signal, stopping gRPC server...")
+	case <-ctx.Done():
+		s.logger.Info("Context cancelled, stopping gRPC server...")
+	}
+
+	s.Stop(5 * time.Second)
+	return nil
+}
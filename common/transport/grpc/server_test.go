@@ -0,0 +1,80 @@
+package grpctransport
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// TestServer_Health verifies that the health service reports SERVING once started.
+func TestServer_Health(t *testing.T) {
+	srv := New(Options{Logger: logger.New("debug", nil)})
+	conn := dialHealth(t, srv)
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
+
+// TestServer_HealthNotReady verifies health checks follow the readiness callback.
+func TestServer_HealthNotReady(t *testing.T) {
+	var ready atomic.Bool
+	srv := New(Options{Logger: logger.New("debug", nil), Ready: ready.Load})
+	client := healthpb.NewHealthClient(dialHealth(t, srv))
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+	ready.Store(true)
+	resp, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
+
+// dialHealth serves srv over an in-memory listener and returns a client connection.
+func dialHealth(t *testing.T, srv *Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(func() { srv.Stop(time.Second) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// TestServer_StartAndShutdown verifies the server stops when the context is cancelled.
+func TestServer_StartAndShutdown(t *testing.T) {
+	srv := New(Options{Port: 0, Logger: logger.New("debug", nil)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Start(ctx) }()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not stop")
+	}
+}
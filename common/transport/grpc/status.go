@@ -0,0 +1,66 @@
+package grpctransport
+
+import (
+	"net/http"
+
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain identifies Mannaiah in google.rpc.ErrorInfo details.
+const ErrorDomain = "mannaiah"
+
+// ToStatus converts err into a gRPC status error. Errors that already carry a
+// status are returned unchanged; application errors keep their public message
+// and expose their stable code through an ErrorInfo detail; anything else is
+// hidden behind codes.Internal. Logging the cause is left to
+// LoggingUnaryInterceptor.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	appErr := apperrors.From(err)
+	code := CodeFromHTTPStatus(appErr.Status)
+
+	st := status.New(code, appErr.Message)
+	if withDetails, derr := st.WithDetails(&errdetails.ErrorInfo{Reason: appErr.Code, Domain: ErrorDomain}); derr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// CodeFromHTTPStatus maps an HTTP status code onto the closest gRPC code.
+func CodeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusInternalServerError:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
@@ -0,0 +1,87 @@
+package httptransport
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// clientIPLocal is the Fiber local under which ClientIPMiddleware stores the client address.
+type clientIPLocal struct{}
+
+// ParseTrustedProxies parses the addresses and CIDR ranges of trusted reverse proxies.
+func ParseTrustedProxies(entries []string) ([]netip.Prefix, error) {
+	proxies := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", entry)
+		}
+		addr = addr.Unmap()
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
+// ClientIPMiddleware resolves the address of the client behind trusted reverse
+// proxies. When the peer is a trusted proxy, header (usually X-Forwarded-For)
+// is read from right to left and the first address that is not a trusted proxy
+// is taken as the client, so values prepended by the client itself are ignored.
+// Without trusted proxies the peer address is used as is.
+func ClientIPMiddleware(proxies []netip.Prefix, header string) fiber.Handler {
+	trusted := func(addr netip.Addr) bool {
+		for _, p := range proxies {
+			if p.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Locals(clientIPLocal{}, resolveClientIP(c, header, trusted))
+		return c.Next()
+	}
+}
+
+// resolveClientIP walks the forwarding chain back from the peer address.
+func resolveClientIP(c *fiber.Ctx, header string, trusted func(netip.Addr) bool) string {
+	client, ok := netip.AddrFromSlice(c.Context().RemoteIP())
+	if !ok {
+		return c.IP()
+	}
+	client = client.Unmap()
+
+	if header == "" || !trusted(client) {
+		return client.String()
+	}
+
+	hops := strings.Split(c.Get(header), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = hop.Unmap()
+		if !trusted(client) {
+			break
+		}
+	}
+	return client.String()
+}
+
+// ClientIP returns the client address resolved by ClientIPMiddleware, falling
+// back to the peer address when the middleware is not installed.
+func ClientIP(c *fiber.Ctx) string {
+	if ip, ok := c.Locals(clientIPLocal{}).(string); ok {
+		return ip
+	}
+	return c.IP()
+}
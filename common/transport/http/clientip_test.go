@@ -0,0 +1,59 @@
+package httptransport
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseTrustedProxies verifies addresses and ranges are accepted and garbage rejected.
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", " 192.168.1.7 ", "::1"})
+	require.NoError(t, err)
+	require.Len(t, proxies, 3)
+	require.Equal(t, "192.168.1.7/32", proxies[1].String())
+
+	_, err = ParseTrustedProxies([]string{"proxy.local"})
+	require.Error(t, err)
+}
+
+// TestClientIPMiddleware verifies the forwarded address is only honored from trusted proxies.
+func TestClientIPMiddleware(t *testing.T) {
+	// app.Test connects from 0.0.0.0.
+	cases := []struct {
+		name      string
+		trusted   []string
+		forwarded string
+		want      string
+	}{
+		{name: "untrusted peer", trusted: nil, forwarded: "203.0.113.9", want: "0.0.0.0"},
+		{name: "trusted peer", trusted: []string{"0.0.0.0"}, forwarded: "203.0.113.9", want: "203.0.113.9"},
+		{name: "spoofed prefix", trusted: []string{"0.0.0.0", "10.0.0.0/8"}, forwarded: "1.1.1.1, 203.0.113.9, 10.1.2.3", want: "203.0.113.9"},
+		{name: "garbage hop", trusted: []string{"0.0.0.0"}, forwarded: "not-an-ip", want: "0.0.0.0"},
+		{name: "missing header", trusted: []string{"0.0.0.0"}, forwarded: "", want: "0.0.0.0"},
+	}
+
+	for _, tc := range cases {
+		proxies, err := ParseTrustedProxies(tc.trusted)
+		require.NoError(t, err)
+
+		app := fiber.New()
+		app.Use(ClientIPMiddleware(proxies, fiber.HeaderXForwardedFor))
+		app.Get("/", func(c *fiber.Ctx) error {
+			return c.SendString(ClientIP(c))
+		})
+
+		req := httptest.NewRequest("GET", "/", nil)
+		if tc.forwarded != "" {
+			req.Header.Set(fiber.HeaderXForwardedFor, tc.forwarded)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		body := make([]byte, 64)
+		n, _ := resp.Body.Read(body)
+		require.Equal(t, tc.want, string(body[:n]), tc.name)
+	}
+}
@@ -0,0 +1,58 @@
+package httptransport
+
+import (
+	"embed"
+	"path"
+
+	"github.com/flockstore/mannaiah-backend/common/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// docsFS holds the documentation UI rendering /internal/openapi.json. Its
+// assets are served locally so the page works offline and under a strict
+// Content-Security-Policy.
+//
+//go:embed docs
+var docsFS embed.FS
+
+// docsCSP restricts the documentation UI to its own origin.
+const docsCSP = "default-src 'self'"
+
+// registerDocs exposes the OpenAPI document and its documentation UI.
+func registerDocs(app *fiber.App, doc *openapi.Document) {
+	app.Get("/internal/openapi.json", func(c *fiber.Ctx) error {
+		return c.JSON(doc)
+	})
+
+	app.Get("/internal/docs", func(c *fiber.Ctx) error {
+		return sendDocsFile(c, "index.html")
+	})
+
+	app.Get("/internal/docs/assets/:file", func(c *fiber.Ctx) error {
+		return sendDocsFile(c, c.Params("file"))
+	})
+}
+
+// sendDocsFile writes an embedded documentation file with its content type.
+func sendDocsFile(c *fiber.Ctx, name string) error {
+	data, err := docsFS.ReadFile(path.Join("docs", path.Base(name)))
+	if err != nil {
+		return fiber.ErrNotFound
+	}
+
+	c.Type(path.Ext(name))
+	c.Set(fiber.HeaderContentSecurityPolicy, docsCSP)
+	return c.Send(data)
+}
+
+// OpenAPIEnvelope wraps a success payload schema in the SuccessResponse envelope.
+func OpenAPIEnvelope(data *openapi.Schema) *openapi.Schema {
+	return &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"data":      data,
+			"requestId": {Type: "string"},
+		},
+		Required: []string{"data", "requestId"},
+	}
+}
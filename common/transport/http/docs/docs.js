@@ -0,0 +1,161 @@
+// Renders the OpenAPI document referenced by #docs[data-spec] without any
+// third-party code, so the page works offline and under a strict CSP.
+(function () {
+  "use strict";
+
+  var METHODS = ["get", "post", "put", "patch", "delete"];
+  var MAX_DEPTH = 6;
+
+  function el(tag, attrs, children) {
+    var node = document.createElement(tag);
+    Object.keys(attrs || {}).forEach(function (key) {
+      node.setAttribute(key, attrs[key]);
+    });
+    (children || []).forEach(function (child) {
+      node.appendChild(typeof child === "string" ? document.createTextNode(child) : child);
+    });
+    return node;
+  }
+
+  function refName(ref) {
+    return ref.substring(ref.lastIndexOf("/") + 1);
+  }
+
+  // constraints lists the validation keywords of a schema in a short form.
+  function constraints(schema) {
+    var out = [];
+    if (schema.format) out.push(schema.format);
+    if (schema.enum) out.push("one of: " + schema.enum.join(", "));
+    if (schema.pattern) out.push("pattern " + schema.pattern);
+    [["minLength", "min length"], ["maxLength", "max length"], ["minItems", "min items"],
+     ["maxItems", "max items"], ["minimum", "≥"], ["maximum", "≤"],
+     ["exclusiveMinimum", ">"], ["exclusiveMaximum", "<"]].forEach(function (pair) {
+      if (schema[pair[0]] !== undefined) out.push(pair[1] + " " + schema[pair[0]]);
+    });
+    return out.join("; ");
+  }
+
+  function typeLabel(schema) {
+    if (schema.$ref) return refName(schema.$ref);
+    if (schema.type === "array" && schema.items) return typeLabel(schema.items) + "[]";
+    if (schema.type === "object" && schema.additionalProperties) {
+      return "map of " + typeLabel(schema.additionalProperties);
+    }
+    return schema.type || "any";
+  }
+
+  // renderSchema describes schema, expanding references up to MAX_DEPTH levels.
+  function renderSchema(spec, schema, depth) {
+    if (!schema) return el("span", { class: "muted" }, ["none"]);
+    if (schema.$ref) {
+      var resolved = (spec.components.schemas || {})[refName(schema.$ref)];
+      if (!resolved || depth >= MAX_DEPTH) return el("code", {}, [refName(schema.$ref)]);
+      return renderSchema(spec, resolved, depth + 1);
+    }
+    if (schema.type === "array" && schema.items) {
+      var wrapper = el("div", {}, [el("code", {}, [typeLabel(schema)]), " " + constraints(schema)]);
+      if (schema.items.$ref || schema.items.properties) {
+        wrapper.appendChild(renderSchema(spec, schema.items, depth + 1));
+      }
+      return wrapper;
+    }
+    if (!schema.properties) {
+      return el("span", {}, [el("code", {}, [typeLabel(schema)]), " " + constraints(schema)]);
+    }
+
+    var required = schema.required || [];
+    var list = el("ul", { class: "schema" });
+    Object.keys(schema.properties).sort().forEach(function (name) {
+      var prop = schema.properties[name];
+      var item = el("li", {}, [
+        el("code", { class: required.indexOf(name) >= 0 ? "required" : "" }, [name]),
+        ": ",
+        el("span", { class: "muted" }, [typeLabel(prop) + (constraints(prop) ? " (" + constraints(prop) + ")" : "")])
+      ]);
+      var nested = prop.$ref || prop.properties || (prop.items && (prop.items.$ref || prop.items.properties));
+      if (nested && depth < MAX_DEPTH) item.appendChild(renderSchema(spec, prop, depth + 1));
+      list.appendChild(item);
+    });
+    return list;
+  }
+
+  function renderParameters(params) {
+    var rows = params.map(function (p) {
+      return el("tr", {}, [
+        el("td", {}, [el("code", { class: p.required ? "required" : "" }, [p.name])]),
+        el("td", {}, [p.in]),
+        el("td", {}, [typeLabel(p.schema || {})]),
+        el("td", {}, [constraints(p.schema || {}) || p.description || ""])
+      ]);
+    });
+    return el("table", {}, [
+      el("thead", {}, [el("tr", {}, [el("th", {}, ["Name"]), el("th", {}, ["In"]), el("th", {}, ["Type"]), el("th", {}, ["Rules"])])]),
+      el("tbody", {}, rows)
+    ]);
+  }
+
+  function renderContent(spec, content) {
+    var media = content && (content["application/json"] || content[Object.keys(content)[0]]);
+    return media ? renderSchema(spec, media.schema, 0) : el("span", { class: "muted" }, ["no body"]);
+  }
+
+  function renderOperation(spec, method, path, op) {
+    var body = el("div");
+    if (op.summary) body.appendChild(el("p", {}, [op.summary]));
+    body.appendChild(el("p", { class: "muted" }, ["operationId: ", el("code", {}, [op.operationId])]));
+
+    if (op.parameters && op.parameters.length) {
+      body.appendChild(el("h4", {}, ["Parameters"]));
+      body.appendChild(renderParameters(op.parameters));
+    }
+    if (op.requestBody) {
+      body.appendChild(el("h4", {}, ["Request body"]));
+      body.appendChild(renderContent(spec, op.requestBody.content));
+    }
+
+    body.appendChild(el("h4", {}, ["Responses"]));
+    Object.keys(op.responses || {}).sort().forEach(function (code) {
+      var res = op.responses[code];
+      body.appendChild(el("p", {}, [el("strong", {}, [code]), " " + (res.description || "")]));
+      if (res.content) body.appendChild(renderContent(spec, res.content));
+    });
+
+    return el("details", { class: "operation" }, [
+      el("summary", {}, [el("span", { class: "method " + method }, [method]), el("span", { class: "path" }, [path])]),
+      body
+    ]);
+  }
+
+  function render(root, spec) {
+    document.title = spec.info.title + " " + spec.info.version;
+    document.getElementById("title").textContent = document.title;
+
+    var groups = {};
+    Object.keys(spec.paths).sort().forEach(function (path) {
+      METHODS.forEach(function (method) {
+        var op = spec.paths[path][method];
+        if (!op) return;
+        var tag = (op.tags && op.tags[0]) || "default";
+        (groups[tag] = groups[tag] || []).push(renderOperation(spec, method, path, op));
+      });
+    });
+
+    root.textContent = "";
+    Object.keys(groups).sort().forEach(function (tag) {
+      root.appendChild(el("h2", {}, [tag]));
+      groups[tag].forEach(function (node) { root.appendChild(node); });
+    });
+  }
+
+  var root = document.getElementById("docs");
+  fetch(root.getAttribute("data-spec"))
+    .then(function (res) {
+      if (!res.ok) throw new Error("HTTP " + res.status);
+      return res.json();
+    })
+    .then(function (spec) { render(root, spec); })
+    .catch(function (err) {
+      root.textContent = "";
+      root.appendChild(el("p", { class: "error" }, ["Failed to load the API description: " + err.message]));
+    });
+})();
@@ -0,0 +1,65 @@
+package httptransport
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrUnauthenticated is rendered when a request carries no valid API key or administrative token.
+	ErrUnauthenticated = apperrors.Unauthenticated("request.unauthenticated", "authentication required")
+
+	// ErrInvalidLogLevel is rendered when a log level change names an unknown level.
+	ErrInvalidLogLevel = apperrors.InvalidArgument("loglevel.invalid", "unknown log level")
+)
+
+// LogLevelBody is the payload read and returned by /internal/loglevel.
+type LogLevelBody struct {
+	// Level is one of debug, info, warn or error.
+	Level string `json:"level"`
+}
+
+// AdminAuthMiddleware rejects requests whose bearer token does not match token.
+func AdminAuthMiddleware(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		got, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return ErrUnauthenticated
+		}
+		return c.Next()
+	}
+}
+
+// registerLogLevel exposes the current log level and lets administrators
+// change it at runtime. The change lasts until the next restart or
+// configuration reload that modifies the level.
+func registerLogLevel(app *fiber.App, level zap.AtomicLevel, token string) {
+	route := app.Group("/internal/loglevel", AdminAuthMiddleware(token))
+
+	route.Get("/", func(c *fiber.Ctx) error {
+		return WriteSuccess(c, LogLevelBody{Level: level.Level().String()})
+	})
+
+	route.Put("/", func(c *fiber.Ctx) error {
+		var body LogLevelBody
+		if err := c.BodyParser(&body); err != nil {
+			return ErrInvalidBody.Wrap(err)
+		}
+
+		lvl, err := logger.ParseLevel(body.Level)
+		if err != nil {
+			return ErrInvalidLogLevel.Wrap(err)
+		}
+
+		previous := level.Level()
+		level.SetLevel(lvl)
+		logger.FromContext(c.UserContext()).Infow("log level changed", "from", previous.String(), "to", lvl.String())
+
+		return WriteSuccess(c, LogLevelBody{Level: lvl.String()})
+	})
+}
@@ -0,0 +1,76 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// adminToken is the bearer token used by the log level tests.
+const adminToken = "0123456789abcdef"
+
+// logLevelRequest calls /internal/loglevel and decodes the response envelope.
+func logLevelRequest(t *testing.T, srv *Server, method, token, body string) (int, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, "/internal/loglevel", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := srv.App().Test(req)
+	require.NoError(t, err)
+
+	var out map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	return resp.StatusCode, out
+}
+
+// TestLogLevel verifies the level can be read and changed by authenticated callers.
+func TestLogLevel(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	srv := New(Options{LogLevel: level, AdminToken: adminToken})
+
+	status, body := logLevelRequest(t, srv, "GET", adminToken, "")
+	require.Equal(t, 200, status)
+	assert.Equal(t, map[string]any{"level": "info"}, body["data"])
+
+	status, body = logLevelRequest(t, srv, "PUT", adminToken, `{"level":"debug"}`)
+	require.Equal(t, 200, status)
+	assert.Equal(t, map[string]any{"level": "debug"}, body["data"])
+	assert.Equal(t, zapcore.DebugLevel, level.Level())
+
+	status, body = logLevelRequest(t, srv, "PUT", adminToken, `{"level":"verbose"}`)
+	require.Equal(t, 400, status)
+	assert.Equal(t, ErrInvalidLogLevel.Code, body["error"].(map[string]any)["reason"])
+	assert.Equal(t, zapcore.DebugLevel, level.Level())
+}
+
+// TestLogLevel_RequiresToken verifies callers without the admin token are rejected.
+func TestLogLevel_RequiresToken(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	srv := New(Options{LogLevel: level, AdminToken: adminToken})
+
+	for _, token := range []string{"", "wrong-token-0000"} {
+		status, body := logLevelRequest(t, srv, "PUT", token, `{"level":"debug"}`)
+		assert.Equal(t, 401, status)
+		assert.Equal(t, ErrUnauthenticated.Code, body["error"].(map[string]any)["reason"])
+	}
+	assert.Equal(t, zapcore.InfoLevel, level.Level())
+}
+
+// TestLogLevel_DisabledWithoutToken verifies the endpoint is not exposed without an admin token.
+func TestLogLevel_DisabledWithoutToken(t *testing.T) {
+	srv := New(Options{LogLevel: zap.NewAtomicLevel()})
+
+	resp, err := srv.App().Test(httptest.NewRequest("GET", "/internal/loglevel", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+}
@@ -0,0 +1,13 @@
+package httptransport
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// registerMetrics exposes the collectors of gatherer in the Prometheus text format.
+func registerMetrics(app *fiber.App, gatherer prometheus.Gatherer) {
+	app.Get("/internal/metrics", adaptor.HTTPHandler(promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})))
+}
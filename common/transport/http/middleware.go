@@ -1,10 +1,19 @@
 package httptransport
 
 import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/auth"
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"go.uber.org/zap"
 )
 
 // RequestIDMiddleware attaches a unique X-Request-ID to each request.
@@ -12,11 +21,126 @@ func RequestIDMiddleware() fiber.Handler {
 	return requestid.New()
 }
 
+// RequestContextMiddleware stores the request ID and a request-scoped logger
+// in the user context so downstream code can use logger.FromContext.
+// It must be registered after RequestIDMiddleware.
+func RequestContextMiddleware(l *zap.SugaredLogger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := reqctx.WithRequestID(c.UserContext(), c.GetRespHeader(fiber.HeaderXRequestID))
+		ctx = logger.WithContext(ctx, l)
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
+
+// HeaderTenantID carries the tenant a request asks to act on behalf of.
+const HeaderTenantID = "X-Tenant-ID"
+
+// ErrTenantForbidden is rendered when an API key asks to act for a tenant it is not bound to.
+var ErrTenantForbidden = apperrors.PermissionDenied("request.tenant_forbidden", "tenant not allowed for this API key")
+
+// AuthMiddleware verifies the X-API-Key header against keys and stores the
+// caller's principal and tenant in the user context, so downstream code can
+// scope data with reqctx.Tenant. Requests without a valid key are rejected
+// with 401, and those asking in X-Tenant-ID for a tenant their key is not
+// bound to with 403 (see auth.Identity.TenantFor). Internal endpoints (probes,
+// metrics and docs) are left to their own protection. When keys is empty
+// nothing can be verified, so X-Tenant-ID is taken as sent.
+// It must be registered after RequestContextMiddleware.
+func AuthMiddleware(keys *auth.APIKeys) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if strings.HasPrefix(c.Path(), internalPrefix) {
+			return c.Next()
+		}
+		if !keys.Enabled() {
+			if tenant := strings.TrimSpace(c.Get(HeaderTenantID)); tenant != "" {
+				c.SetUserContext(reqctx.WithTenant(c.UserContext(), tenant))
+			}
+			return c.Next()
+		}
+
+		id, ok := keys.Verify(c.Get(HeaderAPIKey))
+		if !ok {
+			return ErrUnauthenticated
+		}
+		tenant, ok := id.TenantFor(c.Get(HeaderTenantID))
+		if !ok {
+			return ErrTenantForbidden
+		}
+
+		c.Locals(identityLocal{}, id)
+		ctx := reqctx.WithPrincipal(c.UserContext(), id.Principal)
+		if tenant != "" {
+			ctx = reqctx.WithTenant(ctx, tenant)
+		}
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
+
+// identityLocal is the Fiber local under which AuthMiddleware stores the verified identity.
+type identityLocal struct{}
+
+// CallerIdentity returns the identity verified by AuthMiddleware, if any.
+func CallerIdentity(c *fiber.Ctx) (auth.Identity, bool) {
+	id, ok := c.Locals(identityLocal{}).(auth.Identity)
+	return id, ok
+}
+
+// AccessLogMiddleware logs one line per request with method, route, status,
+// latency, response size and client IP (see ClientIP).
+// Successful (2xx) responses are sampled: only one out of every successSampling
+// is logged. Values lower than 2 log every request. Other statuses are always logged.
+func AccessLogMiddleware(successSampling int) fiber.Handler {
+	var counter uint64
+
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		if chainErr := c.Next(); chainErr != nil {
+			// Render the error now so the final status code is known when logging.
+			if err := c.App().ErrorHandler(c, chainErr); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		if status >= 200 && status < 300 && successSampling > 1 {
+			if atomic.AddUint64(&counter, 1)%uint64(successSampling) != 1 {
+				return nil
+			}
+		}
+
+		log := logger.FromContext(c.UserContext()).With(
+			"method", c.Method(),
+			"route", c.Route().Path,
+			"path", c.Path(),
+			"status", status,
+			"latency", time.Since(start),
+			"bytes", len(c.Response().Body()),
+			"ip", ClientIP(c),
+		)
+
+		switch {
+		case status >= 500:
+			log.Error("request completed")
+		case status >= 400:
+			log.Warn("request completed")
+		default:
+			log.Info("request completed")
+		}
+
+		return nil
+	}
+}
+
 // CORSMiddleware sets up Cross-Origin Resource Sharing with default options.
 func CORSMiddleware() fiber.Handler {
 	return cors.New(cors.Config{
 		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, " + HeaderAPIKey + ", " + HeaderTenantID,
+		ExposeHeaders: "X-Request-ID, Retry-After, " +
+			HeaderRateLimitLimit + ", " + HeaderRateLimitRemaining + ", " + HeaderRateLimitReset,
 	})
 }
 
@@ -1,11 +1,18 @@
 package httptransport
 
 import (
+	"bytes"
+	"io"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/flockstore/mannaiah-backend/common/auth"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 // TestRequestIDMiddleware verifies that RequestIDMiddleware sets the X-Request-ID header.
@@ -62,3 +69,157 @@ func TestRecoveryMiddleware(t *testing.T) {
 	require.Equal(t, 500, resp.StatusCode)
 
 }
+
+// TestRequestContextMiddleware verifies that the request ID is exposed through the user context.
+func TestRequestContextMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(RequestIDMiddleware())
+	app.Use(RequestContextMiddleware(zap.NewNop().Sugar()))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(reqctx.RequestID(c.UserContext()))
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "fixed-id")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+
+	body, _ := io.ReadAll(resp.Body)
+	require.Equal(t, "fixed-id", string(body))
+}
+
+// TestAuthMiddleware_Tenant verifies the tenant comes from the verified API key:
+// keys bound to a tenant cannot act for another one through X-Tenant-ID, while
+// AnyTenant keys choose it per request.
+func TestAuthMiddleware_Tenant(t *testing.T) {
+	keys, err := auth.ParseAPIKeys([]string{"storefront@acme=0123456789abcdef", "backoffice@*=fedcba9876543210"})
+	require.NoError(t, err)
+
+	app := fiber.New(fiber.Config{ErrorHandler: defaultErrorHandler})
+	app.Use(AuthMiddleware(keys))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(reqctx.Tenant(c.UserContext()))
+	})
+
+	cases := []struct {
+		key, tenant, want string
+		status            int
+	}{
+		{"0123456789abcdef", "", "acme", 200},
+		{"0123456789abcdef", "acme", "acme", 200},
+		{"0123456789abcdef", "globex", "", 403},
+		{"fedcba9876543210", "globex", "globex", 200},
+		{"fedcba9876543210", "", "", 200},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(HeaderAPIKey, tc.key)
+		req.Header.Set(HeaderTenantID, tc.tenant)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, tc.status, resp.StatusCode, tc)
+
+		if tc.status == 200 {
+			body, _ := io.ReadAll(resp.Body)
+			require.Equal(t, tc.want, string(body), tc)
+		}
+	}
+}
+
+// TestAuthMiddleware_TenantWithoutKeys verifies X-Tenant-ID is taken as sent
+// when authentication is disabled.
+func TestAuthMiddleware_TenantWithoutKeys(t *testing.T) {
+	app := fiber.New()
+	app.Use(AuthMiddleware(nil))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(reqctx.Tenant(c.UserContext()))
+	})
+
+	for header, want := range map[string]string{"acme": "acme", " ": "", "": ""} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(HeaderTenantID, header)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+
+		body, _ := io.ReadAll(resp.Body)
+		require.Equal(t, want, string(body))
+	}
+}
+
+// TestAuthMiddleware verifies API keys are required on application routes and
+// that the verified principal is exposed through reqctx.Principal.
+func TestAuthMiddleware(t *testing.T) {
+	keys, err := auth.ParseAPIKeys([]string{"storefront=0123456789abcdef"})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	srv := New(Options{
+		Logger:  logger.New("debug", &buf),
+		APIKeys: keys,
+		Routes: func(router fiber.Router) {
+			router.Get("/whoami", func(c *fiber.Ctx) error {
+				return c.SendString(reqctx.Principal(c.UserContext()))
+			})
+		},
+	})
+
+	for key, want := range map[string]int{"": 401, "wrong": 401, "0123456789abcdef": 200} {
+		req := httptest.NewRequest("GET", "/whoami", nil)
+		req.Header.Set(HeaderAPIKey, key)
+		resp, err := srv.App().Test(req)
+		require.NoError(t, err)
+		require.Equal(t, want, resp.StatusCode, key)
+
+		if want == 200 {
+			body, _ := io.ReadAll(resp.Body)
+			require.Equal(t, "storefront", string(body))
+		}
+	}
+	require.Contains(t, buf.String(), `"principal": "storefront"`)
+
+	resp, err := srv.App().Test(httptest.NewRequest("GET", "/internal/healthz", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+}
+
+// TestAccessLogMiddleware verifies that requests are logged with route, status and request ID.
+func TestAccessLogMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	app := fiber.New()
+	app.Use(RequestIDMiddleware())
+	app.Use(RequestContextMiddleware(logger.New("debug", &buf)))
+	app.Use(AccessLogMiddleware(1))
+	app.Get("/items/:id", func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+
+	req := httptest.NewRequest("GET", "/items/7", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "log-id")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 404, resp.StatusCode)
+
+	output := buf.String()
+	require.Contains(t, output, "request completed")
+	require.Contains(t, output, "/items/:id")
+	require.Contains(t, output, "404")
+	require.Contains(t, output, "log-id")
+}
+
+// TestAccessLogMiddleware_SamplesSuccess verifies that only a fraction of 2xx responses is logged.
+func TestAccessLogMiddleware_SamplesSuccess(t *testing.T) {
+	var buf bytes.Buffer
+	app := fiber.New()
+	app.Use(RequestContextMiddleware(logger.New("debug", &buf)))
+	app.Use(AccessLogMiddleware(3))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	for i := 0; i < 6; i++ {
+		_, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 2, strings.Count(buf.String(), "request completed"))
+}
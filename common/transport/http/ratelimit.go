@@ -0,0 +1,177 @@
+package httptransport
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/config"
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/ratelimit"
+	"github.com/flockstore/mannaiah-backend/common/reqctx"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Standard rate-limit response headers (IETF draft "RateLimit header fields for HTTP").
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// HeaderAPIKey is the header carrying the caller's API key.
+const HeaderAPIKey = "X-API-Key"
+
+// ErrRateLimited is rendered when a caller exceeds its quota.
+var ErrRateLimited = apperrors.New("request.rate_limited", fiber.StatusTooManyRequests, "rate limit exceeded")
+
+// RateLimitKeyFunc extracts the identity a request is counted against.
+type RateLimitKeyFunc func(c *fiber.Ctx) string
+
+// KeyByIP counts requests per client IP, resolved through trusted proxies by ClientIP.
+func KeyByIP() RateLimitKeyFunc {
+	return func(c *fiber.Ctx) string {
+		return "ip:" + ClientIP(c)
+	}
+}
+
+// KeyByAPIKey counts requests per API key verified by AuthMiddleware, falling
+// back to the client IP for unauthenticated requests. Unverified X-API-Key
+// values are never used, so callers cannot escape limits by rotating them.
+func KeyByAPIKey() RateLimitKeyFunc {
+	return func(c *fiber.Ctx) string {
+		if id, ok := CallerIdentity(c); ok {
+			return "key:" + id.KeyID
+		}
+		return "ip:" + ClientIP(c)
+	}
+}
+
+// KeyByPrincipal counts requests per authenticated principal, falling back to the client IP.
+func KeyByPrincipal() RateLimitKeyFunc {
+	return func(c *fiber.Ctx) string {
+		if p := reqctx.Principal(c.UserContext()); p != "" {
+			return "principal:" + p
+		}
+		return "ip:" + ClientIP(c)
+	}
+}
+
+// KeyByTenant counts requests per tenant of authenticated callers, falling back
+// to the client IP. Tenants of unauthenticated requests are not trusted.
+func KeyByTenant() RateLimitKeyFunc {
+	return func(c *fiber.Ctx) string {
+		ctx := c.UserContext()
+		if t := reqctx.Tenant(ctx); t != "" && reqctx.Principal(ctx) != "" {
+			return "tenant:" + t
+		}
+		return "ip:" + ClientIP(c)
+	}
+}
+
+// RateLimitOptions configures RateLimitMiddleware for a route group.
+type RateLimitOptions struct {
+	// Name scopes the counters so different route groups do not share quotas.
+	Name string
+
+	// Rule is the limit enforced for each key.
+	Rule ratelimit.Rule
+
+	// Store holds the counters (in memory or shared across replicas).
+	Store ratelimit.Store
+
+	// Key selects the identity requests are counted against. Defaults to KeyByIP.
+	Key RateLimitKeyFunc
+}
+
+// NewRateLimitOptions builds RateLimitOptions for a route group from configuration.
+func NewRateLimitOptions(name string, cfg config.RateLimitConfig, store ratelimit.Store) (RateLimitOptions, error) {
+	opts := RateLimitOptions{
+		Name: name,
+		Rule: ratelimit.Rule{
+			Algorithm: ratelimit.Algorithm(cfg.Algorithm),
+			Limit:     cfg.Requests,
+			Window:    time.Duration(cfg.Window) * time.Second,
+		},
+		Store: store,
+	}
+
+	switch cfg.KeyBy {
+	case "ip":
+		opts.Key = KeyByIP()
+	case "api_key":
+		opts.Key = KeyByAPIKey()
+	case "principal":
+		opts.Key = KeyByPrincipal()
+	case "tenant":
+		opts.Key = KeyByTenant()
+	default:
+		return RateLimitOptions{}, fmt.Errorf("unsupported rate limit key %q", cfg.KeyBy)
+	}
+
+	return opts, nil
+}
+
+// RateLimitMiddleware rejects requests exceeding the configured rule with 429.
+// Store failures are logged and the request is allowed through (fail open).
+func RateLimitMiddleware(opts RateLimitOptions) fiber.Handler {
+	return NewRateLimiter(opts).Middleware()
+}
+
+// RateLimiter enforces RateLimitOptions that can be replaced while serving,
+// so limits follow configuration reloads without a restart.
+type RateLimiter struct {
+	opts atomic.Pointer[RateLimitOptions]
+}
+
+// NewRateLimiter creates a RateLimiter enforcing opts.
+func NewRateLimiter(opts RateLimitOptions) *RateLimiter {
+	l := &RateLimiter{}
+	l.Update(opts)
+	return l
+}
+
+// Update replaces the enforced options. Requests already being checked keep
+// the options they started with.
+func (l *RateLimiter) Update(opts RateLimitOptions) {
+	if opts.Key == nil {
+		opts.Key = KeyByIP()
+	}
+	l.opts.Store(&opts)
+}
+
+// Middleware rejects requests exceeding the current rule with 429.
+// Store failures are logged and the request is allowed through (fail open).
+func (l *RateLimiter) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		opts := l.opts.Load()
+		key := opts.Name + "|" + opts.Key(c)
+
+		res, err := opts.Store.Allow(c.UserContext(), key, opts.Rule, time.Now())
+		if err != nil {
+			logger.FromContext(c.UserContext()).Warnw("rate limit check failed", "error", err)
+			return c.Next()
+		}
+
+		c.Set(HeaderRateLimitPolicy, strconv.Itoa(opts.Rule.Limit)+";w="+strconv.Itoa(int(opts.Rule.Window.Seconds())))
+		c.Set(HeaderRateLimitLimit, strconv.Itoa(res.Limit))
+		c.Set(HeaderRateLimitRemaining, strconv.Itoa(res.Remaining))
+		c.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(res.Reset)))
+
+		if !res.Allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(ceilSeconds(res.RetryAfter)))
+			return WriteAppError(c, ErrRateLimited)
+		}
+
+		return c.Next()
+	}
+}
+
+// ceilSeconds rounds a duration up to whole seconds, as required by the headers.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,144 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/flockstore/mannaiah-backend/common/auth"
+	"github.com/flockstore/mannaiah-backend/common/config"
+	"github.com/flockstore/mannaiah-backend/common/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRateLimitMiddleware verifies headers on allowed requests and the 429 envelope once exhausted.
+func TestRateLimitMiddleware(t *testing.T) {
+	keys, err := auth.ParseAPIKeys([]string{"storefront=abc", "orders=other"})
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Use(RequestIDMiddleware())
+	app.Use(AuthMiddleware(keys))
+	app.Use(RateLimitMiddleware(RateLimitOptions{
+		Name:  "test",
+		Rule:  ratelimit.Rule{Algorithm: ratelimit.TokenBucket, Limit: 2, Window: time.Minute},
+		Store: ratelimit.NewMemoryStore(),
+		Key:   KeyByAPIKey(),
+	}))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-API-Key", "abc")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode)
+		require.Equal(t, "2", resp.Header.Get(HeaderRateLimitLimit))
+		require.Equal(t, "2;w=60", resp.Header.Get(HeaderRateLimitPolicy))
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-API-Key", "abc")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 429, resp.StatusCode)
+	require.Equal(t, "0", resp.Header.Get(HeaderRateLimitRemaining))
+	require.Equal(t, "30", resp.Header.Get(fiber.HeaderRetryAfter))
+
+	var body ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, 429, body.Error.Code)
+	require.Equal(t, ErrRateLimited.Code, body.Error.Reason)
+	require.NotEmpty(t, body.RequestID)
+
+	// A different key has its own quota.
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-API-Key", "other")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+}
+
+// TestRateLimitKeys_IgnoreUnverifiedHeaders ensures callers cannot escape limits
+// by sending a fresh X-API-Key or X-Tenant-ID on every request.
+func TestRateLimitKeys_IgnoreUnverifiedHeaders(t *testing.T) {
+	for name, key := range map[string]RateLimitKeyFunc{"api_key": KeyByAPIKey(), "tenant": KeyByTenant()} {
+		app := fiber.New()
+		app.Use(AuthMiddleware(nil))
+		app.Use(RateLimitMiddleware(RateLimitOptions{
+			Name:  "test",
+			Rule:  ratelimit.Rule{Algorithm: ratelimit.TokenBucket, Limit: 1, Window: time.Minute},
+			Store: ratelimit.NewMemoryStore(),
+			Key:   key,
+		}))
+		app.Get("/", func(c *fiber.Ctx) error {
+			return c.SendString("ok")
+		})
+
+		statuses := []int{}
+		for _, value := range []string{"random-1", "random-2"} {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set(HeaderAPIKey, value)
+			req.Header.Set(HeaderTenantID, value)
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			statuses = append(statuses, resp.StatusCode)
+		}
+		require.Equal(t, []int{200, 429}, statuses, name)
+	}
+}
+
+// TestNewRateLimitOptions verifies configuration is translated into a rule and key function.
+func TestNewRateLimitOptions(t *testing.T) {
+	opts, err := NewRateLimitOptions("contacts", config.RateLimitConfig{
+		Requests:  10,
+		Window:    60,
+		Algorithm: "sliding_window",
+		KeyBy:     "tenant",
+	}, ratelimit.NewMemoryStore())
+	require.NoError(t, err)
+	require.Equal(t, ratelimit.SlidingWindow, opts.Rule.Algorithm)
+	require.Equal(t, 10, opts.Rule.Limit)
+	require.Equal(t, time.Minute, opts.Rule.Window)
+	require.NotNil(t, opts.Key)
+
+	_, err = NewRateLimitOptions("contacts", config.RateLimitConfig{KeyBy: "cookie"}, nil)
+	require.Error(t, err)
+}
+
+// TestRateLimiter_Update verifies new limits apply to subsequent requests.
+func TestRateLimiter_Update(t *testing.T) {
+	opts := RateLimitOptions{
+		Name:  "test",
+		Rule:  ratelimit.Rule{Algorithm: ratelimit.SlidingWindow, Limit: 1, Window: time.Minute},
+		Store: ratelimit.NewMemoryStore(),
+	}
+	limiter := NewRateLimiter(opts)
+
+	app := fiber.New()
+	app.Use(limiter.Middleware())
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	status := func() int {
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	require.Equal(t, 200, status())
+	require.Equal(t, 429, status())
+
+	opts.Rule.Limit = 5
+	limiter.Update(opts)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+	require.Equal(t, "5;w=60", resp.Header.Get(HeaderRateLimitPolicy))
+}
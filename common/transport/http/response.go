@@ -1,6 +1,7 @@
 package httptransport
 
 import (
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -27,6 +28,9 @@ type ErrorBody struct {
 	// Code is the HTTP status code of the error.
 	Code int `json:"code"`
 
+	// Reason is the stable, machine-readable error code (e.g. "contact.not_found").
+	Reason string `json:"reason,omitempty"`
+
 	// Message is a human-readable explanation of the error.
 	Message string `json:"message"`
 
@@ -67,3 +71,19 @@ func WriteError(c *fiber.Ctx, code int, message string, details any) error {
 	}
 	return c.Status(code).JSON(errResp)
 }
+
+// WriteAppError sends a standardized error response for a typed application error.
+// Only the public fields are rendered; the internal cause is never exposed.
+func WriteAppError(c *fiber.Ctx, err *apperrors.Error) error {
+	requestID := c.GetRespHeader(fiber.HeaderXRequestID)
+	errResp := ErrorResponse{
+		Error: ErrorBody{
+			Code:    err.Status,
+			Reason:  err.Code,
+			Message: err.Message,
+			Details: err.Details,
+		},
+		RequestID: requestID,
+	}
+	return c.Status(err.Status).JSON(errResp)
+}
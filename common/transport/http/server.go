@@ -4,15 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/flockstore/mannaiah-backend/common/auth"
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/openapi"
+	"github.com/flockstore/mannaiah-backend/common/pii"
 	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
+// internalPrefix is the path prefix of operational endpoints such as probes and metrics.
+const internalPrefix = "/internal/"
+
 // Server holds the HTTP server and configuration for graceful shutdown.
 type Server struct {
 	// app is the Fiber application instance.
@@ -40,17 +50,54 @@ type Options struct {
 
 	// Routes is a function to register application-specific routes.
 	Routes func(router fiber.Router)
+
+	// OpenAPI is the API description served at /internal/openapi.json, if any.
+	OpenAPI *openapi.Document
+
+	// Ready reports whether dependencies such as the database are available.
+	// /internal/readyz answers 503 while it returns false. Nil means always ready.
+	Ready func() bool
+
+	// Metrics is the Prometheus registry served at /internal/metrics, if any.
+	Metrics prometheus.Gatherer
+
+	// LogLevel is the level served and changed at /internal/loglevel. The
+	// endpoint is registered only when AdminToken is also set.
+	LogLevel zap.AtomicLevel
+
+	// AdminToken is the bearer token required by administrative endpoints.
+	AdminToken string
+
+	// APIKeys authenticates callers of the application routes. Nil or empty
+	// leaves them unauthenticated.
+	APIKeys *auth.APIKeys
+
+	// TrustedProxies lists the reverse proxies allowed to report the client
+	// address in ProxyHeader. Without them, the peer address is the client.
+	TrustedProxies []netip.Prefix
+
+	// ProxyHeader is the header trusted proxies forward the client address in,
+	// usually X-Forwarded-For.
+	ProxyHeader string
+
+	// AccessLogSampling logs one out of every N successful (2xx) requests.
+	// Values lower than 2 log every request.
+	AccessLogSampling int
 }
 
 // New creates a new Server with the provided options.
 func New(opts Options) *Server {
 
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop().Sugar()
+	}
+
 	app := fiber.New(fiber.Config{
 		ErrorHandler:          defaultErrorHandler,
 		DisableStartupMessage: true,
 	})
 
-	registerMiddlewares(app)
+	registerMiddlewares(app, opts)
 
 	if opts.Routes != nil {
 		opts.Routes(app)
@@ -60,6 +107,25 @@ func New(opts Options) *Server {
 		return c.SendStatus(fiber.StatusOK)
 	})
 
+	app.Get("/internal/readyz", func(c *fiber.Ctx) error {
+		if opts.Ready != nil && !opts.Ready() {
+			return c.SendStatus(fiber.StatusServiceUnavailable)
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	if opts.OpenAPI != nil {
+		registerDocs(app, opts.OpenAPI)
+	}
+
+	if opts.Metrics != nil {
+		registerMetrics(app, opts.Metrics)
+	}
+
+	if opts.AdminToken != "" && opts.LogLevel != (zap.AtomicLevel{}) {
+		registerLogLevel(app, opts.LogLevel, opts.AdminToken)
+	}
+
 	return &Server{
 		app:    app,
 		logger: opts.Logger,
@@ -96,19 +162,44 @@ func (s *Server) Start(ctx context.Context) error {
 	return s.app.ShutdownWithContext(shutdownCtx)
 }
 
-// defaultErrorHandler uses the centralized WriteError to send standardized error responses.
+// defaultErrorHandler renders errors with the standardized envelope.
+// Typed application errors are rendered with their public code and message,
+// Fiber errors below 500 keep their message, and anything else becomes a
+// generic internal error. Server-side failures are logged with their cause.
 func defaultErrorHandler(c *fiber.Ctx, err error) error {
-	code := fiber.StatusInternalServerError
-	var e *fiber.Error
-	if errors.As(err, &e) {
-		code = e.Code
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		return WriteAppError(c, ErrValidationFailed.WithDetails(ve.Fields))
+	}
+
+	var fe *fiber.Error
+	if errors.As(err, &fe) && fe.Code < fiber.StatusInternalServerError {
+		return WriteError(c, fe.Code, fe.Message, nil)
 	}
-	return WriteError(c, code, err.Error(), nil)
+
+	appErr := apperrors.From(err)
+	if appErr.Status >= fiber.StatusInternalServerError {
+		logger.FromContext(c.UserContext()).Errorw("request failed",
+			"code", appErr.Code,
+			pii.Error(err),
+		)
+	} else if appErr.Cause() != nil {
+		logger.FromContext(c.UserContext()).Debugw("request rejected",
+			"code", appErr.Code,
+			pii.Error(err),
+		)
+	}
+
+	return WriteAppError(c, appErr)
 }
 
 // registerMiddlewares sets up standard middlewares on the Fiber app.
-func registerMiddlewares(app *fiber.App) {
+func registerMiddlewares(app *fiber.App, opts Options) {
+	app.Use(ClientIPMiddleware(opts.TrustedProxies, opts.ProxyHeader))
 	app.Use(RequestIDMiddleware())
+	app.Use(RequestContextMiddleware(opts.Logger))
+	app.Use(AccessLogMiddleware(opts.AccessLogSampling))
 	app.Use(CORSMiddleware())
 	app.Use(RecoveryMiddleware())
+	app.Use(AuthMiddleware(opts.APIKeys))
 }
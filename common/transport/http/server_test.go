@@ -3,12 +3,19 @@ package httptransport
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
 	"github.com/flockstore/mannaiah-backend/common/logger"
+	"github.com/flockstore/mannaiah-backend/common/openapi"
 	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,12 +31,27 @@ func TestServer_Healthz(t *testing.T) {
 	// Directly access the app via the public App() method
 	app := srv.App()
 
-	req := httptest.NewRequest("GET", "/healthz", nil)
+	req := httptest.NewRequest("GET", "/internal/healthz", nil)
 	resp, err := app.Test(req)
 	require.NoError(t, err)
 	require.Equal(t, 200, resp.StatusCode)
 }
 
+// TestServer_Readyz verifies that readiness follows the Ready callback.
+func TestServer_Readyz(t *testing.T) {
+	var ready atomic.Bool
+	app := New(Options{Ready: ready.Load}).App()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/internal/readyz", nil))
+	require.NoError(t, err)
+	require.Equal(t, 503, resp.StatusCode)
+
+	ready.Store(true)
+	resp, err = app.Test(httptest.NewRequest("GET", "/internal/readyz", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+}
+
 // TestServer_ErrorHandler verifies that the error http returns standardized JSON.
 func TestServer_ErrorHandler(t *testing.T) {
 	log := logger.New("debug", nil)
@@ -85,3 +107,109 @@ func TestServer_StartAndShutdown(t *testing.T) {
 	// Give time for shutdown to complete
 	time.Sleep(100 * time.Millisecond)
 }
+
+// TestServer_OpenAPI verifies that the OpenAPI document and docs UI are served when provided.
+func TestServer_OpenAPI(t *testing.T) {
+	srv := New(Options{
+		Logger:  logger.New("debug", nil),
+		OpenAPI: &openapi.Document{OpenAPI: openapi.Version, Info: openapi.Info{Title: "test", Version: "1"}},
+	})
+
+	resp, err := srv.App().Test(httptest.NewRequest("GET", "/internal/openapi.json", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, openapi.Version, body["openapi"])
+
+	resp, err = srv.App().Test(httptest.NewRequest("GET", "/internal/docs", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+	require.Equal(t, docsCSP, resp.Header.Get(fiber.HeaderContentSecurityPolicy))
+
+	page, _ := io.ReadAll(resp.Body)
+	require.NotContains(t, string(page), "https://", "the docs UI must not load remote assets")
+
+	for asset, contentType := range map[string]string{"docs.js": "javascript", "docs.css": "text/css"} {
+		resp, err = srv.App().Test(httptest.NewRequest("GET", "/internal/docs/assets/"+asset, nil))
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode, asset)
+		require.Contains(t, resp.Header.Get("Content-Type"), contentType)
+	}
+
+	resp, err = srv.App().Test(httptest.NewRequest("GET", "/internal/docs/assets/missing.js", nil))
+	require.NoError(t, err)
+	require.Equal(t, 404, resp.StatusCode)
+}
+
+// TestServer_Metrics verifies the Prometheus registry is exposed when configured.
+func TestServer_Metrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_total", Help: "test"})
+	reg.MustRegister(counter)
+	counter.Inc()
+
+	srv := New(Options{Logger: logger.New("debug", nil), Metrics: reg})
+
+	resp, err := srv.App().Test(httptest.NewRequest("GET", "/internal/metrics", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "test_total 1")
+
+	resp, err = New(Options{}).App().Test(httptest.NewRequest("GET", "/internal/metrics", nil))
+	require.NoError(t, err)
+	require.Equal(t, 404, resp.StatusCode)
+}
+
+// TestServer_ErrorHandlerHidesInternalErrors verifies raw errors never reach clients.
+func TestServer_ErrorHandlerHidesInternalErrors(t *testing.T) {
+	srv := New(Options{
+		Logger: logger.New("debug", nil),
+		Routes: func(r fiber.Router) {
+			r.Get("/raw", func(c *fiber.Ctx) error {
+				return errors.New("pq: password authentication failed")
+			})
+			r.Get("/fiber", func(c *fiber.Ctx) error {
+				return fiber.NewError(fiber.StatusInternalServerError, "dial tcp 10.0.0.1:5432")
+			})
+		},
+	})
+
+	for _, path := range []string{"/raw", "/fiber"} {
+		resp, err := srv.App().Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+		require.Equal(t, 500, resp.StatusCode)
+
+		var body ErrorResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		require.Equal(t, "internal server error", body.Error.Message)
+		require.Equal(t, apperrors.CodeInternal, body.Error.Reason)
+	}
+}
+
+// TestServer_ErrorHandlerRendersAppErrors verifies typed errors keep their status and code.
+func TestServer_ErrorHandlerRendersAppErrors(t *testing.T) {
+	notFound := apperrors.NotFound("thing.not_found", "thing not found")
+	srv := New(Options{
+		Logger: logger.New("debug", nil),
+		Routes: func(r fiber.Router) {
+			r.Get("/thing", func(c *fiber.Ctx) error {
+				return fmt.Errorf("lookup: %w", notFound.Wrap(errors.New("no rows in result set")))
+			})
+		},
+	})
+
+	resp, err := srv.App().Test(httptest.NewRequest("GET", "/thing", nil))
+	require.NoError(t, err)
+	require.Equal(t, 404, resp.StatusCode)
+
+	var body ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, "thing.not_found", body.Error.Reason)
+	require.Equal(t, "thing not found", body.Error.Message)
+}
@@ -0,0 +1,160 @@
+package httptransport
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	apperrors "github.com/flockstore/mannaiah-backend/common/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	// ErrValidationFailed is rendered when input fails validation; its details list every FieldError.
+	ErrValidationFailed = apperrors.InvalidArgument("request.validation_failed", "validation failed")
+
+	// ErrInvalidBody is returned when the request body cannot be decoded.
+	ErrInvalidBody = apperrors.InvalidArgument("request.invalid_body", "invalid JSON")
+
+	// ErrInvalidQuery is returned when the query string cannot be decoded.
+	ErrInvalidQuery = apperrors.InvalidArgument("request.invalid_query", "invalid query parameters")
+)
+
+// FieldError describes a single input field that failed validation.
+type FieldError struct {
+	// Field is the JSON path of the offending field (e.g. "cityCode").
+	Field string `json:"field"`
+
+	// Rule is the validation rule that failed (e.g. "len").
+	Rule string `json:"rule"`
+
+	// Param is the rule parameter, if any (e.g. "5").
+	Param string `json:"param,omitempty"`
+
+	// Message is a human-readable explanation of the failure.
+	Message string `json:"message"`
+}
+
+// ValidationError reports invalid input. The default error handler renders it
+// as a 400 response whose details list every FieldError.
+type ValidationError struct {
+	// Fields lists every invalid field.
+	Fields []FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Message
+	}
+	return "validation failed: " + strings.Join(msgs, ", ")
+}
+
+// NewValidator returns a validator that reports fields by their JSON names.
+func NewValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// NewValidationError converts validator errors into a ValidationError.
+// Errors that do not come from the validator yield a generic single-field failure.
+func NewValidationError(err error) *ValidationError {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return &ValidationError{Fields: []FieldError{{Rule: "invalid", Message: "validation failed"}}}
+	}
+
+	fields := make([]FieldError, len(errs))
+	for i, e := range errs {
+		fields[i] = FieldError{
+			Field:   fieldPath(e),
+			Rule:    e.Tag(),
+			Param:   e.Param(),
+			Message: fieldMessage(fieldPath(e), e.Tag(), e.Param(), e.Kind()),
+		}
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// fieldPath returns the JSON path of the field without the root struct name.
+func fieldPath(e validator.FieldError) string {
+	ns := e.Namespace()
+	if _, rest, ok := strings.Cut(ns, "."); ok {
+		return rest
+	}
+	return e.Field()
+}
+
+// fieldMessage renders a readable message for the most common rules.
+// Size rules are worded after the kind of the field: characters for strings,
+// items for collections and values for numbers.
+func fieldMessage(field, rule, param string, kind reflect.Kind) string {
+	switch rule {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "url", "uri":
+		return fmt.Sprintf("%s must be a valid URL", field)
+	case "numeric":
+		return fmt.Sprintf("%s must contain only digits", field)
+	case "len":
+		return sizeMessage(field, "exactly", param, kind)
+	case "min":
+		return sizeMessage(field, "at least", param, kind)
+	case "max":
+		return sizeMessage(field, "at most", param, kind)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, param)
+	case "gte", "gt", "lte", "lt":
+		return fmt.Sprintf("%s must be %s %s", field, comparison(rule), param)
+	default:
+		return fmt.Sprintf("%s failed on '%s'", field, rule)
+	}
+}
+
+// sizeMessage renders a len, min or max failure for the kind of the field.
+func sizeMessage(field, bound, param string, kind reflect.Kind) string {
+	switch kind {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if param == "1" {
+			return fmt.Sprintf("%s must contain %s 1 item", field, bound)
+		}
+		return fmt.Sprintf("%s must contain %s %s items", field, bound, param)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if bound == "exactly" {
+			return fmt.Sprintf("%s must be %s", field, param)
+		}
+		return fmt.Sprintf("%s must be %s %s", field, bound, param)
+	default:
+		return fmt.Sprintf("%s must be %s %s characters long", field, bound, param)
+	}
+}
+
+// comparison returns the wording for numeric comparison rules.
+func comparison(rule string) string {
+	switch rule {
+	case "gte":
+		return "greater than or equal to"
+	case "gt":
+		return "greater than"
+	case "lte":
+		return "less than or equal to"
+	default:
+		return "less than"
+	}
+}
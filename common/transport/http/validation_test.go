@@ -0,0 +1,79 @@
+package httptransport
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validationSample struct {
+	CityCode string `json:"cityCode" validate:"required,len=5"`
+	Email    string `json:"email" validate:"required,email"`
+}
+
+// TestNewValidationError_UsesJSONNames verifies that field errors are keyed by JSON names.
+func TestNewValidationError_UsesJSONNames(t *testing.T) {
+	err := NewValidator().Struct(validationSample{CityCode: "123", Email: "nope"})
+	require.Error(t, err)
+
+	ve := NewValidationError(err)
+	require.Len(t, ve.Fields, 2)
+	assert.Equal(t, FieldError{Field: "cityCode", Rule: "len", Param: "5", Message: "cityCode must be exactly 5 characters long"}, ve.Fields[0])
+	assert.Equal(t, "email", ve.Fields[1].Field)
+	assert.Equal(t, "email", ve.Fields[1].Rule)
+}
+
+// TestNewValidationError_SizeWording verifies len, min and max messages follow the field kind.
+func TestNewValidationError_SizeWording(t *testing.T) {
+	type sample struct {
+		Tags  []string `json:"tags" validate:"min=1"`
+		Count int      `json:"count" validate:"max=10"`
+		Code  string   `json:"code" validate:"min=3"`
+		Pair  []int    `json:"pair" validate:"len=2"`
+	}
+
+	ve := NewValidationError(NewValidator().Struct(sample{Count: 11, Code: "ab", Pair: []int{1}}))
+	require.Len(t, ve.Fields, 4)
+	assert.Equal(t, "tags must contain at least 1 item", ve.Fields[0].Message)
+	assert.Equal(t, "count must be at most 10", ve.Fields[1].Message)
+	assert.Equal(t, "code must be at least 3 characters long", ve.Fields[2].Message)
+	assert.Equal(t, "pair must contain exactly 2 items", ve.Fields[3].Message)
+}
+
+// TestNewValidationError_NonValidatorError ensures unknown errors still produce a ValidationError.
+func TestNewValidationError_NonValidatorError(t *testing.T) {
+	ve := NewValidationError(errors.New("boom"))
+	require.Len(t, ve.Fields, 1)
+}
+
+// TestServer_RendersValidationError verifies the default error handler exposes field details.
+func TestServer_RendersValidationError(t *testing.T) {
+	srv := New(Options{
+		Routes: func(r fiber.Router) {
+			r.Post("/", func(c *fiber.Ctx) error {
+				return NewValidationError(NewValidator().Struct(validationSample{}))
+			})
+		},
+	})
+
+	resp, err := srv.App().Test(httptest.NewRequest("POST", "/", nil))
+	require.NoError(t, err)
+	require.Equal(t, 400, resp.StatusCode)
+
+	var body struct {
+		Error struct {
+			Code    int          `json:"code"`
+			Details []FieldError `json:"details"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Equal(t, 400, body.Error.Code)
+	require.Len(t, body.Error.Details, 2)
+	require.Equal(t, "cityCode", body.Error.Details[0].Field)
+	require.Equal(t, "required", body.Error.Details[0].Rule)
+}